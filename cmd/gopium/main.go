@@ -2,11 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"os"
 	"os/signal"
-	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/1pkg/gopium/gopium"
 	"github.com/1pkg/gopium/runners"
@@ -21,21 +22,35 @@ var (
 	tcompiler string
 	tarch     string
 	tcpulines []int
+	tmatrix   []string
 	// package parser vars
 	ppath   string
 	pbenvs  []string
 	pbflags []string
+	ptests  string
 	// gopium walker vars
-	wregex   string
-	wdeep    bool
-	wbackref bool
+	wregex      string
+	wincludes   []string
+	wexcludes   []string
+	wfiles      []string
+	wminsize    int
+	wmaxsize    int
+	wminwaste   int
+	wexported   bool
+	wdirectives []string
+	wdeep       bool
+	wbackref    bool
+	wrev        string
 	// gopium printer vars
 	pindent   int
 	ptabwidth int
 	pusespace bool
 	pusegofmt bool
 	// gopium global vars
-	timeout int
+	timeout   int
+	keepgoing bool
+	trace     bool
+	config    string
 )
 
 // init cli command runner
@@ -43,7 +58,7 @@ var (
 func init() {
 	// set root cli command app
 	cli = &cobra.Command{
-		Use:     "gopium -flag_0 -flag_n walker package strategy_1 strategy_2 strategy_3 ...",
+		Use:     "gopium -flag_0 -flag_n [walker] package [strategy_1 strategy_2 strategy_3 ...]",
 		Short:   gopium.STAMP,
 		Version: gopium.VERSION,
 		Example: "gopium -r ^A go_std 1pkg/gopium filter_pads memory_pack separate_padding_cpu_l1_top separate_padding_cpu_l1_bottom",
@@ -55,12 +70,35 @@ Gopium is the tool that was designed to automate and simplify some common perfor
 - auto annotation
 - generic fields management, etc.
 
-In order to use gopium cli you need to provide at least package name (full package name is expected),
+In order to use gopium cli you need to provide at least package (either import path or relative dir),
 list of strategies which is applied one by one and single walker.
 Outcome of execution is fully defined by list of strategies and walker combination.
 List of strategies modifies structs inside the package, walker facilitates and insures,
 that outcome is formatted and written to one of provided destinations.
 
+Instead of single package name comma separated list of package patterns could be provided,
+like ./...,example.com/svc/... or several packages separated from strategies by dash,
+like gopium ast_go ./a ./b -- memory_pack, all matched packages are loaded at once
+from current directory (or from package path override without {{package}} template)
+and visited one by one, so file walkers write results separately for each package.
+
+Walker and list of strategies could be omitted if gopium configuration file provides them.
+Configuration file .gopium.yaml, .gopium.yml, .gopium.json or .gopium.toml is discovered
+upward from package directory, it defines default target platform, walker and printer parameters
+that are used unless relevant flags are set explicitly, and ordered list of rules:
+
+ rules:
+  - package: 1pkg/... (package glob, /... suffix matches all subpackages)
+    file: "*.go" (file base name glob)
+    struct: ^A (struct name regexp)
+    min_size: 64 (struct min size in bytes)
+    strategies: [memory_pack, cache_rounding_cpu_l1_discrete]
+ presets:
+  fast: ["@perf", struct_annotate_comment] (user defined preset @fast)
+
+The first rule that matches structure defines its strategies pipeline,
+structures that don't match any rule use strategies list provided to cli.
+
 Gopium provides next walkers:
 
  - ast_go (directly syncs result as go code to orinal file)
@@ -75,6 +113,12 @@ Gopium provides next walkers:
 	inside package directory)
  - fields_file_html_table (prints html encoded table of fields difference for results to single file
 	inside package directory)
+ - snapshot (prints json encoded layouts lock of current structures layouts to single file inside package directory)
+ - regress (prints markdown table of layouts difference between current structures layouts and baseline to stdout
+	and fails if any structure layout grows or loses packing, baseline is either layouts lock
+	or package on provided git revision)
+ - dependencies_file_md_table (prints markdown table of results fields dependencies on named structures
+	to single file inside package directory, shows whether their sizes came from optimized or original layouts)
 
 Gopium provides next strategies:
 
@@ -100,6 +144,14 @@ Gopium provides next strategies:
 	for each structure field)
 - false_sharing_bytes_{{uint}} (guards structure from false sharing by adding extra provided number of bytes paddings
 	for each structure field)
+ - isolate_fields_cpu_l1 (isolates structure fields hinted by gopium:"isolate" on their own cpu cache line #1
+	by adding extra paddings around them)
+ - isolate_fields_cpu_l2 (isolates structure fields hinted by gopium:"isolate" on their own cpu cache line #2
+	by adding extra paddings around them)
+ - isolate_fields_cpu_l3 (isolates structure fields hinted by gopium:"isolate" on their own cpu cache line #3
+	by adding extra paddings around them)
+ - isolate_fields_bytes_{{uint}} (isolates structure fields hinted by gopium:"isolate" on their own provided number
+	of bytes by adding extra paddings around them)
  - separate_padding_system_alignment_top (separates structure with extra system alignment padding by adding
 	the padding at the top)
 - separate_padding_system_alignment_bottom (separates structure with extra system alignment padding by adding
@@ -124,6 +176,12 @@ Gopium provides next strategies:
 	missing paddings for each field)
  - explicit_paddings_type_natural (explicitly aligns each structure field to max type alignment padding by adding
 	missing paddings for each field)
+ - padding_style_bytes (represents all structure paddings as plain bytes arrays _ [N]byte)
+ - padding_style_uint64 (represents structure paddings as _ [N/8]uint64 arrays that keep target uint64 alignment,
+	if it doesn't change structure layout)
+ - padding_style_cache_line (represents structure paddings of cpu cache line #1 size as _ cpu.CacheLinePad
+	from golang.org/x/sys/cpu, if it doesn't change structure layout and target arch
+	cpu.CacheLinePad size equals to cache line #1 size, otherwise as plain bytes arrays)
  - add_tag_group_soft (adds gopium fields tags annotation if no previous annotation found)
  - add_tag_group_force (adds gopium fields tags annotation if previous annotation found overwrites it)
  - add_tag_group_discrete (discretely adds gopium fields tags annotation if no previous annotation found)
@@ -138,37 +196,194 @@ Gopium provides next strategies:
  - name_lexicographical_descending (sorts fields accordingly to their names descending order)
  - type_lexicographical_ascending (sorts fields accordingly to their types in ascending order)
  - type_lexicographical_descending (sorts fields accordingly to their types in descending order)
- - filter_pads (filters out all structure padding fields)
+ - enforce_budget (fails or reports diagnostic if structure aligned size or align exceeds structure declared budget)
+ - filter_pads (filters out all structure padding fields generated by gopium)
  - ignore (does nothing by returning original structure)
 
+Gopium provides next built-in strategies presets, that are expanded to strategies lists
+and could be used anywhere strategies are expected, including cli args and gopium tags:
+
+ - @compact (filter_pads, memory_pack)
+ - @perf (@compact, cache_rounding_cpu_l1_discrete)
+ - @concurrent (filter_pads, false_sharing_cpu_l1)
+
+User defined presets could be provided by configuration file presets section,
+they override built-in presets with the same name and could reference other presets:
+
+ presets:
+  fast: ["@perf", struct_annotate_comment]
+
+Gopium also supports strategies expressions, that could be used anywhere strategies are expected:
+
+ - a | b | c (pipes strategies sequentially, same as strategies list a b c)
+ - pack, unpack (aliases for memory_pack, memory_unpack)
+ - filter(name=~"regexp", type=~"regexp") (filters out structure fields matching regexps)
+ - explicit_paddings(alignment=system|natural)
+ - false_sharing(line=1|2|3) or false_sharing(bytes=N)
+ - isolate_fields(line=1|2|3) or isolate_fields(bytes=N)
+ - cache_rounding(line=1|2|3, mode=discrete|full) or cache_rounding(bytes=N, mode=discrete|full)
+ - separate_padding(alignment=system, side=top|bottom) or separate_padding(line=1|2|3|bytes=N, side=top|bottom)
+ - all paddings functions above also accept comment=true argument, that adds explanatory comment
+	to each added padding like // false sharing guard (L1)
+ - padding_style(style=bytes|uint64|cache_line) or padding_style(type="pkg/path.Pad", line=1|2|3|bytes=N, align=N)
+	(represents structure paddings of provided size as named pad type, default size is cpu cache line #1
+	and default align is 1, restyled paddings keep gopium:"pad" tag to be recognized as padding later,
+	pad type should be fully qualified like "example.com/pkg.Pad", required imports are added to the file
+	and pad type is qualified by the file import name, pad types from the same package stay unqualified)
+ - if(predicate, then, else) (applies then if structure matches predicate and else otherwise,
+	else is optional, next predicates are supported:
+	 - size, align or fields compared with integer using ==, !=, <, <=, >, >= (size>32)
+	 - exported or pointers flags optionally compared with true or false using =, != (pointers=false)
+	 - name matched with structure name regexp using =~, !~ (name=~"^A")
+	 - type matched with any structure field type regexp using =~, !~ (type=~"^atomic\.")
+	 - directive presence checked by directive name using =, != (directive=ignore))
+ - best_of(stg, stg, ..., metric=size|ptrdata|moved|lines|class) (applies all candidates concurrently
+	and picks the result with the lowest metric: aligned size, pointer data size, number of moved fields,
+	number of cpu l1 cache lines touched or go allocator size class, default metric is size,
+	on equal scores earlier candidate wins, the winner is noted in structure comment)
+
+ gopium ast_std package 'filter(name=~"^_$") | pack | if(size>64, cache_rounding(line=1))'
+ gopium ast_std package 'best_of(pack, @perf, ignore, metric=lines)'
+ gopium ast_std package 'if(type=~"^atomic\.", false_sharing_cpu_l1) | if(size>32, cache_rounding(line=1))'
+ gopium ast_go package 'filter_pads | false_sharing(line=1, comment=true) | padding_style(style=cache_line)'
+
 Notes:
  - it might be useful to use filter_pads in pipes with other strategies to clean paddings first.
  - process_tag_group currently supports only next fields tags annotation formats:
   - gopium:"stg,stg,stg" processed as default group
   - gopium:"group:def;stg,stg,stg" processed as named group
+  - gopium:"group:def:priority=1:isolate;stg,stg,stg" processed as named group with options,
+	prioritized groups are placed first by ascending priority and then all other groups lexicographically,
+	isolated groups (isolate anchor option) are separated by cpu cache line #1 paddings,
+	isolation paddings are restyled by style=bytes|uint64|cache_line anchor option as padding_style_* do
+  - gopium:"group:def:priority=1;isolate;stg,stg,stg" isolate token that directly follows group anchor
+	isolates the group the same way as isolate anchor option, otherwise it's a field hint token
+  - gopium:"budget:size=64 align=8;..." budget token is skipped by groups processing
+  - gopium:"pin;..." fields hints tokens are skipped by groups processing
+  - strategies expressions could be used as tag strategies too, add_tag_group_* strategies write them
+	with escaped string literals like gopium:"filter(name=~\"^_$\"),memory_pack", so they are read back as is
+ - all reordering strategies (memory_pack, memory_unpack, name_lexicographical_*, type_lexicographical_*)
+	respect next fields hints tokens, that could be combined with other tag tokens:
+  - gopium:"pin" keeps field at its current index
+  - gopium:"first" or gopium:"last" moves field to structure top or bottom
+  - gopium:"order:3" moves field to provided zero based index
+  - gopium:"isolate" marks field to be isolated on its own cache line by isolate_fields_* strategies
+ - only blank fields marked with gopium:"pad" tag token are treated as gopium paddings, all added paddings
+	are marked with it, all other user blank fields like '_ [8]byte', '_ cpu.CacheLinePad' or '_ noCopy'
+	are kept by filter_pads and stay pinned
+	at their indexes by all reordering strategies unless they are hinted with first, last or order tokens
+ - structures fields are matched with their ast declarations by names, user blank fields by their occurrence
+	and embedded fields by their type names, so any number of embedded fields could be reordered
+ - ast walkers honor //gopium:merge structure doc directive, that merges back adjacent fields originally
+	declared together like 'a, b int' if they still share the same type and tag and have no field notes,
+	by default such fields are split to separate lines
+ - file and diff walkers write results for structures declared in _test.go files separately
+	to gopium_test files inside package directory, package test variants are selected by package_tests flag
+ - generic structures are optimized per instantiation, considered instantiations are either user specified
+	by //gopium:instance int64, string structure directives, or all concrete instantiations used in package
+	declarations, or worst case instantiations built from type parameters union constraints terms
+	or from uint8 and complex128 types, the first result which reordering doesn't grow any considered
+	instantiation size is written back with paddings computed for it, otherwise structure is kept as is,
+	file and diff walkers additionally report layouts for each considered instantiation
+ - with backref flag structures on each scope are visited in topological order of their type dependency graph
+	built before visiting, so named structures are optimized before structures that depend on them by value,
+	structures with cyclic references are reported as error, while references on structures filtered out
+	or declared in packages outside of visited packages are unresolved and their original layouts are used
+ - with backref flag multiple visited packages are visited in their import order, so optimized layouts
+	of imported packages structures are used by dependent packages structures, size_align_file_md_table
+	and dependencies_file_md_table walkers then additionally write gopium_module.md report to root path
+	with per package transitive structures sizes savings, target matrix mode never shares layouts across packages
+ - structures could be additionally selected by walker_include, walker_exclude, walker_file, walker_min_size,
+	walker_max_size, walker_min_waste, walker_exported and walker_directive filters, structure is skipped by the first
+	filter it doesn't pass in the same order, size filters use original layouts and ignore generic structures,
+	file, diff and dependencies walkers write skipped structures with their filters to gopium_skipped.md report
+ - failures are reported as parse, strategy, format or write errors along with failed structure identity
+	and position if any, with keep_going flag failures are collected instead of aborting execution,
+	healthy structures results are still written and failures summary is printed with exit code 2
+ - with trace flag strategies pipeline records each structure layout after every strategy stage
+	along with stage duration, size with paddings and size delta to previous stage, file and diff walkers
+	then additionally write gopium_trace.json report for json walker or gopium_trace.md report otherwise
+ - structures are identified by package path, scope path and type name like 'github.com/1pkg/pkg:F.0.A',
+	where scope path consists of enclosing function or receiver and method names and enclosing blocks
+	numbers inside their parent scopes, init and blank functions and package level function literals are additionally
+	numbered inside their package in files names order with test files last, so identities are stable across
+	unrelated edits and files and lines are only used as structures positions, colliding identities are reported
+	as parse error, snapshot and regression layouts are keyed by structures identities without package path
+ - in target matrix mode structures are unified by their identity across all entries that include structure file,
+	the first entry result which layout with its paddings isn't bigger than own result of any including entry
+	is picked, otherwise structure is kept as is, so files guarded by build constraints are processed as well
+ - fields of anonymous struct types like 'n struct{a bool; b int64}' are optimized recursively bottom up
+	with the same strategies as their parent structure, then the parent structure is optimized
+	with updated nested structures sizes and alignments, anonymous struct elements of arrays, slices
+	and pointers like 'n []*struct{a bool; b int64}' are optimized the same way
+ - process_tag_group also honors next structure doc directives:
+  - //gopium:strategies stg,stg,stg processed as default group for all untagged fields
+  - //gopium:untagged top|bottom places untagged fields at structure top or bottom
+  - //gopium:ignore skips structure processing
+  - //gopium:file-ignore placed anywhere in file comments skips all file structures processing
+ - enforce_budget reads structure budget either from //gopium:budget size=64 align=8 structure directive
+	or from gopium:"budget:size=64 align=8" field tag token, structure directive has priority.
+ - exceeded budgets are reported as non fatal diagnostics by report and check walkers (file_*, size_align_*, fields_*,
+	dependencies_* and regress) in gopium_diagnostics.md file inside package directory or stdout for regress,
+	ast_* and snapshot walkers fail on exceeded budgets instead.
  - by specifying tag_type you can automatically generate fields tags annotation suitable for process_tag_group.
  - add_tag_* strategies just add list of applied transformations to structure fields tags and NOT change results of
 	other strategies, you can execute process_tag_group strategy afterwards to reuse saved strategies list.
 		`,
-		Args: cobra.MinimumNArgs(3),
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// in case only package was provided
+			// walker is taken from config
+			walker, pkg, stgs := "", args[0], []string{}
+			if len(args) > 1 {
+				walker, pkg, stgs = args[0], args[1], args[2:]
+			}
+			// in case packages list was separated
+			// from strategies by dash
+			// join packages to patterns list
+			if dash := cmd.ArgsLenAtDash(); dash > 2 {
+				walker, pkg, stgs = args[0], strings.Join(args[1:dash], ","), args[dash:]
+			}
+			// load gopium config
+			// and apply config defaults
+			cfg, err := runners.LoadConfig(config, pkg, ppath, pbenvs, pbflags)
+			if err != nil {
+				return err
+			}
+			if walker == "" {
+				walker = cfg.Walker
+			}
+			defaults(cmd, cfg)
 			// create cli app instance
 			cli, err := runners.NewCli(
 				// target platform vars
 				tcompiler,
 				tarch,
 				tcpulines,
+				tmatrix,
 				// package parser vars
-				args[1], // package name
+				pkg, // package name
 				ppath,
 				pbenvs,
 				pbflags,
+				ptests,
 				// gopium walker vars
-				args[0], // single walker
+				walker, // single walker
 				wregex,
+				wincludes,
+				wexcludes,
+				wfiles,
+				wminsize,
+				wmaxsize,
+				wminwaste,
+				wexported,
+				wdirectives,
 				wdeep,
 				wbackref,
-				args[2:], // strategies slice
+				wrev,
+				stgs,        // strategies slice
+				cfg.Rules,   // config rules
+				cfg.Presets, // config presets
 				// gopium printer vars
 				pindent,
 				ptabwidth,
@@ -176,6 +391,8 @@ Notes:
 				pusegofmt,
 				// gopium global vars
 				timeout,
+				keepgoing,
+				trace,
 			)
 			if err != nil {
 				return err
@@ -211,16 +428,31 @@ Gopium target platform CPU cache line sizes in bytes, cache line size is set one
 For now only 3 lines of cache are supported by strategies.
 		`,
 	)
+	// set target_matrix flag
+	cli.Flags().StringSliceVarP(
+		&tmatrix,
+		"target_matrix",
+		"m",
+		[]string{},
+		`
+Gopium target platforms matrix, list of GOOS/GOARCH[/tag/tag] entries is expected, for example linux/amd64,linux/386/netgo.
+Package is loaded under each entry GOOS, GOARCH and build tags, and sizes are computed for the entry GOARCH
+instead of target_architecture, structures are unified across entries and rewritten only when result is valid
+under every entry that includes structure file.
+		`,
+	)
 	// set package_path flag
 	cli.Flags().StringVarP(
 		&ppath,
 		"package_path",
 		"p",
-		filepath.Join("src", "{{package}}"),
+		"",
 		`
-Gopium go package path, either relative or absolute path to root of the package is expected.
+Gopium go package path override, either relative or absolute path to root of the package is expected.
+By default package is located the same way go command does it, by finding enclosing go.mod or go.work,
+package could be either import path or relative dir and build flags like -mod=vendor are honored.
 To obtain full path from relative, package path is concatenated with current GOPATH env var.
-Template {{package}} part is replaced with package name.
+Template {{package}} part is replaced with package name, for example src/{{package}}.
 		`,
 	)
 	// set package_build_envs flag
@@ -239,6 +471,19 @@ Template {{package}} part is replaced with package name.
 		[]string{},
 		"Gopium go package build flags, additional list of building flags is expected.",
 	)
+	// set package_tests flag
+	cli.Flags().StringVarP(
+		&ptests,
+		"package_tests",
+		"",
+		"internal",
+		`
+Gopium go package test variants, possible values are:
+none (plain package without test files), internal (package with internal test files),
+external (plain package and external _test package) or all (package with internal test files
+and external _test package). Each structure is processed only once in single package variant.
+		`,
+	)
 	// set walker_regexp flag
 	cli.Flags().StringVarP(
 		&wregex,
@@ -250,6 +495,77 @@ Gopium walker regexp, regexp that defines which structures are subjects for visi
 Visiting is done only if structure name matches the regexp.
 		`,
 	)
+	// set walker_include flag
+	cli.Flags().StringArrayVar(
+		&wincludes,
+		"walker_include",
+		[]string{},
+		`
+Gopium walker include regexp, repeatable flag that defines additional structure name regexps,
+visiting is done only if structure name matches any of the include regexps.
+		`,
+	)
+	// set walker_exclude flag
+	cli.Flags().StringArrayVar(
+		&wexcludes,
+		"walker_exclude",
+		[]string{},
+		`
+Gopium walker exclude regexp, repeatable flag that defines structure name regexps,
+visiting is skipped if structure name matches any of the exclude regexps.
+		`,
+	)
+	// set walker_file flag
+	cli.Flags().StringArrayVar(
+		&wfiles,
+		"walker_file",
+		[]string{},
+		`
+Gopium walker file glob, repeatable flag that defines structure file globs,
+visiting is done only if structure file path or file name matches any of the globs, for example *_gen.go.
+		`,
+	)
+	// set walker_min_size flag
+	cli.Flags().IntVar(
+		&wminsize,
+		"walker_min_size",
+		0,
+		"Gopium walker min size, visiting is done only if structure original size in bytes isn't less than min size.",
+	)
+	// set walker_max_size flag
+	cli.Flags().IntVar(
+		&wmaxsize,
+		"walker_max_size",
+		0,
+		"Gopium walker max size, visiting is done only if structure original size in bytes isn't bigger than max size.",
+	)
+	// set walker_min_waste flag
+	cli.Flags().IntVar(
+		&wminwaste,
+		"walker_min_waste",
+		0,
+		`
+Gopium walker min waste, visiting is done only if structure original padding waste in bytes isn't less than min waste,
+padding waste is structure size without sizes of all its non padding fields.
+		`,
+	)
+	// set walker_exported flag
+	cli.Flags().BoolVar(
+		&wexported,
+		"walker_exported",
+		false,
+		"Gopium walker exported flag, visiting is done only for exported structures.",
+	)
+	// set walker_directive flag
+	cli.Flags().StringArrayVar(
+		&wdirectives,
+		"walker_directive",
+		[]string{},
+		`
+Gopium walker directive name, repeatable flag that defines structure doc directives names,
+visiting is done only if structure has any of the directives, for example budget or gopium:budget.
+		`,
+	)
 	// set walker_deep flag
 	cli.Flags().BoolVarP(
 		&wdeep,
@@ -272,6 +588,17 @@ Gopium walker backref flag, flag that defines type of names referencing.
 By default any previous visited types have affect on future relevant visits.
 		`,
 	)
+	// set walker_revision flag
+	cli.Flags().StringVarP(
+		&wrev,
+		"walker_revision",
+		"",
+		"",
+		`
+Gopium walker revision, git revision that is used as layouts baseline by regress walker.
+By default layouts lock from package directory is used as baseline instead.
+		`,
+	)
 	// set printer_indent flag
 	cli.Flags().IntVarP(
 		&pindent,
@@ -315,6 +642,84 @@ By default it is used and overrides other printer formatting parameters.
 		0,
 		"Gopium global timeout of cli command in seconds, considered only if value greater than 0.",
 	)
+	// set keep_going flag
+	cli.Flags().BoolVarP(
+		&keepgoing,
+		"keep_going",
+		"",
+		false,
+		`
+Gopium global keep going flag, flag that defines if structures and packages failures should be collected
+instead of aborting execution, so all healthy structures are still processed.
+Collected failures summary is printed at the end and gopium exits with code 2.
+`,
+	)
+	// set trace flag
+	cli.Flags().BoolVarP(
+		&trace,
+		"trace",
+		"",
+		false,
+		`
+Gopium global trace flag, flag that defines if strategies pipeline should record structures layouts
+after every strategy stage along with stage durations and size deltas.
+Recorded stages are written to gopium_trace report next to walker results.
+`,
+	)
+	// set config flag
+	cli.Flags().StringVarP(
+		&config,
+		"config",
+		"",
+		"",
+		`
+Gopium config file path, yaml, json or toml config file is expected.
+By default config file is discovered upward from package directory.
+		`,
+	)
+}
+
+// defaults applies config values
+// to all cli vars which flags
+// weren't set explicitly
+func defaults(cmd *cobra.Command, cfg runners.Config) {
+	// check if flag wasn't set explicitly
+	unset := func(name string) bool {
+		return !cmd.Flags().Changed(name)
+	}
+	// target platform vars
+	if cfg.Compiler != "" && unset("target_compiler") {
+		tcompiler = cfg.Compiler
+	}
+	if cfg.Arch != "" && unset("target_architecture") {
+		tarch = cfg.Arch
+	}
+	if len(cfg.CPUCaches) > 0 && unset("target_cpu_cache_lines_sizes") {
+		tcpulines = cfg.CPUCaches
+	}
+	// gopium walker vars
+	if cfg.Regex != "" && unset("walker_regexp") {
+		wregex = cfg.Regex
+	}
+	if cfg.Deep != nil && unset("walker_deep") {
+		wdeep = *cfg.Deep
+	}
+	if cfg.Backref != nil && unset("walker_backref") {
+		wbackref = *cfg.Backref
+	}
+	// gopium printer vars
+	if cfg.Indent > 0 && unset("printer_indent") {
+		pindent = cfg.Indent
+	}
+	if cfg.TabWidth > 0 && unset("printer_tab_width") {
+		ptabwidth = cfg.TabWidth
+	}
+	if cfg.UseSpace != nil && unset("printer_use_space") {
+		pusespace = *cfg.UseSpace
+	}
+	if cfg.UseGofmt != nil && unset("printer_use_gofmt") {
+		pusegofmt = *cfg.UseGofmt
+	}
 }
 
 // signals creates context with cancelation
@@ -356,6 +761,14 @@ func main() {
 	// execute cobra cli command
 	// with ctx and log error if any
 	if err := cli.ExecuteContext(ctx); err != nil {
+		// in case of collected failures
+		// log failures summary and exit
+		// with distinct exit code
+		var errs gopium.Errors
+		if errors.As(err, &errs) {
+			log.Println(err)
+			os.Exit(2)
+		}
 		log.Fatal(err)
 		os.Exit(1)
 	}
@@ -16,6 +16,11 @@ func CopyField(f gopium.Field) gopium.Field {
 		nf.Comment = make([]string, len(f.Comment), cap(f.Comment))
 		copy(nf.Comment, f.Comment)
 	}
+	// check that field nested struct exists
+	if f.Nested != nil {
+		nested := CopyStruct(*f.Nested)
+		nf.Nested = &nested
+	}
 	return nf
 }
 
@@ -33,6 +38,11 @@ func CopyStruct(s gopium.Struct) gopium.Struct {
 		ns.Comment = make([]string, len(s.Comment), cap(s.Comment))
 		copy(ns.Comment, s.Comment)
 	}
+	// check that struct directives exists
+	if s.Directives != nil {
+		ns.Directives = make([]string, len(s.Directives), cap(s.Directives))
+		copy(ns.Directives, s.Directives)
+	}
 	// check that struct fields exists
 	if s.Fields != nil {
 		ns.Fields = make([]gopium.Field, len(s.Fields), cap(s.Fields))
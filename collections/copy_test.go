@@ -45,6 +45,24 @@ func TestCopyField(t *testing.T) {
 				Comment:  []string{"test-com-1", "test-com-2"},
 			},
 		},
+		"non empty field with nested struct should be copied to same field": {
+			o: gopium.Field{
+				Name: "test",
+				Type: "struct{a int}",
+				Nested: &gopium.Struct{
+					Name:   "test",
+					Fields: []gopium.Field{{Name: "a", Type: "int", Doc: []string{"test-doc"}}},
+				},
+			},
+			r: gopium.Field{
+				Name: "test",
+				Type: "struct{a int}",
+				Nested: &gopium.Struct{
+					Name:   "test",
+					Fields: []gopium.Field{{Name: "a", Type: "int", Doc: []string{"test-doc"}}},
+				},
+			},
+		},
 	}
 	for name, tcase := range table {
 		t.Run(name, func(t *testing.T) {
@@ -54,6 +72,9 @@ func TestCopyField(t *testing.T) {
 			if !reflect.DeepEqual(r, tcase.r) {
 				t.Errorf("actual %v doesn't equal to %v", r, tcase.r)
 			}
+			if r.Nested != nil && r.Nested == tcase.o.Nested {
+				t.Errorf("actual %v nested struct isn't copied", r)
+			}
 		})
 	}
 }
@@ -88,6 +109,16 @@ func TestCopyStruct(t *testing.T) {
 				Comment: []string{"test-com-1", "test-com-2"},
 			},
 		},
+		"non empty struct with directives should be copied to same struct": {
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget size=8"},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget size=8"},
+			},
+		},
 		"non empty struct with notes and fields should be copied to same struct": {
 			o: gopium.Struct{
 				Name:    "test",
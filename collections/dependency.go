@@ -0,0 +1,34 @@
+package collections
+
+import "sort"
+
+// Dependency defines single struct field
+// dependency on named struct by value
+// with dependency size and align and
+// flag that shows whether they came
+// from optimized or original struct layout
+type Dependency struct {
+	Struct    string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Field     string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Type      string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Size      int64    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Align     int64    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Optimized bool     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_         [63]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
+
+// Dependencies defines struct fields
+// dependencies collection
+type Dependencies []Dependency
+
+// Sorted returns copy of dependencies collection
+// sorted by struct key, fields order
+// inside the same struct is kept as is
+func (ds Dependencies) Sorted() Dependencies {
+	sorted := make(Dependencies, len(ds))
+	copy(sorted, ds)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Struct < sorted[j].Struct
+	})
+	return sorted
+}
@@ -0,0 +1,43 @@
+package collections
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDependenciesSorted(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		ds Dependencies
+		r  Dependencies
+	}{
+		"nil collection should return empty sorted": {
+			ds: nil,
+			r:  Dependencies{},
+		},
+		"collection should be sorted by struct key with fields order kept": {
+			ds: Dependencies{
+				{Struct: "f.B", Field: "b"},
+				{Struct: "A", Field: "z"},
+				{Struct: "f.B", Field: "a"},
+				{Struct: "A", Field: "y"},
+			},
+			r: Dependencies{
+				{Struct: "A", Field: "z"},
+				{Struct: "A", Field: "y"},
+				{Struct: "f.B", Field: "b"},
+				{Struct: "f.B", Field: "a"},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r := tcase.ds.Sorted()
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+		})
+	}
+}
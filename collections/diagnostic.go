@@ -0,0 +1,68 @@
+package collections
+
+import (
+	"sort"
+	"sync"
+)
+
+// Diagnostic defines single struct
+// non fatal strategy diagnostic
+// with struct key, location
+// and diagnostic message
+type Diagnostic struct {
+	Struct  string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Loc     string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Message string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [16]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// Diagnostics defines structs diagnostics collection
+type Diagnostics []Diagnostic
+
+// Sorted returns copy of diagnostics
+// collection sorted by struct key,
+// diagnostics order inside the same
+// struct is kept as is
+func (ds Diagnostics) Sorted() Diagnostics {
+	sorted := make(Diagnostics, len(ds))
+	copy(sorted, ds)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Struct < sorted[j].Struct
+	})
+	return sorted
+}
+
+// Diagnoser defines single struct gopium.Diagnoser
+// implementation that collects struct diagnostics,
+// diagnoser is safe for concurrent use as strategies
+// like best_of apply candidates concurrently
+type Diagnoser struct {
+	diags Diagnostics `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	key   string      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	loc   string      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	mutex sync.Mutex  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// NewDiagnoser creates diagnoser instance
+// for struct with provided key and location
+func NewDiagnoser(key string, loc string) *Diagnoser {
+	return &Diagnoser{key: key, loc: loc}
+}
+
+// Diagnose gopium.Diagnoser implementation
+func (d *Diagnoser) Diagnose(err error) {
+	defer d.mutex.Unlock()
+	d.mutex.Lock()
+	d.diags = append(d.diags, Diagnostic{
+		Struct:  d.key,
+		Loc:     d.loc,
+		Message: err.Error(),
+	})
+}
+
+// Diagnostics returns collected struct diagnostics
+func (d *Diagnoser) Diagnostics() Diagnostics {
+	defer d.mutex.Unlock()
+	d.mutex.Lock()
+	return d.diags
+}
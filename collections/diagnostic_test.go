@@ -0,0 +1,80 @@
+package collections
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestDiagnosticsSorted(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		ds Diagnostics
+		r  Diagnostics
+	}{
+		"nil collection should return empty sorted": {
+			ds: nil,
+			r:  Diagnostics{},
+		},
+		"collection should be sorted by struct key keeping diagnostics order": {
+			ds: Diagnostics{
+				{Struct: "f.B", Message: "test-2"},
+				{Struct: "A", Message: "test-1"},
+				{Struct: "f.B", Message: "test-1"},
+			},
+			r: Diagnostics{
+				{Struct: "A", Message: "test-1"},
+				{Struct: "f.B", Message: "test-2"},
+				{Struct: "f.B", Message: "test-1"},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r := tcase.ds.Sorted()
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+		})
+	}
+}
+
+func TestDiagnoser(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		errs []error
+		r    Diagnostics
+	}{
+		"empty diagnoser should return empty diagnostics": {},
+		"diagnoser should return expected diagnostics": {
+			errs: []error{errors.New("test-1"), errors.New("test-1")},
+			r: Diagnostics{
+				{Struct: "f.A", Loc: "pkg/file.go:10", Message: "test-1"},
+				{Struct: "f.A", Loc: "pkg/file.go:10", Message: "test-1"},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			d := NewDiagnoser("f.A", "pkg/file.go:10")
+			var wg sync.WaitGroup
+			for _, err := range tcase.errs {
+				wg.Add(1)
+				go func(err error) {
+					defer wg.Done()
+					d.Diagnose(err)
+				}(err)
+			}
+			wg.Wait()
+			r := d.Diagnostics()
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package collections
 
 import (
-	"fmt"
 	"sort"
 	"strings"
 
@@ -14,8 +13,9 @@ type Flat map[string]gopium.Struct
 
 // Sorted converts flat collection
 // to sorted slice of structs
-// note: it's possible due to next:
-// generated ids are ordered inside same loc
+// note: structs are sorted by their ids
+// which are stable identities made of
+// package path, scope path and type name
 func (f Flat) Sorted() []gopium.Struct {
 	// preapare ids and sorted slice
 	ids := make([]string, 0, len(f))
@@ -25,34 +25,33 @@ func (f Flat) Sorted() []gopium.Struct {
 		ids = append(ids, id)
 	}
 	// sort all ids in asc order
-	sort.SliceStable(ids, func(i, j int) bool {
-		// only first part of "%d-%s" id is ordered
-		// so we need to parse and compare it
-		var numi, numj int
-		var sumi, sumj string
-		// scanf works only with space
-		// separated values so we need
-		// to apply this format first
-		//
-		// in case of any pattern error
-		// just apply natural sort
-		// otherwise sort it by id
-		_, erri := fmt.Sscanf(strings.Replace(ids[i], ":", " ", 1), "%s %d", &sumi, &numi)
-		_, errj := fmt.Sscanf(strings.Replace(ids[j], ":", " ", 1), "%s %d", &sumj, &numj)
-		switch {
-		case erri != nil && errj != nil:
-			return ids[i] < ids[j]
-		case erri != nil:
-			return false
-		case errj != nil:
-			return true
-		default:
-			return numi < numj
-		}
-	})
+	sort.Strings(ids)
 	// collect all structs in asc order
 	for _, id := range ids {
 		sorted = append(sorted, f[id])
 	}
 	return sorted
 }
+
+// Local converts flat collection
+// to flat collection keyed by
+// local ids without package path
+// note: it's possible due to next:
+// local ids are unique inside single package
+func (f Flat) Local() Flat {
+	local := make(Flat, len(f))
+	for id, st := range f {
+		local[Local(id)] = st
+	}
+	return local
+}
+
+// Local returns local part of id
+// without package path `F.0.A`
+// or id itself if it has no package path
+func Local(id string) string {
+	if i := strings.Index(id, ":"); i >= 0 {
+		return id[i+1:]
+	}
+	return id
+}
@@ -22,122 +22,138 @@ func TestFlatSorted(t *testing.T) {
 			r: []gopium.Struct{},
 		},
 		"single item flat collection should return single item sorted": {
-			f: Flat{"test:1": gopium.Struct{Name: "test1"}},
-			r: []gopium.Struct{{Name: "test1"}},
+			f: Flat{"test:A": gopium.Struct{Name: "A"}},
+			r: []gopium.Struct{{Name: "A"}},
 		},
 		"multiple presorted items flat collection should return multiple items sorted": {
 			f: Flat{
-				"test:1": gopium.Struct{Name: "test1"},
-				"test:2": gopium.Struct{Name: "test2"},
-				"test:3": gopium.Struct{Name: "test3"},
+				"test:A": gopium.Struct{Name: "A"},
+				"test:B": gopium.Struct{Name: "B"},
+				"test:C": gopium.Struct{Name: "C"},
 			},
 			r: []gopium.Struct{
-				{Name: "test1"},
-				{Name: "test2"},
-				{Name: "test3"},
+				{Name: "A"},
+				{Name: "B"},
+				{Name: "C"},
 			},
 		},
 		"multiple reverted items flat collection should return multiple items sorted": {
 			f: Flat{
-				"test:3": gopium.Struct{Name: "test3"},
-				"test:2": gopium.Struct{Name: "test2"},
-				"test:1": gopium.Struct{Name: "test1"},
+				"test:C": gopium.Struct{Name: "C"},
+				"test:B": gopium.Struct{Name: "B"},
+				"test:A": gopium.Struct{Name: "A"},
 			},
 			r: []gopium.Struct{
-				{Name: "test1"},
-				{Name: "test2"},
-				{Name: "test3"},
+				{Name: "A"},
+				{Name: "B"},
+				{Name: "C"},
 			},
 		},
-		"multiple mixed items flat collection should return multiple items sorted": {
+		"multiple mixed scopes items flat collection should return items sorted by identity": {
 			f: Flat{
-				"test:99":   gopium.Struct{Name: "test99"},
-				"test:5":    gopium.Struct{Name: "test5"},
-				"test:1000": gopium.Struct{Name: "test1000"},
-				"test:3":    gopium.Struct{Name: "test3"},
-				"test:1":    gopium.Struct{Name: "test1"},
-				"test:2":    gopium.Struct{Name: "test2"},
-				"test:4":    gopium.Struct{Name: "test4"},
-				"test:0":    gopium.Struct{Name: "test0"},
+				"test:f.0.A":  gopium.Struct{Name: "f.0.A"},
+				"test:f.A":    gopium.Struct{Name: "f.A"},
+				"test:T.M.A":  gopium.Struct{Name: "T.M.A"},
+				"test:A":      gopium.Struct{Name: "A"},
+				"test:init.0": gopium.Struct{Name: "init.0"},
+				"test:b":      gopium.Struct{Name: "b"},
 			},
 			r: []gopium.Struct{
-				{Name: "test0"},
-				{Name: "test1"},
-				{Name: "test2"},
-				{Name: "test3"},
-				{Name: "test4"},
-				{Name: "test5"},
-				{Name: "test99"},
-				{Name: "test1000"},
+				{Name: "A"},
+				{Name: "T.M.A"},
+				{Name: "b"},
+				{Name: "f.0.A"},
+				{Name: "f.A"},
+				{Name: "init.0"},
 			},
 		},
-		"multiple non pattern ids items flat collection should return items sorted naturally": {
+		"multiple mixed packages items flat collection should return items sorted by identity": {
 			f: Flat{
-				"test:a": gopium.Struct{Name: "testa"},
-				"test:b": gopium.Struct{Name: "testb"},
-				"test:c": gopium.Struct{Name: "testc"},
+				"test/b:A": gopium.Struct{Name: "b.A"},
+				"test/a:B": gopium.Struct{Name: "a.B"},
+				"test/a:A": gopium.Struct{Name: "a.A"},
 			},
 			r: []gopium.Struct{
-				{Name: "testa"},
-				{Name: "testb"},
-				{Name: "testc"},
+				{Name: "a.A"},
+				{Name: "a.B"},
+				{Name: "b.A"},
 			},
 		},
-		"multiple mixed non pattern ids items flat collection should return items sorted naturally": {
-			f: Flat{
-				"test:3": gopium.Struct{Name: "test3"},
-				"test:2": gopium.Struct{Name: "test2"},
-				"test:1": gopium.Struct{Name: "test1"},
-				"test:a": gopium.Struct{Name: "testa"},
-				"test:b": gopium.Struct{Name: "testb"},
-				"test:c": gopium.Struct{Name: "testc"},
-			},
-			r: []gopium.Struct{
-				{Name: "test1"},
-				{Name: "test2"},
-				{Name: "test3"},
-				{Name: "testa"},
-				{Name: "testb"},
-				{Name: "testc"},
-			},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r := tcase.f.Sorted()
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+		})
+	}
+}
+
+func TestFlatLocal(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		f Flat
+		r Flat
+	}{
+		"nil flat collection should return empty local": {
+			f: nil,
+			r: Flat{},
 		},
-		"complex multiple mixed non pattern ids items flat collection should return items sorted naturally": {
+		"flat collection should return local ids collection": {
 			f: Flat{
-				"test:z":    gopium.Struct{Name: "testz"},
-				"test:3":    gopium.Struct{Name: "test3"},
-				"test:2":    gopium.Struct{Name: "test2"},
-				"test:1":    gopium.Struct{Name: "test1"},
-				"test:a":    gopium.Struct{Name: "testa"},
-				"test:b":    gopium.Struct{Name: "testb"},
-				"test:c":    gopium.Struct{Name: "testc"},
-				"test:99":   gopium.Struct{Name: "test99"},
-				"test:5":    gopium.Struct{Name: "test5"},
-				"test:1000": gopium.Struct{Name: "test1000"},
-				"test:4":    gopium.Struct{Name: "test4"},
-				"test:0":    gopium.Struct{Name: "test0"},
-				"test:xy":   gopium.Struct{Name: "testxy"},
+				"test/a:A":     gopium.Struct{Name: "A"},
+				"test/a:f.0.B": gopium.Struct{Name: "B"},
+				"C":            gopium.Struct{Name: "C"},
 			},
-			r: []gopium.Struct{
-				{Name: "test0"},
-				{Name: "test1"},
-				{Name: "test2"},
-				{Name: "test3"},
-				{Name: "test4"},
-				{Name: "test5"},
-				{Name: "test99"},
-				{Name: "test1000"},
-				{Name: "testa"},
-				{Name: "testb"},
-				{Name: "testc"},
-				{Name: "testxy"},
-				{Name: "testz"},
+			r: Flat{
+				"A":     gopium.Struct{Name: "A"},
+				"f.0.B": gopium.Struct{Name: "B"},
+				"C":     gopium.Struct{Name: "C"},
 			},
 		},
 	}
 	for name, tcase := range table {
 		t.Run(name, func(t *testing.T) {
 			// exec
-			r := tcase.f.Sorted()
+			r := tcase.f.Local()
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+		})
+	}
+}
+
+func TestLocal(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		id string
+		r  string
+	}{
+		"empty id should return empty local id": {
+			id: "",
+			r:  "",
+		},
+		"id without package should return id itself": {
+			id: "f.0.A",
+			r:  "f.0.A",
+		},
+		"id with empty package should return local id": {
+			id: ":A",
+			r:  "A",
+		},
+		"id with package should return local id": {
+			id: "github.com/1pkg/gopium/tests/data/nested:scope2.0.a1",
+			r:  "scope2.0.a1",
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r := Local(tcase.id)
 			// check
 			if !reflect.DeepEqual(r, tcase.r) {
 				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
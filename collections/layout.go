@@ -0,0 +1,62 @@
+package collections
+
+import (
+	"sort"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// Layout defines single struct layout
+// baseline data transfer object
+type Layout struct {
+	Fields []string `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Size   int64    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Align  int64    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Ptr    int64    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Pad    int64    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_      [8]byte  `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// NewLayout creates struct layout
+// from provided gopium struct
+func NewLayout(st gopium.Struct) Layout {
+	// calculate aligned size, align and ptr
+	size, align := SizeAlign(st)
+	ptr := PtrData(st)
+	// collect fields order and
+	// total size of all fields
+	var fsize int64
+	fields := make([]string, 0, len(st.Fields))
+	for _, f := range st.Fields {
+		fields = append(fields, f.Name)
+		fsize += f.Size
+	}
+	return Layout{
+		Fields: fields,
+		Size:   size,
+		Align:  align,
+		Ptr:    ptr,
+		Pad:    size - fsize,
+	}
+}
+
+// Layouts defines struct layouts collection
+// which is categorized by struct key
+type Layouts map[string]Layout
+
+// Regressed returns sorted list of keys
+// of struct layouts that either grow
+// or lose packing in provided layouts
+// comparing to layouts baseline
+func (ls Layouts) Regressed(r Layouts) []string {
+	keys := make([]string, 0, len(ls))
+	for key, lo := range ls {
+		// if both collections contains
+		// struct layout, compare them
+		if lr, ok := r[key]; ok && (lr.Size > lo.Size || lr.Pad > lo.Pad) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,121 @@
+package collections
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestNewLayout(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		st gopium.Struct
+		l  Layout
+	}{
+		"empty struct should return expected layout": {
+			l: Layout{
+				Fields: []string{},
+				Align:  1,
+			},
+		},
+		"non empty struct should return expected layout": {
+			st: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "string",
+						Size:  16,
+						Align: 8,
+						Ptr:   8,
+					},
+					{
+						Name:  "test3",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			l: Layout{
+				Fields: []string{"test1", "test2", "test3"},
+				Size:   32,
+				Align:  8,
+				Ptr:    16,
+				Pad:    14,
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			l := NewLayout(tcase.st)
+			// check
+			if !reflect.DeepEqual(l, tcase.l) {
+				t.Errorf("actual %v doesn't equal to %v", l, tcase.l)
+			}
+		})
+	}
+}
+
+func TestLayoutsRegressed(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		o    Layouts
+		r    Layouts
+		keys []string
+	}{
+		"empty layouts should return empty keys": {
+			keys: []string{},
+		},
+		"same layouts should return empty keys": {
+			o: Layouts{
+				"a": {Size: 16, Pad: 2},
+			},
+			r: Layouts{
+				"a": {Size: 16, Pad: 2},
+			},
+			keys: []string{},
+		},
+		"improved layouts should return empty keys": {
+			o: Layouts{
+				"a": {Size: 24, Pad: 10},
+			},
+			r: Layouts{
+				"a": {Size: 16, Pad: 2},
+				"b": {Size: 64, Pad: 20},
+			},
+			keys: []string{},
+		},
+		"regressed layouts should return expected keys": {
+			o: Layouts{
+				"c": {Size: 16, Pad: 2},
+				"a": {Size: 16, Pad: 2},
+				"b": {Size: 16, Pad: 2},
+			},
+			r: Layouts{
+				"c": {Size: 24, Pad: 10},
+				"a": {Size: 16, Pad: 4},
+				"b": {Size: 16, Pad: 2},
+			},
+			keys: []string{"a", "c"},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			keys := tcase.o.Regressed(tcase.r)
+			// check
+			if !reflect.DeepEqual(keys, tcase.keys) {
+				t.Errorf("actual %v doesn't equal to %v", keys, tcase.keys)
+			}
+		})
+	}
+}
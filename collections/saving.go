@@ -0,0 +1,18 @@
+package collections
+
+// Saving defines package structs sizes
+// saving data transfer object, original size
+// is calculated with original layouts of all
+// nested structs, while current size is calculated
+// with their optimized layouts, so it shows
+// transitive saving of package structs
+type Saving struct {
+	Structs  int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Original int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Current  int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_        [8]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
+
+// Savings defines structs sizes savings
+// collection which is categorized by package path
+type Savings map[string]Saving
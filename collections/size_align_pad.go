@@ -3,6 +3,8 @@ package collections
 import (
 	"fmt"
 	"math"
+	"reflect"
+	"strings"
 
 	"github.com/1pkg/gopium/gopium"
 )
@@ -76,8 +78,60 @@ func SizeAlign(st gopium.Struct) (int64, int64) {
 	return alsize, align
 }
 
+// PtrData calculates sturct pointer data size,
+// prefix of struct bytes that contains pointers,
+// by using walk struct helper
+func PtrData(st gopium.Struct) int64 {
+	// preset defaults
+	var offset, ptr int64
+	WalkStruct(st, 0, func(pad int64, fields ...gopium.Field) {
+		// add pad to current offset
+		offset += pad
+		// go through fields
+		for _, f := range fields {
+			// if field contains pointers
+			// move pointer data end
+			if f.Ptr > 0 {
+				ptr = offset + f.Ptr
+			}
+			// add field size to offset
+			offset += f.Size
+		}
+	})
+	return ptr
+}
+
+// sizeclasses defines go runtime
+// memory allocator size classes
+// note: copied from `runtime/sizeclasses.go`
+var sizeclasses = []int64{
+	0, 8, 16, 32, 48, 64, 80, 96, 112, 128, 144, 160, 176, 192, 208, 224, 240, 256,
+	288, 320, 352, 384, 416, 448, 480, 512, 576, 640, 704, 768, 896, 1024, 1152, 1280,
+	1408, 1536, 1792, 2048, 2304, 2688, 3072, 3200, 3456, 4096, 4864, 5376, 6144, 6528,
+	6784, 6912, 8192, 9472, 9728, 10240, 10880, 12288, 13568, 14336, 16384, 18432, 19072,
+	20480, 21760, 24576, 27264, 28672, 32768,
+}
+
+// SizeClass calculates size that go runtime memory
+// allocator actually uses for heap object of provided size,
+// which is the smallest size class that fits the size
+// or the size rounded to page size for large objects
+func SizeClass(size int64) int64 {
+	// go through all size classes
+	// and find first that fits the size
+	for _, class := range sizeclasses {
+		if class >= size {
+			return class
+		}
+	}
+	// large objects are rounded to pages
+	return Align(size, 8192)
+}
+
 // PadField defines helper that
-// creates pad field with specified size
+// creates pad field with specified size,
+// pad field is marked with `gopium:"pad"` tag
+// so it could be recognized as pad later
 func PadField(pad int64) gopium.Field {
 	pad = int64(math.Max(0, float64(pad)))
 	return gopium.Field{
@@ -85,7 +139,30 @@ func PadField(pad int64) gopium.Field {
 		Type:  fmt.Sprintf("[%d]byte", pad),
 		Size:  pad,
 		Align: 1,
+		Tag:   fmt.Sprintf("%s:%q", gopium.NAME, padtoken),
+	}
+}
+
+// padtoken defines pad field gopium tag token
+const padtoken = "pad"
+
+// IsPad checks if field is pad field
+// created by pad field helper or restyled
+// from it, so it's blank field which gopium tag
+// has pad token `gopium:"pad;group:def;stg"`,
+// other blank fields like `_ [8]byte`
+// or `_ cpu.CacheLinePad` are not treated as pads
+func IsPad(f gopium.Field) bool {
+	if f.Name != "_" {
+		return false
+	}
+	tag := reflect.StructTag(f.Tag).Get(gopium.NAME)
+	for _, token := range strings.Split(tag, ";") {
+		if strings.TrimSpace(token) == padtoken {
+			return true
+		}
 	}
+	return false
 }
 
 // Align returns the smallest y >= x such that y % a == 0.
@@ -87,6 +87,118 @@ func TestSizeAlign(t *testing.T) {
 	}
 }
 
+func TestPtrData(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		st  gopium.Struct
+		ptr int64
+	}{
+		"empty struct should return empty ptr data": {
+			ptr: 0,
+		},
+		"struct without pointers should return empty ptr data": {
+			st: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test2",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			ptr: 0,
+		},
+		"struct with pointers should return expected ptr data": {
+			st: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "string",
+						Size:  16,
+						Align: 8,
+						Ptr:   8,
+					},
+					{
+						Name:  "test3",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			ptr: 16,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			ptr := PtrData(tcase.st)
+			// check
+			if !reflect.DeepEqual(ptr, tcase.ptr) {
+				t.Errorf("actual %v doesn't equal to %v", ptr, tcase.ptr)
+			}
+		})
+	}
+}
+
+func TestSizeClass(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		size  int64
+		class int64
+	}{
+		"zero size should return zero size class": {
+			size:  0,
+			class: 0,
+		},
+		"exact size class size should return same size class": {
+			size:  48,
+			class: 48,
+		},
+		"small size should return next size class": {
+			size:  49,
+			class: 64,
+		},
+		"medium size should return next size class": {
+			size:  2500,
+			class: 2688,
+		},
+		"max size class size should return same size class": {
+			size:  32768,
+			class: 32768,
+		},
+		"large size should return size rounded to page": {
+			size:  32769,
+			class: 40960,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			class := SizeClass(tcase.size)
+			// check
+			if !reflect.DeepEqual(class, tcase.class) {
+				t.Errorf("actual %v doesn't equal to %v", class, tcase.class)
+			}
+		})
+	}
+}
+
 func TestPadField(t *testing.T) {
 	// prepare
 	table := map[string]struct {
@@ -99,6 +211,7 @@ func TestPadField(t *testing.T) {
 				Type:  "[0]byte",
 				Size:  0,
 				Align: 1,
+				Tag:   `gopium:"pad"`,
 			},
 		},
 		"positive pad should return valid field pad": {
@@ -108,6 +221,7 @@ func TestPadField(t *testing.T) {
 				Type:  "[10]byte",
 				Size:  10,
 				Align: 1,
+				Tag:   `gopium:"pad"`,
 			},
 		},
 		"negative pad should return empty field": {
@@ -117,6 +231,7 @@ func TestPadField(t *testing.T) {
 				Type:  "[0]byte",
 				Size:  0,
 				Align: 1,
+				Tag:   `gopium:"pad"`,
 			},
 		},
 	}
@@ -131,3 +246,54 @@ func TestPadField(t *testing.T) {
 		})
 	}
 }
+
+func TestIsPad(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		f   gopium.Field
+		pad bool
+	}{
+		"pad field should be pad": {
+			f:   PadField(8),
+			pad: true,
+		},
+		"marked blank uint8 array field should be pad": {
+			f:   gopium.Field{Name: "_", Type: "[16]uint8", Size: 16, Align: 1, Tag: `gopium:"pad"`},
+			pad: true,
+		},
+		"marked blank uint64 array field with other tokens should be pad": {
+			f:   gopium.Field{Name: "_", Type: "[8]uint64", Size: 64, Align: 8, Tag: `json:"-" gopium:"pad;group:def;filter_pads"`},
+			pad: true,
+		},
+		"marked blank named pad type field should be pad": {
+			f:   gopium.Field{Name: "_", Type: "golang.org/x/sys/cpu.CacheLinePad", Size: 64, Align: 1, Tag: `gopium:"pad"`},
+			pad: true,
+		},
+		"marked named byte array field shouldn't be pad": {
+			f:   gopium.Field{Name: "test", Type: "[8]byte", Size: 8, Align: 1, Tag: `gopium:"pad"`},
+			pad: false,
+		},
+		"unmarked blank byte array field shouldn't be pad": {
+			f:   gopium.Field{Name: "_", Type: "[8]byte", Size: 8, Align: 1},
+			pad: false,
+		},
+		"unmarked blank named pad type field shouldn't be pad": {
+			f:   gopium.Field{Name: "_", Type: "golang.org/x/sys/cpu.CacheLinePad", Size: 64, Align: 1},
+			pad: false,
+		},
+		"blank field with other gopium tokens shouldn't be pad": {
+			f:   gopium.Field{Name: "_", Type: "[0]func()", Size: 0, Align: 8, Tag: `gopium:"pin;pads"`},
+			pad: false,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			pad := IsPad(tcase.f)
+			// check
+			if !reflect.DeepEqual(pad, tcase.pad) {
+				t.Errorf("actual %v doesn't equal to %v", pad, tcase.pad)
+			}
+		})
+	}
+}
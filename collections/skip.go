@@ -0,0 +1,28 @@
+package collections
+
+import "sort"
+
+// Skip defines single struct
+// skipped by selection filters
+// with struct key, location and
+// description of skipping filter
+type Skip struct {
+	Struct string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Loc    string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Filter string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_      [16]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// Skips defines skipped structs collection
+type Skips []Skip
+
+// Sorted returns copy of skipped structs
+// collection sorted by struct key
+func (ss Skips) Sorted() Skips {
+	sorted := make(Skips, len(ss))
+	copy(sorted, ss)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Struct < sorted[j].Struct
+	})
+	return sorted
+}
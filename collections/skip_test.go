@@ -0,0 +1,41 @@
+package collections
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSkipsSorted(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		ss Skips
+		r  Skips
+	}{
+		"nil collection should return empty sorted": {
+			ss: nil,
+			r:  Skips{},
+		},
+		"collection should be sorted by struct key": {
+			ss: Skips{
+				{Struct: "f.B", Filter: "exported"},
+				{Struct: "A", Filter: "min_size 8"},
+				{Struct: "C", Filter: `exclude "C"`},
+			},
+			r: Skips{
+				{Struct: "A", Filter: "min_size 8"},
+				{Struct: "C", Filter: `exclude "C"`},
+				{Struct: "f.B", Filter: "exported"},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r := tcase.ss.Sorted()
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+		})
+	}
+}
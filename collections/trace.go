@@ -0,0 +1,75 @@
+package collections
+
+import (
+	"sort"
+	"time"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// Stage defines single struct strategies
+// pipeline stage trace with struct key and location,
+// stage strategy name, struct after the stage,
+// stage duration, struct size after the stage
+// and size delta against struct before the stage
+type Stage struct {
+	Result   gopium.Struct `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Struct   string        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Loc      string        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Strategy string        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Duration time.Duration `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Size     int64         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Delta    int64         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_        [8]byte       `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 192 bytes; struct align: 8 bytes; struct aligned size: 192 bytes; - 🌺 gopium @1pkg
+
+// Stages defines structs strategies
+// pipeline stages traces collection
+type Stages []Stage
+
+// Sorted returns copy of stages collection
+// sorted by struct key, stages order
+// inside the same struct is kept as is
+func (ss Stages) Sorted() Stages {
+	sorted := make(Stages, len(ss))
+	copy(sorted, ss)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Struct < sorted[j].Struct
+	})
+	return sorted
+}
+
+// Tracer defines single struct gopium.Tracer implementation
+// that collects struct strategies pipeline stages
+type Tracer struct {
+	stages Stages  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	key    string  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	loc    string  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_      [8]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// NewTracer creates tracer instance
+// for struct with provided key and location
+func NewTracer(key string, loc string) *Tracer {
+	return &Tracer{key: key, loc: loc}
+}
+
+// Trace gopium.Tracer implementation
+func (t *Tracer) Trace(name gopium.StrategyName, o gopium.Struct, r gopium.Struct, d time.Duration) {
+	osize, _ := SizeAlign(o)
+	rsize, _ := SizeAlign(r)
+	t.stages = append(t.stages, Stage{
+		Struct:   t.key,
+		Loc:      t.loc,
+		Strategy: string(name),
+		Result:   CopyStruct(r),
+		Duration: d,
+		Size:     rsize,
+		Delta:    rsize - osize,
+	})
+}
+
+// Stages returns collected struct stages
+func (t *Tracer) Stages() Stages {
+	return t.stages
+}
@@ -0,0 +1,117 @@
+package collections
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestStagesSorted(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		ss Stages
+		r  Stages
+	}{
+		"nil collection should return empty sorted": {
+			ss: nil,
+			r:  Stages{},
+		},
+		"collection should be sorted by struct key keeping stages order": {
+			ss: Stages{
+				{Struct: "f.B", Strategy: "memory_pack"},
+				{Struct: "A", Strategy: "filter_pads"},
+				{Struct: "f.B", Strategy: "filter_pads"},
+				{Struct: "A", Strategy: "memory_pack"},
+			},
+			r: Stages{
+				{Struct: "A", Strategy: "filter_pads"},
+				{Struct: "A", Strategy: "memory_pack"},
+				{Struct: "f.B", Strategy: "memory_pack"},
+				{Struct: "f.B", Strategy: "filter_pads"},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r := tcase.ss.Sorted()
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+		})
+	}
+}
+
+func TestTracer(t *testing.T) {
+	// prepare
+	o := gopium.Struct{
+		Name: "A",
+		Fields: []gopium.Field{
+			{Name: "a", Size: 1, Align: 1},
+			{Name: "b", Size: 8, Align: 8},
+			{Name: "c", Size: 1, Align: 1},
+		},
+	}
+	r := gopium.Struct{
+		Name: "A",
+		Fields: []gopium.Field{
+			{Name: "b", Size: 8, Align: 8},
+			{Name: "a", Size: 1, Align: 1},
+			{Name: "c", Size: 1, Align: 1},
+		},
+	}
+	type trace struct {
+		name gopium.StrategyName
+		o    gopium.Struct
+		r    gopium.Struct
+		d    time.Duration
+	}
+	table := map[string]struct {
+		traces []trace
+		r      Stages
+	}{
+		"empty tracer should return empty stages": {},
+		"tracer should return expected stages": {
+			traces: []trace{
+				{name: "test-1", o: o, r: r, d: time.Second},
+				{name: "test-2", o: r, r: r, d: 2 * time.Second},
+			},
+			r: Stages{
+				{
+					Struct:   "f.A",
+					Loc:      "pkg/file.go:10",
+					Strategy: "test-1",
+					Result:   r,
+					Duration: time.Second,
+					Size:     16,
+					Delta:    -8,
+				},
+				{
+					Struct:   "f.A",
+					Loc:      "pkg/file.go:10",
+					Strategy: "test-2",
+					Result:   r,
+					Duration: 2 * time.Second,
+					Size:     16,
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			tr := NewTracer("f.A", "pkg/file.go:10")
+			for _, trace := range tcase.traces {
+				tr.Trace(trace.name, trace.o, trace.r, trace.d)
+			}
+			r := tr.Stages()
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+		})
+	}
+}
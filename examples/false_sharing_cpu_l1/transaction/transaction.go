@@ -19,7 +19,7 @@ type transaction struct {
 // aggregate defines compressed set of transactions
 type aggregate struct {
 	total float64  `gopium:"filter_pads,false_sharing_cpu_l1,struct_annotate_comment,add_tag_group_force"`
-	_     [56]byte `gopium:"filter_pads,false_sharing_cpu_l1,struct_annotate_comment,add_tag_group_force"`
+	_     [56]byte `gopium:"pad;filter_pads,false_sharing_cpu_l1,struct_annotate_comment,add_tag_group_force"`
 } // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
 
 // generate creates n pseudo random transactions
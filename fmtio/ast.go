@@ -3,10 +3,15 @@ package fmtio
 import (
 	"fmt"
 	"go/ast"
+	"go/parser"
 	"go/token"
+	"go/types"
+	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 
+	"github.com/1pkg/gopium/collections"
 	"github.com/1pkg/gopium/gopium"
 )
 
@@ -16,6 +21,7 @@ import (
 // - shuffle helper
 // - padsync helper
 // - tagsync helper
+// - merge helper
 // - reindex helper
 var FSPT = combine(
 	flatten,
@@ -23,15 +29,20 @@ var FSPT = combine(
 	shuffle,
 	padsync,
 	tagsync,
+	merge,
 	reindex,
 )
 
 // combine helps to pipe several
-// ast helpers to single ast func
+// ast helpers to single ast func,
+// which is also applied recursively
+// to all anonymous nested struct types
 func combine(funcs ...gopium.Ast) gopium.Ast {
-	return func(ts *ast.TypeSpec, st gopium.Struct) error {
+	var combined gopium.Ast
+	combined = func(ts *ast.TypeSpec, st gopium.Struct) error {
 		// check that we are working with ast struct type
-		if _, ok := ts.Type.(*ast.StructType); !ok {
+		tts, ok := ts.Type.(*ast.StructType)
+		if !ok {
 			return fmt.Errorf("type %q is not valid structure", ts.Name.Name)
 		}
 		// go through all provided funcs
@@ -44,8 +55,26 @@ func combine(funcs ...gopium.Ast) gopium.Ast {
 				return err
 			}
 		}
+		// go through all synced fields
+		// and apply funcs to nested structs
+		for index, f := range st.Fields {
+			if f.Nested == nil || index >= len(tts.Fields.List) {
+				continue
+			}
+			if nts, ok := NestedStruct(tts.Fields.List[index].Type); ok {
+				// nested structs inherit
+				// parent structure directives
+				nested := &ast.TypeSpec{Name: ts.Name, Type: nts}
+				nst := *f.Nested
+				nst.Directives = st.Directives
+				if err := combined(nested, nst); err != nil {
+					return err
+				}
+			}
+		}
 		return nil
 	}
+	return combined
 }
 
 // flatten helps to make ast struct type
@@ -64,6 +93,11 @@ func flatten(ts *ast.TypeSpec, st gopium.Struct) error {
 			f := *field
 			// update names slice
 			f.Names = []*ast.Ident{name}
+			// copy anonymous nested struct type
+			// so each field could be synced separately
+			if _, ok := NestedStruct(field.Type); ok {
+				f.Type = nstcopy(field.Type)
+			}
 			// put it to result slice
 			fields = append(fields, &f)
 		}
@@ -78,16 +112,64 @@ func flatten(ts *ast.TypeSpec, st gopium.Struct) error {
 	return nil
 }
 
+// NestedStruct helps to unwrap anonymous nested
+// struct type from arrays, slices and pointers
+// element types `[N]struct{}`, `[]struct{}`, `*struct{}`
+func NestedStruct(expr ast.Expr) (*ast.StructType, bool) {
+	for {
+		switch tp := expr.(type) {
+		case *ast.StructType:
+			return tp, true
+		case *ast.ArrayType:
+			expr = tp.Elt
+		case *ast.StarExpr:
+			expr = tp.X
+		case *ast.ParenExpr:
+			expr = tp.X
+		default:
+			return nil, false
+		}
+	}
+}
+
+// nstcopy helps to copy anonymous nested
+// struct type with all its wrappers types
+func nstcopy(expr ast.Expr) ast.Expr {
+	switch tp := expr.(type) {
+	case *ast.StructType:
+		nst, nfields := *tp, *tp.Fields
+		nst.Fields = &nfields
+		return &nst
+	case *ast.ArrayType:
+		at := *tp
+		at.Elt = nstcopy(tp.Elt)
+		return &at
+	case *ast.StarExpr:
+		se := *tp
+		se.X = nstcopy(tp.X)
+		return &se
+	case *ast.ParenExpr:
+		pe := *tp
+		pe.X = nstcopy(tp.X)
+		return &pe
+	default:
+		return expr
+	}
+}
+
 // fpadfilter helps to filter fields and pads
 // from fields list for original ast type spec
-// accordingly to result gopium struct
+// accordingly to result gopium struct,
+// user blank fields aren't treated as pads
 func fpadfilter(ts *ast.TypeSpec, st gopium.Struct) error {
-	// collect unique fields
+	// collect unique fields identities
 	tts := ts.Type.(*ast.StructType)
 	fields := make(map[string]struct{}, len(st.Fields))
+	ids := fids{}
 	for _, f := range st.Fields {
-		fields[f.Name] = struct{}{}
+		fields[ids.field(f)] = struct{}{}
 	}
+	ids = fids{}
 	// prepare resulted fields slice
 	nfields := make([]*ast.Field, 0, len(tts.Fields.List))
 	// go through original ast fields list
@@ -96,7 +178,13 @@ func fpadfilter(ts *ast.TypeSpec, st gopium.Struct) error {
 		if len(f.Names) == 1 {
 			// if pad field was detected
 			// filter it out
+			if astpad(f) {
+				continue
+			}
+			// user blank fields
+			// should be still collected
 			if f.Names[0].Name == "_" {
+				nfields = append(nfields, f)
 				continue
 			}
 			// if field isn't inside
@@ -107,10 +195,12 @@ func fpadfilter(ts *ast.TypeSpec, st gopium.Struct) error {
 			// otherwise collect field
 			nfields = append(nfields, f)
 		}
-		// embedded fields should
-		// be still collected
+		// embedded fields are
+		// filtered by their identity
 		if len(f.Names) == 0 {
-			nfields = append(nfields, f)
+			if _, ok := fields[ids.ast(f)]; ok {
+				nfields = append(nfields, f)
+			}
 		}
 	}
 	// update original ast fields list
@@ -120,35 +210,34 @@ func fpadfilter(ts *ast.TypeSpec, st gopium.Struct) error {
 
 // shuffle helps to sort fields list
 // for ast type spec accordingly to result struct,
-//
-// note shuffle works properly on structs with
-// one or less embedded fields
+// fields are matched by their stable identities
+// so any number of embedded and user blank
+// fields are reordered correctly
 func shuffle(ts *ast.TypeSpec, st gopium.Struct) error {
 	// collect fields indexes
 	tts := ts.Type.(*ast.StructType)
 	fields := make(map[string]int, len(st.Fields))
-	// in case struct have two or more embedded fields
-	// this solution will not work as expected
+	ids := fids{}
 	for i, f := range st.Fields {
-		fields[f.Name] = i
+		// skip pads as they are
+		// synced by pad sync later
+		if collections.IsPad(f) {
+			continue
+		}
+		fields[ids.field(f)] = i
+	}
+	// collect ast fields identities
+	ids = fids{}
+	names := make(map[*ast.Field]string, len(tts.Fields.List))
+	for _, f := range tts.Fields.List {
+		names[f] = ids.ast(f)
 	}
 	// shuffle fields list
 	sort.SliceStable(tts.Fields.List, func(i, j int) bool {
-		// we can safely pick only first name
-		// for flat structure non embedded
-		// ast's i-th and j-th fields
-		// in case fields are embedded
-		// use empty name by default
-		var ni, nj string
-		if fni := tts.Fields.List[i]; len(fni.Names) == 1 {
-			ni = fni.Names[0].Name
-		}
-		if fnj := tts.Fields.List[j]; len(fnj.Names) == 1 {
-			nj = fnj.Names[0].Name
-		}
+		ni, nj := names[tts.Fields.List[i]], names[tts.Fields.List[j]]
 		// prepare comparison indexes
 		// and search for them in resulted structure
-		// in case field name of resulted
+		// in case field identity of resulted
 		// structure matches either:
 		// - ast's i-th structure field
 		// - ast's j-th structure field
@@ -166,21 +255,85 @@ func shuffle(ts *ast.TypeSpec, st gopium.Struct) error {
 	return nil
 }
 
+// fids defines fields identities helper
+// that tracks fields occurrences
+// to build stable fields identities
+// - named fields are identified by name `a`
+// - user blank fields by occurrence index `_#1`
+// - embedded fields by type name and occurrence index `@A#0`
+type fids map[string]int
+
+// field builds identity for gopium struct field
+func (ids fids) field(f gopium.Field) string {
+	switch {
+	case f.Embedded:
+		return ids.next(fmt.Sprintf("@%s", ename(f.Type)))
+	case f.Name == "_":
+		return ids.next(f.Name)
+	default:
+		return f.Name
+	}
+}
+
+// ast builds identity for flat ast struct field
+func (ids fids) ast(f *ast.Field) string {
+	switch {
+	case len(f.Names) == 0:
+		return ids.next(fmt.Sprintf("@%s", ename(types.ExprString(f.Type))))
+	case f.Names[0].Name == "_":
+		return ids.next(f.Names[0].Name)
+	default:
+		return f.Names[0].Name
+	}
+}
+
+// next helps to build identity
+// with next occurrence index
+func (ids fids) next(base string) string {
+	index := ids[base]
+	ids[base]++
+	return fmt.Sprintf("%s#%d", base, index)
+}
+
+// ename helps to get embedded field
+// type name from its full type
+// `*github.com/1pkg/pkg.A` or `*pkg.A` to `A`
+func ename(typ string) string {
+	typ = strings.TrimLeft(typ, "*")
+	return typ[strings.LastIndex(typ, ".")+1:]
+}
+
 // padsync helps to sync fields padding list
-// for ast type spec accordingly to result struct
+// for ast type spec accordingly to result struct,
+// result struct non pad fields should match
+// ast fields otherwise error is returned
 func padsync(ts *ast.TypeSpec, st gopium.Struct) error {
-	// prepare resulted fields slice
+	// check that all result struct
+	// non pad fields are in ast fields
 	tts := ts.Type.(*ast.StructType)
+	var nonpads int
+	for _, f := range st.Fields {
+		if !collections.IsPad(f) {
+			nonpads++
+		}
+	}
+	if nonpads != len(tts.Fields.List) {
+		return fmt.Errorf("type %q fields don't match result structure fields", ts.Name.Name)
+	}
+	// prepare resulted fields slice
 	fields := make([]*ast.Field, len(st.Fields))
 	copy(fields, tts.Fields.List)
 	for index, f := range st.Fields {
 		// skip non pad fields
-		if f.Name != "_" {
+		if !collections.IsPad(f) {
 			continue
 		}
-		// transform size to string format
+		// transform pad type to ast expr
 		// and add pad field to struct
-		size := strconv.Itoa(int(f.Size))
+		expr, err := padexpr(f)
+		if err != nil {
+			return err
+		}
 		field := &ast.Field{
 			Names: []*ast.Ident{
 				{
@@ -191,15 +344,7 @@ func padsync(ts *ast.TypeSpec, st gopium.Struct) error {
 					},
 				},
 			},
-			Type: &ast.ArrayType{
-				Len: &ast.BasicLit{
-					Kind:  token.INT,
-					Value: size,
-				},
-				Elt: &ast.Ident{
-					Name: "byte",
-				},
-			},
+			Type: expr,
 		}
 		// shift fields one right
 		copy(fields[index+1:], fields[index:])
@@ -211,6 +356,75 @@ func padsync(ts *ast.TypeSpec, st gopium.Struct) error {
 	return nil
 }
 
+// padexpr helps to transform pad field type
+// to ast type expr, arrays pads are transformed
+// to array type `[N]byte` or `[N]uint64`,
+// named pads are transformed to selector with
+// full package path `golang.org/x/sys/cpu.CacheLinePad`
+// which is resolved against file imports later
+func padexpr(f gopium.Field) (ast.Expr, error) {
+	// transform arrays pads
+	if m := padarr.FindStringSubmatch(f.Type); m != nil {
+		return &ast.ArrayType{
+			Len: &ast.BasicLit{
+				Kind:  token.INT,
+				Value: m[1],
+			},
+			Elt: &ast.Ident{
+				Name: m[2],
+			},
+		}, nil
+	}
+	// transform named pads with package path
+	if pkg, name := PadImport(f); pkg != "" {
+		return &ast.SelectorExpr{
+			X:   ast.NewIdent(pkg),
+			Sel: ast.NewIdent(name),
+		}, nil
+	}
+	// otherwise parse pad type as is
+	expr, err := parser.ParseExpr(f.Type)
+	if err != nil {
+		return nil, fmt.Errorf("pad type %q can't be parsed %w", f.Type, err)
+	}
+	return expr, nil
+}
+
+// PadImport helps to split named pad field type
+// to package path and type name `golang.org/x/sys/cpu`
+// and `CacheLinePad`, it returns empty path
+// for non named or local pad types
+func PadImport(f gopium.Field) (string, string) {
+	// skip arrays and local pads
+	index := strings.LastIndex(f.Type, ".")
+	if index < 0 || strings.ContainsAny(f.Type, "[]*()") {
+		return "", f.Type
+	}
+	return f.Type[:index], f.Type[index+1:]
+}
+
+// padarr defines pad array type regex
+var padarr = regexp.MustCompile(`^\[(\d+)\](byte|uint8|uint64)$`)
+
+// astpad checks if ast field is pad field
+// marked with gopium pad tag token
+func astpad(f *ast.Field) bool {
+	// check field is blank
+	if len(f.Names) != 1 || f.Names[0].Name != "_" {
+		return false
+	}
+	// check field tag is pad tag
+	var tag string
+	if f.Tag != nil {
+		tag, _ = strconv.Unquote(f.Tag.Value)
+	}
+	return collections.IsPad(gopium.Field{
+		Name: "_",
+		Type: types.ExprString(f.Type),
+		Tag:  tag,
+	})
+}
+
 // tagsync helps to sync field tags between
 // ast type spec and result struct
 func tagsync(ts *ast.TypeSpec, st gopium.Struct) error {
@@ -230,6 +444,62 @@ func tagsync(ts *ast.TypeSpec, st gopium.Struct) error {
 	return nil
 }
 
+// merge helps to merge back adjacent fields
+// that were originally declared together `a, b int`
+// if structure has `//gopium:merge` directive,
+// fields are merged only if they still share
+// the same type, tag and have no gopium notes
+func merge(ts *ast.TypeSpec, st gopium.Struct) error {
+	// check that merge is requested
+	merging := false
+	for _, dir := range st.Directives {
+		merging = merging || dir == "merge"
+	}
+	tts := ts.Type.(*ast.StructType)
+	if !merging || len(tts.Fields.List) != len(st.Fields) {
+		return nil
+	}
+	// prepare resulted fields slice
+	fields := make([]*ast.Field, 0, len(tts.Fields.List))
+	var prev *ast.Field
+	for index, field := range tts.Fields.List {
+		f := st.Fields[index]
+		// flatten keeps the same type expr
+		// for fields declared together
+		// so it's used as declaration mark
+		if prev != nil &&
+			len(field.Names) == 1 &&
+			field.Type == prev.Type &&
+			tagval(field.Tag) == tagval(prev.Tag) &&
+			len(f.Doc) == 0 && len(f.Comment) == 0 &&
+			len(st.Fields[index-1].Doc) == 0 && len(st.Fields[index-1].Comment) == 0 {
+			prev.Names = append(prev.Names, field.Names...)
+			continue
+		}
+		// otherwise copy field
+		// to not affect original names
+		nf := *field
+		nf.Names = append([]*ast.Ident{}, field.Names...)
+		fields = append(fields, &nf)
+		prev = &nf
+		// only flat named fields are merged
+		if len(field.Names) != 1 {
+			prev = nil
+		}
+	}
+	// update original ast fields list
+	tts.Fields.List = fields
+	return nil
+}
+
+// tagval helps to get ast tag value
+func tagval(tag *ast.BasicLit) string {
+	if tag == nil {
+		return ""
+	}
+	return tag.Value
+}
+
 // reindex helps to reindex fields local token pos
 // for original ast type spec, by just incrementing
 // pos for each struct field,
@@ -245,11 +515,15 @@ func reindex(ts *ast.TypeSpec, st gopium.Struct) error {
 	pos := token.Pos(0)
 	// go through all structure fields
 	tts := ts.Type.(*ast.StructType)
-	for _, field := range tts.Fields.List {
-		// in case field isn't flat skip it
-		if len(field.Names) == 1 {
-			// set field to current pos
-			field.Names[0].NamePos = pos
+	for index, field := range tts.Fields.List {
+		// missing fields can't be reindexed
+		if field == nil {
+			return fmt.Errorf("type %q field %d is missing", ts.Name.Name, index)
+		}
+		// in case field is embedded skip it
+		for _, name := range field.Names {
+			// set field name to current pos
+			name.NamePos = pos
 			// just increment pos
 			pos += token.Pos(1)
 		}
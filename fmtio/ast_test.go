@@ -162,7 +162,7 @@ test struct {
 }
 `),
 		},
-		"struct with excess paddings and fields should be filtered and sorted": {
+		"struct with multiple embedded fields should be sorted": {
 			fmt: FSPT,
 			ts: &ast.TypeSpec{
 				Name: &ast.Ident{
@@ -171,13 +171,173 @@ test struct {
 				Type: &ast.StructType{
 					Fields: &ast.FieldList{
 						List: []*ast.Field{
+							{
+								Type: &ast.Ident{Name: "A"},
+							},
+							{
+								Type: &ast.StarExpr{X: &ast.Ident{Name: "B"}},
+							},
 							{
 								Names: []*ast.Ident{
 									{
-										Name: "test-removed",
+										Name: "c",
 									},
+								},
+								Type: &ast.Ident{Name: "int"},
+							},
+							{
+								Type: &ast.SelectorExpr{X: &ast.Ident{Name: "pkg"}, Sel: &ast.Ident{Name: "D"}},
+							},
+						},
+					},
+				},
+			},
+			st: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "c",
+						Type: "int",
+					},
+					{
+						Name:     "D",
+						Type:     "github.com/1pkg/pkg.D",
+						Embedded: true,
+					},
+					{
+						Name:     "B",
+						Type:     "*test.B",
+						Embedded: true,
+					},
+					{
+						Name:     "A",
+						Type:     "test.A",
+						Embedded: true,
+					},
+				},
+			},
+			r: []byte(`
+test struct {
+	c int
+	pkg.D
+	*B
+	A
+}
+`),
+		},
+		"struct with merge directive should merge adjacent fields declared together": {
+			fmt: FSPT,
+			ts: &ast.TypeSpec{
+				Name: &ast.Ident{
+					Name: "test",
+				},
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
 									{
-										Name: "_",
+										Name: "a",
+									},
+									{
+										Name: "b",
+									},
+								},
+								Type: &ast.Ident{Name: "int64"},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "c",
+									},
+								},
+								Type: &ast.Ident{Name: "bool"},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "d",
+									},
+									{
+										Name: "e",
+									},
+								},
+								Type: &ast.Ident{Name: "bool"},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "f",
+									},
+									{
+										Name: "g",
+									},
+								},
+								Type: &ast.Ident{Name: "string"},
+							},
+						},
+					},
+				},
+			},
+			st: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"merge"},
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+						Type: "int64",
+					},
+					{
+						Name: "b",
+						Type: "int64",
+					},
+					{
+						Name:    "f",
+						Type:    "string",
+						Comment: []string{"// test"},
+					},
+					{
+						Name: "g",
+						Type: "string",
+					},
+					{
+						Name: "d",
+						Type: "bool",
+					},
+					{
+						Name: "c",
+						Type: "bool",
+					},
+					{
+						Name: "e",
+						Type: "bool",
+					},
+				},
+			},
+			r: []byte(`
+test struct {
+	a, b int64
+	f    string
+	g    string
+	d    bool
+	c    bool
+	e    bool
+}
+`),
+		},
+		"struct with excess paddings and fields should be filtered and sorted": {
+			fmt: FSPT,
+			ts: &ast.TypeSpec{
+				Name: &ast.Ident{
+					Name: "test",
+				},
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "test-removed",
 									},
 								},
 								Type: &ast.Ident{
@@ -214,12 +374,18 @@ test struct {
 										Name: "_",
 									},
 								},
-								Type: &ast.Ident{
-									Name: "float32",
+								Type: &ast.ArrayType{
+									Len: &ast.BasicLit{
+										Kind:  token.INT,
+										Value: "4",
+									},
+									Elt: &ast.Ident{
+										Name: "byte",
+									},
 								},
 								Tag: &ast.BasicLit{
 									Kind:  token.STRING,
-									Value: "tag",
+									Value: "`gopium:\"pad\"`",
 								},
 							},
 						},
@@ -246,7 +412,7 @@ test struct {// random
 }
 `),
 		},
-		"struct paddings and fields should be synchronized": {
+		"struct user blank fields should be kept and synchronized": {
 			fmt: FSPT,
 			ts: &ast.TypeSpec{
 				Name: &ast.Ident{
@@ -258,12 +424,404 @@ test struct {// random
 							{
 								Names: []*ast.Ident{
 									{
-										Name: "test-removed",
+										Name: "_",
+									},
+								},
+								Type: &ast.Ident{Name: "noCopy"},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "a",
+									},
+								},
+								Type: &ast.Ident{Name: "int64"},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "_",
+									},
+								},
+								Type: &ast.ArrayType{Len: &ast.BasicLit{Kind: token.INT, Value: "8"}, Elt: &ast.Ident{Name: "byte"}},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "b",
 									},
+								},
+								Type: &ast.Ident{Name: "int32"},
+							},
+							{
+								Names: []*ast.Ident{
 									{
 										Name: "_",
 									},
 								},
+								Type: &ast.ArrayType{Len: &ast.BasicLit{Kind: token.INT, Value: "0"}, Elt: &ast.FuncType{Params: &ast.FieldList{}}},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "_",
+									},
+								},
+								Type: &ast.SelectorExpr{X: &ast.Ident{Name: "cpu"}, Sel: &ast.Ident{Name: "CacheLinePad"}},
+							},
+						},
+					},
+				},
+			},
+			st: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "_",
+						Type: "noCopy",
+					},
+					{
+						Name: "b",
+						Type: "int32",
+						Tag:  "btag",
+					},
+					{
+						Name: "a",
+						Type: "int64",
+					},
+					{
+						Name: "_",
+						Type: "[4]byte",
+						Size: 4,
+						Tag:  `gopium:"pad"`,
+					},
+					{
+						Name: "_",
+						Type: "[8]byte",
+						Size: 8,
+					},
+					{
+						Name: "_",
+						Type: "[0]func()",
+					},
+					{
+						Name: "_",
+						Type: "golang.org/x/sys/cpu.CacheLinePad",
+						Size: 64,
+					},
+				},
+			},
+			r: []byte(`
+test struct {
+	_ noCopy
+	b int32 'btag'
+	a int64
+	_ [4]byte 'gopium:"pad"'
+	_ [8]byte
+	_ [0]func()
+	_ cpu.CacheLinePad
+}
+`),
+		},
+		"struct with result fields missing from ast should return error": {
+			fmt: FSPT,
+			ts: &ast.TypeSpec{
+				Name: &ast.Ident{
+					Name: "test",
+				},
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "a",
+									},
+								},
+								Type: &ast.Ident{Name: "int64"},
+							},
+						},
+					},
+				},
+			},
+			st: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+						Type: "int64",
+					},
+					{
+						Name: "_",
+						Type: "[]byte",
+						Size: 24,
+					},
+				},
+			},
+			err: errors.New(`type "test" fields don't match result structure fields`),
+		},
+		"struct styled paddings should be filtered and synchronized": {
+			fmt: FSPT,
+			ts: &ast.TypeSpec{
+				Name: &ast.Ident{
+					Name: "test",
+				},
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "a",
+									},
+								},
+								Type: &ast.Ident{Name: "int64"},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "_",
+									},
+								},
+								Type: &ast.SelectorExpr{X: &ast.Ident{Name: "cpu"}, Sel: &ast.Ident{Name: "CacheLinePad"}},
+								Tag:  &ast.BasicLit{Kind: token.STRING, Value: "`gopium:\"pad\"`"},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "_",
+									},
+								},
+								Type: &ast.ArrayType{Len: &ast.BasicLit{Kind: token.INT, Value: "2"}, Elt: &ast.Ident{Name: "uint64"}},
+								Tag:  &ast.BasicLit{Kind: token.STRING, Value: "`gopium:\"pad;group:def;filter_pads\"`"},
+							},
+						},
+					},
+				},
+			},
+			st: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "_",
+						Type: "[3]uint64",
+						Size: 24,
+						Tag:  `gopium:"pad"`,
+					},
+					{
+						Name: "a",
+						Type: "int64",
+					},
+					{
+						Name: "_",
+						Type: "golang.org/x/sys/cpu.CacheLinePad",
+						Size: 64,
+						Tag:  `gopium:"pad"`,
+					},
+					{
+						Name: "_",
+						Type: "example.com/pkg.linePad",
+						Size: 32,
+						Tag:  `gopium:"pad"`,
+					},
+				},
+			},
+			r: []byte(`
+test struct {
+	_ [3]uint64 'gopium:"pad"'
+	a int64
+	_ golang.org/x/sys/cpu.CacheLinePad 'gopium:"pad"'
+	_ example.com/pkg.linePad           'gopium:"pad"'
+}
+`),
+		},
+		"struct nested structs should be synchronized recursively": {
+			fmt: FSPT,
+			ts: &ast.TypeSpec{
+				Name: &ast.Ident{
+					Name: "test",
+				},
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "a",
+									},
+								},
+								Type: &ast.Ident{Name: "bool"},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "n",
+									},
+								},
+								Type: &ast.StructType{
+									Fields: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Names: []*ast.Ident{
+													{
+														Name: "x",
+													},
+												},
+												Type: &ast.Ident{Name: "bool"},
+											},
+											{
+												Names: []*ast.Ident{
+													{
+														Name: "y",
+													},
+												},
+												Type: &ast.Ident{Name: "int64"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			st: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "n",
+						Type: "struct{y int64; x bool}",
+						Nested: &gopium.Struct{
+							Name: "test.n",
+							Fields: []gopium.Field{
+								{
+									Name: "y",
+									Type: "int64",
+								},
+								{
+									Name: "x",
+									Type: "bool",
+								},
+							},
+						},
+					},
+					{
+						Name: "a",
+						Type: "bool",
+					},
+				},
+			},
+			r: []byte(`
+test struct {
+	n struct {
+		y int64
+		x bool
+	}
+	a bool
+}
+`),
+		},
+		"struct wrapped nested structs should be synchronized recursively": {
+			fmt: FSPT,
+			ts: &ast.TypeSpec{
+				Name: &ast.Ident{
+					Name: "test",
+				},
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "a",
+									},
+								},
+								Type: &ast.Ident{Name: "bool"},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "n",
+									},
+								},
+								Type: &ast.ArrayType{Elt: &ast.StarExpr{X: &ast.StructType{
+									Fields: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Names: []*ast.Ident{
+													{
+														Name: "x",
+													},
+												},
+												Type: &ast.Ident{Name: "bool"},
+											},
+											{
+												Names: []*ast.Ident{
+													{
+														Name: "y",
+													},
+												},
+												Type: &ast.Ident{Name: "int64"},
+											},
+										},
+									},
+								}}},
+							},
+						},
+					},
+				},
+			},
+			st: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "n",
+						Type: "[]*struct{y int64; x bool}",
+						Nested: &gopium.Struct{
+							Name: "test.n",
+							Fields: []gopium.Field{
+								{
+									Name: "y",
+									Type: "int64",
+								},
+								{
+									Name: "x",
+									Type: "bool",
+								},
+							},
+						},
+					},
+					{
+						Name: "a",
+						Type: "bool",
+					},
+				},
+			},
+			r: []byte(`
+test struct {
+	n []*struct {
+		y int64
+		x bool
+	}
+	a bool
+}
+`),
+		},
+		"struct paddings and fields should be synchronized": {
+			fmt: FSPT,
+			ts: &ast.TypeSpec{
+				Name: &ast.Ident{
+					Name: "test",
+				},
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "test-removed",
+									},
+								},
 								Type: &ast.Ident{
 									Name: "string",
 								},
@@ -298,12 +856,18 @@ test struct {// random
 										Name: "_",
 									},
 								},
-								Type: &ast.Ident{
-									Name: "float32",
+								Type: &ast.ArrayType{
+									Len: &ast.BasicLit{
+										Kind:  token.INT,
+										Value: "4",
+									},
+									Elt: &ast.Ident{
+										Name: "byte",
+									},
 								},
 								Tag: &ast.BasicLit{
 									Kind:  token.STRING,
-									Value: "tag",
+									Value: "`gopium:\"pad\"`",
 								},
 							},
 						},
@@ -325,12 +889,13 @@ test struct {// random
 						Name: "_",
 						Type: "[10]byte",
 						Size: 10,
-						Tag:  "btag",
+						Tag:  `gopium:"pad" btag`,
 					},
 					{
 						Name: "_",
-						Type: "float64",
+						Type: "[8]byte",
 						Size: 8,
+						Tag:  `gopium:"pad"`,
 					},
 				},
 			},
@@ -339,8 +904,8 @@ test struct {// random
 	test-1  int64
 	test-2  int64
 	_       [// random
-	10]byte 'btag'
-	_ [8]byte
+	10]byte 'gopium:"pad" btag'
+	_ [8]byte 'gopium:"pad"'
 }
 `),
 		},
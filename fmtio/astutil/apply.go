@@ -6,9 +6,12 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"unicode"
 
 	"github.com/1pkg/gopium/collections"
 	"github.com/1pkg/gopium/fmtio"
@@ -16,15 +19,18 @@ import (
 	"github.com/1pkg/gopium/typepkg"
 
 	"golang.org/x/sync/errgroup"
+	xastutil "golang.org/x/tools/go/ast/astutil"
 )
 
 // UFFN implements apply and combines:
 // - ufmt with fmtio FSPT helper
 // - filter helper
+// - imports helper
 // - note helper
 var UFFN = combine(
 	ufmt(walk, fmtio.FSPT),
 	filter(walk),
+	imports,
 	note(
 		walk,
 		&typepkg.ParserXToolPackagesAst{
@@ -105,7 +111,7 @@ func ufmt(w gopium.Walk, fmt gopium.Ast) gopium.Apply {
 			ctx,
 			pkg,
 			fmtast(fmt),
-			&flatid{loc: loc, sts: collections.Flat(c.Full())},
+			&flatid{loc: loc, sts: collections.Flat(c.Full()).Local()},
 		)
 		if err != nil {
 			return nil, err
@@ -135,7 +141,7 @@ func filter(w gopium.Walk) gopium.Apply {
 			ctx,
 			pkg,
 			bc,
-			&flatid{loc: loc, sts: collections.Flat(c.Full())},
+			&flatid{loc: loc, sts: collections.Flat(c.Full()).Local()},
 		); err != nil {
 			return nil, err
 		}
@@ -191,6 +197,126 @@ func filter(w gopium.Walk) gopium.Apply {
 	}
 }
 
+// imports helps to add all imports
+// to ast package files that are required
+// by named pads types of gopium struct results
+// including their anonymous nested structs,
+// then named pads qualifiers are resolved against
+// file imports, pads types declared in the same
+// package as their structs are left unqualified
+func imports(
+	ctx context.Context,
+	pkg *ast.Package,
+	loc gopium.Locator,
+	c gopium.Categorized,
+) (*ast.Package, error) {
+	// go through package files
+	for name, file := range pkg.Files {
+		// get collection for cat
+		// and go through all its structs fields
+		locals := make(map[string]bool)
+		var add func(pkgpath string, st gopium.Struct)
+		add = func(pkgpath string, st gopium.Struct) {
+			for _, f := range st.Fields {
+				// add import for all named pads
+				if path, _ := fmtio.PadImport(f); path != "" && collections.IsPad(f) {
+					if _, ok := imported(file, path); !ok && path != pkgpath {
+						xastutil.AddImport(loc.Root(), file, path)
+					}
+					locals[path] = path == pkgpath
+				}
+				if f.Nested != nil {
+					add(pkgpath, *f.Nested)
+				}
+			}
+		}
+		sts, _ := c.Cat(name)
+		for id, st := range sts {
+			add(strings.SplitN(id, ":", 2)[0], st)
+		}
+		// skip files without named pads
+		if len(locals) == 0 {
+			continue
+		}
+		// go through all file pads fields
+		// and resolve named pads qualifiers
+		ast.Inspect(file, func(n ast.Node) bool {
+			f, ok := n.(*ast.Field)
+			if !ok || len(f.Names) != 1 || f.Tag == nil {
+				return true
+			}
+			if tag, _ := strconv.Unquote(f.Tag.Value); !collections.IsPad(gopium.Field{
+				Name: f.Names[0].Name,
+				Tag:  tag,
+			}) {
+				return true
+			}
+			sel, ok := f.Type.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			x, ok := sel.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			if local, ok := locals[x.Name]; ok {
+				if q := qualifier(file, x.Name); local || q == "." {
+					f.Type = sel.Sel
+				} else {
+					x.Name = q
+				}
+			}
+			return true
+		})
+	}
+	return pkg, ctx.Err()
+}
+
+// imported helps to find file import
+// for provided package path, blank
+// imports are not treated as imports
+func imported(file *ast.File, path string) (*ast.ImportSpec, bool) {
+	for _, spec := range file.Imports {
+		if ipath, err := strconv.Unquote(spec.Path.Value); err != nil || ipath != path {
+			continue
+		}
+		if spec.Name == nil || spec.Name.Name != "_" {
+			return spec, true
+		}
+	}
+	return nil, false
+}
+
+// qualifier helps to find file import name
+// for provided package path, unnamed imports
+// are named after the last import path element
+// `gopkg.in/yaml.v2` or `example.com/go-yaml/v2` to `yaml`
+func qualifier(file *ast.File, path string) string {
+	// check if path is imported
+	// with explicit import name
+	if spec, ok := imported(file, path); ok && spec.Name != nil {
+		return spec.Name.Name
+	}
+	// otherwise use the last path element
+	// skipping major version element
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	if len(parts) > 1 && majorv.MatchString(name) {
+		name = parts[len(parts)-2]
+	}
+	// and keep only its identifier part
+	name = strings.TrimPrefix(name, "go-")
+	if index := strings.IndexFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}); index > 0 {
+		name = name[:index]
+	}
+	return name
+}
+
+// majorv defines major version path element regex
+var majorv = regexp.MustCompile(`^v[0-9]+$`)
+
 // note helps to update ast package
 // accordingly to gopium struct result
 //
@@ -247,7 +373,7 @@ func note(w gopium.Walk, xp gopium.AstParser, p gopium.Printer) gopium.Apply {
 					gctx,
 					file,
 					((*pressnote)(file)),
-					hasnote{cmp: newsorted(catsts)},
+					hasnote{cmp: &flatid{loc: nloc, sts: collections.Flat(catsts).Local()}},
 				)
 				if err != nil {
 					return err
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"go/ast"
+	"go/format"
 	"go/parser"
+	"go/token"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -23,7 +26,7 @@ func TestApply(t *testing.T) {
 	// prepare
 	lh := collections.NewHierarchic(tests.Gopium)
 	lh.Push(
-		"tests_data_note_file-1.go:6",
+		"github.com/1pkg/gopium/tests/data/note:Note",
 		filepath.Join(tests.Gopium, "tests", "data", "note", "file-1.go"),
 		gopium.Struct{
 			Name:    "Note",
@@ -36,8 +39,9 @@ func TestApply(t *testing.T) {
 				},
 				{
 					Name: "_",
-					Type: "[]byte",
+					Type: "[8]byte",
 					Size: 8,
+					Tag:  `gopium:"pad"`,
 					Doc:  []string{"// test-pad", "// test-pad-pad"},
 				},
 				{
@@ -48,7 +52,7 @@ func TestApply(t *testing.T) {
 		},
 	)
 	lh.Push(
-		"tests_data_note_file-2.go:6",
+		"github.com/1pkg/gopium/tests/data/note:DocCom",
 		filepath.Join(tests.Gopium, "tests", "data", "note", "file-2.go"),
 		gopium.Struct{
 			Name: "DocCom",
@@ -63,7 +67,7 @@ func TestApply(t *testing.T) {
 	)
 	ldc := collections.NewHierarchic(tests.Gopium)
 	ldc.Push(
-		"tests_data_note_file-1.go:6",
+		"github.com/1pkg/gopium/tests/data/note:Note",
 		filepath.Join(tests.Gopium, "tests", "data", "note", "file-1.go"),
 		gopium.Struct{
 			Name: "Note",
@@ -74,8 +78,9 @@ func TestApply(t *testing.T) {
 				},
 				{
 					Name: "_",
-					Type: "[]byte",
+					Type: "[8]byte",
 					Size: 8,
+					Tag:  `gopium:"pad"`,
 				},
 				{
 					Name: "A",
@@ -85,11 +90,11 @@ func TestApply(t *testing.T) {
 		},
 	)
 	ldc.Push(
-		"tests_data_note_file-2.go:6",
+		"github.com/1pkg/gopium/tests/data/note:DocCom",
 		filepath.Join(tests.Gopium, "tests", "data", "note", "file-2.go"),
 	)
 	ldc.Push(
-		"tests_data_note_file-3.go:14",
+		"github.com/1pkg/gopium/tests/data/note:tt",
 		filepath.Join(tests.Gopium, "tests", "data", "note", "file-3.go"),
 		gopium.Struct{Name: "tt"},
 	)
@@ -127,7 +132,7 @@ package note
 type Note struct {
 	C string
 	// test-pad test-pad-pad
-	_ [8]byte
+	_ [8]byte ` + "`gopium:\"pad\"`" + `
 	A string
 } // test-com
 // some comment
@@ -159,7 +164,7 @@ package note
 // Note doc
 type Note struct {
 	C string
-	_ [8]byte
+	_ [8]byte ` + "`gopium:\"pad\"`" + `
 	A string
 } // some comment
 
@@ -340,3 +345,88 @@ type tt gopium.Struct
 		})
 	}
 }
+
+func TestImports(t *testing.T) {
+	// prepare
+	pad := func(path string) func(*ast.File) {
+		return func(file *ast.File) {
+			ast.Inspect(file, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok {
+					if x, ok := sel.X.(*ast.Ident); ok && x.Name == "pkg" {
+						x.Name = path
+					}
+				}
+				return true
+			})
+		}
+	}
+	table := map[string]struct {
+		id  string
+		typ string
+		src string
+		r   string
+	}{
+		"named pad should be imported and qualified": {
+			id:  "example.com/a:A",
+			typ: "golang.org/x/sys/cpu.CacheLinePad",
+			src: "package a\ntype A struct {\n\ta int64\n\t_ pkg.CacheLinePad `gopium:\"pad\"`\n}\n",
+			r:   "package a\n\nimport \"golang.org/x/sys/cpu\"\n\ntype A struct {\n\ta int64\n\t_ cpu.CacheLinePad `gopium:\"pad\"`\n}\n",
+		},
+		"named pad should be qualified by existing import name": {
+			id:  "example.com/a:A",
+			typ: "golang.org/x/sys/cpu.CacheLinePad",
+			src: "package a\nimport xcpu \"golang.org/x/sys/cpu\"\ntype A struct {\n\ta int64\n\t_ pkg.CacheLinePad `gopium:\"pad\"`\n}\n",
+			r:   "package a\n\nimport xcpu \"golang.org/x/sys/cpu\"\n\ntype A struct {\n\ta int64\n\t_ xcpu.CacheLinePad `gopium:\"pad\"`\n}\n",
+		},
+		"named pad should stay unqualified on dot import": {
+			id:  "example.com/a:A",
+			typ: "golang.org/x/sys/cpu.CacheLinePad",
+			src: "package a\nimport . \"golang.org/x/sys/cpu\"\ntype A struct {\n\ta int64\n\t_ pkg.CacheLinePad `gopium:\"pad\"`\n}\n",
+			r:   "package a\n\nimport . \"golang.org/x/sys/cpu\"\n\ntype A struct {\n\ta int64\n\t_ CacheLinePad `gopium:\"pad\"`\n}\n",
+		},
+		"named pad should be qualified by versioned import name": {
+			id:  "example.com/a:A",
+			typ: "example.com/go-pads/v2.Pad",
+			src: "package a\ntype A struct {\n\ta int64\n\t_ pkg.Pad `gopium:\"pad\"`\n}\n",
+			r:   "package a\n\nimport \"example.com/go-pads/v2\"\n\ntype A struct {\n\ta int64\n\t_ pads.Pad `gopium:\"pad\"`\n}\n",
+		},
+		"local named pad should stay unqualified": {
+			id:  "example.com/a:A",
+			typ: "example.com/a.linePad",
+			src: "package a\ntype A struct {\n\ta int64\n\t_ pkg.linePad `gopium:\"pad\"`\n}\n",
+			r:   "package a\n\ntype A struct {\n\ta int64\n\t_ linePad `gopium:\"pad\"`\n}\n",
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			path, _ := fmtio.PadImport(gopium.Field{Type: tcase.typ})
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "a.go", tcase.src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			pad(path)(file)
+			h := collections.NewHierarchic("")
+			h.Push(tcase.id, "a.go", gopium.Struct{
+				Name: "A",
+				Fields: []gopium.Field{
+					{Name: "a", Type: "int64", Size: 8, Align: 8},
+					{Name: "_", Type: tcase.typ, Size: 64, Align: 1, Tag: `gopium:"pad"`},
+				},
+			})
+			pkg := &ast.Package{Name: "a", Files: map[string]*ast.File{"a.go": file}}
+			// exec
+			_, err = imports(context.Background(), pkg, typepkg.NewLocator(fset), h)
+			var buf bytes.Buffer
+			_ = format.Node(&buf, fset, file)
+			// check
+			if !reflect.DeepEqual(err, nil) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			if !reflect.DeepEqual(buf.String(), tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", buf.String(), tcase.r)
+			}
+		})
+	}
+}
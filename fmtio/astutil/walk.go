@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
 	"github.com/1pkg/gopium/gopium"
 
 	"golang.org/x/tools/go/ast/astutil"
@@ -82,7 +83,7 @@ func (fmt fmtast) Visit(ts *ast.TypeSpec, st gopium.Struct) error {
 // action boundaries collector implementation
 type bcollect struct {
 	bs collections.Boundaries `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_  [8]byte                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_  [8]byte                `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 } // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
 
 // Visit bcollect implementation
@@ -129,81 +130,84 @@ func (pdc *pressnote) Visit(ts *ast.TypeSpec, st gopium.Struct) error {
 			{Slash: slash, Text: com},
 		}})
 	}
+	// press all resulted structure fields notes
+	pdc.fields(ts.Type.(*ast.StructType), st)
+	return nil
+}
+
+// fields helps to press notes from
+// gopium structure fields to ast file,
+// anonymous nested structs notes are pressed
+// recursively and merged with their fields notes,
+// merged ast fields `a, b int` match several
+// gopium structure fields at once
+func (pdc *pressnote) fields(tts *ast.StructType, st gopium.Struct) {
 	// go through all resulted structure fields
-	tts := ts.Type.(*ast.StructType)
-	for index, field := range st.Fields {
-		// get the field from ast
-		astfield := tts.Fields.List[index]
+	file := ((*ast.File)(pdc))
+	index := 0
+	for _, astfield := range tts.Fields.List {
+		// get the field from gopium structure
+		if index >= len(st.Fields) {
+			break
+		}
+		field := st.Fields[index]
+		index += len(astfield.Names)
+		if len(astfield.Names) == 0 {
+			index++
+		}
+		docs, coms := field.Doc, field.Comment
+		// press nested struct fields notes
+		// and merge nested struct notes
+		if nts, ok := fmtio.NestedStruct(astfield.Type); ok && field.Nested != nil {
+			pdc.fields(nts, *field.Nested)
+			docs = append(append([]string{}, field.Nested.Doc...), docs...)
+			coms = append(append([]string{}, field.Nested.Comment...), coms...)
+		}
 		// if it has at least one doc
-		if len(field.Doc) >= 1 {
+		if len(docs) >= 1 {
 			// doc position is position of name - 1
 			slash := astfield.Pos() - token.Pos(1)
 			// collect all docs from resulted field
-			doc := fmt.Sprintf("//%s", strings.ReplaceAll(strings.Join(field.Doc, ""), "//", ""))
+			doc := fmt.Sprintf("//%s", strings.ReplaceAll(strings.Join(docs, ""), "//", ""))
 			// update file comments list
 			file.Comments = append(file.Comments, &ast.CommentGroup{List: []*ast.Comment{
 				{Slash: slash, Text: doc},
 			}})
 		}
 		// if it has at least one comment
-		if len(field.Comment) >= 1 {
+		if len(coms) >= 1 {
 			// comment position is end of field type
 			slash := astfield.Type.End()
 			// collect all comments from resulted field
-			com := fmt.Sprintf("//%s", strings.ReplaceAll(strings.Join(field.Comment, ""), "//", ""))
+			com := fmt.Sprintf("//%s", strings.ReplaceAll(strings.Join(coms, ""), "//", ""))
 			// update file comments list
 			file.Comments = append(file.Comments, &ast.CommentGroup{List: []*ast.Comment{
 				{Slash: slash, Text: com},
 			}})
 		}
 	}
-	return nil
 }
 
 // flatid defines gopium ast walk
-// comparator structs flat ids implementation
+// comparator structs flat ids implementation,
+// structs are matched by their local identities
+// as ast locators don't hold package path
 type flatid struct {
 	loc gopium.Locator   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	sts collections.Flat `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_   [8]byte          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_   [8]byte          `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 } // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
 
 // Check flatid implementation
 func (cmp flatid) Check(ts *ast.TypeSpec) (gopium.Struct, bool) {
 	// just check if struct
-	// with such id is inside
+	// with such local id is inside
 	id := cmp.loc.ID(ts.Pos())
-	st, ok := cmp.sts[id]
-	return st, ok
-}
-
-// sorted defines gopium ast walk
-// comparator structs flat implementation
-// which uses match on sorted structs name
-type sorted struct {
-	sts []gopium.Struct `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_   [8]byte         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
-
-// newsorted creates sorted
-// comparator from structs map
-func newsorted(sts map[string]gopium.Struct) *sorted {
-	f := collections.Flat(sts)
-	return &sorted{sts: f.Sorted()}
-}
-
-// Check sorted implementation
-func (cmp *sorted) Check(ts *ast.TypeSpec) (gopium.Struct, bool) {
-	// if sorted list is not empty
-	if len(cmp.sts) > 0 {
-		// check the top sorted element name
-		if st := cmp.sts[0]; st.Name == ts.Name.Name {
-			cmp.sts = cmp.sts[1:]
-			return st, true
-		}
+	if id == "" {
+		return gopium.Struct{}, false
 	}
-	// otherwise skip it
-	return gopium.Struct{}, false
+	st, ok := cmp.sts[collections.Local(id)]
+	return st, ok
 }
 
 // hasnote defines gopium ast walk
@@ -220,20 +224,31 @@ func (cmp hasnote) Check(ts *ast.TypeSpec) (gopium.Struct, bool) {
 	// use underlying comparator func
 	// check if we should process struct
 	if st, ok := cmp.cmp.Check(ts); ok {
-		// if struct has any notes
-		if len(st.Doc) > 0 || len(st.Comment) > 0 {
-			return st, true
-		}
-		// if any field of struct has any notes
-		for _, f := range st.Fields {
-			if len(f.Doc) > 0 || len(f.Comment) > 0 {
-				return st, true
-			}
-		}
-		// in case struct has no inner
-		// notes just skip it
-		return st, false
+		// if struct or any struct's field
+		// or nested struct has any notes
+		return st, noted(st)
 	}
 	// otherwise skip it
 	return gopium.Struct{}, false
 }
+
+// noted checks if structure or any structure's
+// field or nested struct has any notes attached
+func noted(st gopium.Struct) bool {
+	// if struct has any notes
+	if len(st.Doc) > 0 || len(st.Comment) > 0 {
+		return true
+	}
+	// if any field of struct has any notes
+	for _, f := range st.Fields {
+		if len(f.Doc) > 0 || len(f.Comment) > 0 {
+			return true
+		}
+		if f.Nested != nil && noted(*f.Nested) {
+			return true
+		}
+	}
+	// in case struct has no inner
+	// notes just skip it
+	return false
+}
@@ -33,6 +33,7 @@ func TestBytes(t *testing.T) {
 		"Name": "",
 		"Doc": null,
 		"Comment": null,
+		"Directives": null,
 		"Fields": null
 	}
 ]
@@ -83,17 +84,20 @@ func TestBytes(t *testing.T) {
 		"Name": "Test-1",
 		"Doc": null,
 		"Comment": null,
+		"Directives": null,
 		"Fields": [
 			{
 				"Name": "test-3",
 				"Type": "test",
 				"Size": 1,
 				"Align": 1,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			}
 		]
 	},
@@ -105,12 +109,14 @@ func TestBytes(t *testing.T) {
 		"Comment": [
 			"comtest"
 		],
+		"Directives": null,
 		"Fields": [
 			{
 				"Name": "test-1",
 				"Type": "string",
 				"Size": 16,
 				"Align": 8,
+				"Ptr": 0,
 				"Tag": "test-tag",
 				"Exported": true,
 				"Embedded": true,
@@ -119,18 +125,21 @@ func TestBytes(t *testing.T) {
 				],
 				"Comment": [
 					"fcomtest"
-				]
+				],
+				"Nested": null
 			},
 			{
 				"Name": "test-2",
 				"Type": "test_type",
 				"Size": 12,
 				"Align": 4,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			}
 		]
 	}
@@ -198,6 +207,7 @@ func TestBytes(t *testing.T) {
 		<Type>test</Type>
 		<Size>1</Size>
 		<Align>1</Align>
+		<Ptr>0</Ptr>
 		<Tag></Tag>
 		<Exported>false</Exported>
 		<Embedded>false</Embedded>
@@ -212,6 +222,7 @@ func TestBytes(t *testing.T) {
 		<Type>string</Type>
 		<Size>16</Size>
 		<Align>8</Align>
+		<Ptr>0</Ptr>
 		<Tag>test-tag</Tag>
 		<Exported>true</Exported>
 		<Embedded>true</Embedded>
@@ -223,6 +234,7 @@ func TestBytes(t *testing.T) {
 		<Type>test_type</Type>
 		<Size>12</Size>
 		<Align>4</Align>
+		<Ptr>0</Ptr>
 		<Tag></Tag>
 		<Exported>false</Exported>
 		<Embedded>false</Embedded>
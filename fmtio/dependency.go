@@ -0,0 +1,45 @@
+package fmtio
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/1pkg/gopium/collections"
+)
+
+// DependenciesMdt defines dependencies bytes implementation
+// which serializes struct fields dependencies collection
+// to formatted markdown table byte slice
+func DependenciesMdt(ds collections.Dependencies) ([]byte, error) {
+	// prepare buffer
+	var buf bytes.Buffer
+	// write header
+	// no error should be
+	// checked as it uses
+	// buffered writer
+	_, _ = buf.WriteString("| Struct Key | Field Name | Dependency Type | Dependency Size | Dependency Align | Dependency Layout |\n")
+	_, _ = buf.WriteString("| :---: | :---: | :---: | :---: | :---: | :---: |\n")
+	for _, d := range ds.Sorted() {
+		// pick dependency layout source
+		layout := "original"
+		if d.Optimized {
+			layout = "optimized"
+		}
+		// write dependency info
+		// no error should be
+		// checked as it uses
+		// buffered writer
+		_, _ = buf.WriteString(
+			fmt.Sprintf(
+				"| %s | %s | %s | %d bytes | %d bytes | %s |\n",
+				d.Struct,
+				d.Field,
+				d.Type,
+				d.Size,
+				d.Align,
+				layout,
+			),
+		)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,52 @@
+package fmtio
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+)
+
+func TestDependenciesMdt(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		ds  collections.Dependencies
+		r   []byte
+		err error
+	}{
+		"empty collection should return empty results": {
+			ds: collections.Dependencies{},
+			r: []byte(`
+| Struct Key | Field Name | Dependency Type | Dependency Size | Dependency Align | Dependency Layout |
+| :---: | :---: | :---: | :---: | :---: | :---: |
+`),
+		},
+		"non empty collection should return expected results": {
+			ds: collections.Dependencies{
+				{Struct: "f.B", Field: "a", Type: "pkg.A", Size: 16, Align: 8, Optimized: true},
+				{Struct: "C", Field: "n.b", Type: "pkg.B", Size: 24, Align: 8},
+				{Struct: "f.B", Field: "c", Type: "other.C", Size: 8, Align: 4},
+			},
+			r: []byte(`
+| Struct Key | Field Name | Dependency Type | Dependency Size | Dependency Align | Dependency Layout |
+| :---: | :---: | :---: | :---: | :---: | :---: |
+| C | n.b | pkg.B | 24 bytes | 8 bytes | original |
+| f.B | a | pkg.A | 16 bytes | 8 bytes | optimized |
+| f.B | c | other.C | 8 bytes | 4 bytes | original |
+`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := DependenciesMdt(tcase.ds)
+			// check
+			if !reflect.DeepEqual(r, tcase.r[1:]) {
+				t.Errorf("actual %v doesn't equal to expected %v", string(r), string(tcase.r[1:]))
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
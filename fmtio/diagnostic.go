@@ -0,0 +1,30 @@
+package fmtio
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/1pkg/gopium/collections"
+)
+
+// DiagnosticsMdt defines diagnostics bytes implementation
+// which serializes structs diagnostics collection
+// to formatted markdown table byte slice
+func DiagnosticsMdt(ds collections.Diagnostics) ([]byte, error) {
+	// prepare buffer
+	var buf bytes.Buffer
+	// write header
+	// no error should be
+	// checked as it uses
+	// buffered writer
+	_, _ = buf.WriteString("| Struct Key | Struct Location | Diagnostic |\n")
+	_, _ = buf.WriteString("| :---: | :---: | :---: |\n")
+	for _, d := range ds.Sorted() {
+		// write diagnostic info
+		// no error should be
+		// checked as it uses
+		// buffered writer
+		_, _ = buf.WriteString(fmt.Sprintf("| %s | %s | %s |\n", d.Struct, d.Loc, d.Message))
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,50 @@
+package fmtio
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+)
+
+func TestDiagnosticsMdt(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		ds  collections.Diagnostics
+		r   []byte
+		err error
+	}{
+		"empty collection should return empty results": {
+			ds: collections.Diagnostics{},
+			r: []byte(`
+| Struct Key | Struct Location | Diagnostic |
+| :---: | :---: | :---: |
+`),
+		},
+		"non empty collection should return expected results": {
+			ds: collections.Diagnostics{
+				{Struct: "f.B", Loc: "pkg/file.go", Message: `struct "B" align 8 bytes exceeds budget align 4 bytes`},
+				{Struct: "A", Loc: "pkg/file.go", Message: `struct "A" aligned size 24 bytes exceeds budget size 16 bytes`},
+			},
+			r: []byte(`
+| Struct Key | Struct Location | Diagnostic |
+| :---: | :---: | :---: |
+| A | pkg/file.go | struct "A" aligned size 24 bytes exceeds budget size 16 bytes |
+| f.B | pkg/file.go | struct "B" align 8 bytes exceeds budget align 4 bytes |
+`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := DiagnosticsMdt(tcase.ds)
+			// check
+			if !reflect.DeepEqual(r, tcase.r[1:]) {
+				t.Errorf("actual %v doesn't equal to expected %v", string(r), string(tcase.r[1:]))
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ const (
 	CSV    = "csv"
 	MD     = "md"
 	HTML   = "html"
+	LOCK   = "lock.json"
 )
 
 // stdout defines tiny wrapper for
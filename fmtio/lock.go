@@ -0,0 +1,108 @@
+package fmtio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+)
+
+// Lockb defines layouts bytes implementation
+// which uses json marshal with indent
+// to serialize layouts collection to byte slice
+func Lockb(ls collections.Layouts) ([]byte, error) {
+	// just use json marshal with indent
+	// note: json sorts map keys
+	// so lock output is stable
+	return json.MarshalIndent(ls, "", "\t")
+}
+
+// Unlockb defines layouts bytes implementation
+// which uses json unmarshal to deserialize
+// byte slice back to layouts collection
+func Unlockb(buf []byte) (collections.Layouts, error) {
+	// just use json unmarshal
+	var ls collections.Layouts
+	if err := json.Unmarshal(buf, &ls); err != nil {
+		return nil, err
+	}
+	return ls, nil
+}
+
+// RegressMdt defines layouts diff implementation
+// which compares baseline and current layouts collections
+// to formatted markdown table byte slice
+func RegressMdt(o collections.Layouts, r collections.Layouts) ([]byte, error) {
+	// prepare buffer and collections
+	var buf bytes.Buffer
+	var tsizeo, tsizer, tpado, tpadr int64
+	// make keys order predictable
+	keys := make([]string, 0, len(o))
+	for key := range o {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	// write header
+	// no error should be
+	// checked as it uses
+	// buffered writer
+	_, _ = buf.WriteString("| Struct Key | Baseline Size | Current Size | Size Difference | Baseline Pad | Current Pad | Pad Difference | Baseline Ptr | Current Ptr | Fields Order |\n")
+	_, _ = buf.WriteString("| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |\n")
+	for _, key := range keys {
+		// if both collections contains
+		// struct layout, compare them
+		lo := o[key]
+		if lr, ok := r[key]; ok {
+			// check if fields order
+			// has been changed
+			order := "same"
+			if strings.Join(lo.Fields, ",") != strings.Join(lr.Fields, ",") {
+				order = "changed"
+			}
+			// write diff info
+			// no error should be
+			// checked as it uses
+			// buffered writer
+			_, _ = buf.WriteString(
+				fmt.Sprintf(
+					"| %s | %d bytes | %d bytes | %+d bytes | %d bytes | %d bytes | %+d bytes | %d bytes | %d bytes | %s |\n",
+					key,
+					lo.Size,
+					lr.Size,
+					lr.Size-lo.Size,
+					lo.Pad,
+					lr.Pad,
+					lr.Pad-lo.Pad,
+					lo.Ptr,
+					lr.Ptr,
+					order,
+				),
+			)
+			// increment total sizes
+			tsizeo += lo.Size
+			tsizer += lr.Size
+			tpado += lo.Pad
+			tpadr += lr.Pad
+		}
+	}
+	// write total info
+	// no error should be
+	// checked as it uses
+	// buffered writer
+	_, _ = buf.WriteString(
+		fmt.Sprintf(
+			"| %s | %d bytes | %d bytes | %+d bytes | %d bytes | %d bytes | %+d bytes | - | - | - |\n",
+			"Total",
+			tsizeo,
+			tsizer,
+			tsizer-tsizeo,
+			tpado,
+			tpadr,
+			tpadr-tpado,
+		),
+	)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,159 @@
+package fmtio
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+)
+
+func TestLock(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		ls  collections.Layouts
+		r   []byte
+		err error
+	}{
+		"empty collection should return empty results": {
+			ls: collections.Layouts{},
+			r: []byte(`
+{}
+`),
+		},
+		"non empty collection should return expected results": {
+			ls: collections.Layouts{
+				"file.go:B": {
+					Fields: []string{"b"},
+					Size:   8,
+					Align:  8,
+					Ptr:    8,
+				},
+				"file.go:A": {
+					Fields: []string{"a", "_"},
+					Size:   16,
+					Align:  8,
+					Pad:    8,
+				},
+			},
+			r: []byte(`
+{
+	"file.go:A": {
+		"Fields": [
+			"a",
+			"_"
+		],
+		"Size": 16,
+		"Align": 8,
+		"Ptr": 0,
+		"Pad": 8
+	},
+	"file.go:B": {
+		"Fields": [
+			"b"
+		],
+		"Size": 8,
+		"Align": 8,
+		"Ptr": 8,
+		"Pad": 0
+	}
+}
+`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := Lockb(tcase.ls)
+			ls, uerr := Unlockb(r)
+			// check
+			// format actual and expected identically
+			actual := string(r)
+			expected := string(tcase.r[1 : len(tcase.r)-1])
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("name %v actual %v doesn't equal to expected %v", name, actual, expected)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			if !reflect.DeepEqual(ls, tcase.ls) {
+				t.Errorf("actual %v doesn't equal to expected %v", ls, tcase.ls)
+			}
+			if !reflect.DeepEqual(uerr, nil) {
+				t.Errorf("actual %v doesn't equal to expected %v", uerr, nil)
+			}
+		})
+	}
+}
+
+func TestRegressMdt(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		o   collections.Layouts
+		r   collections.Layouts
+		b   []byte
+		err error
+	}{
+		"empty collections should return empty results": {
+			b: []byte(`
+| Struct Key | Baseline Size | Current Size | Size Difference | Baseline Pad | Current Pad | Pad Difference | Baseline Ptr | Current Ptr | Fields Order |
+| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
+| Total | 0 bytes | 0 bytes | +0 bytes | 0 bytes | 0 bytes | +0 bytes | - | - | - |
+`),
+		},
+		"non empty collections should return expected results": {
+			o: collections.Layouts{
+				"file.go:B": {
+					Fields: []string{"a", "b", "c"},
+					Size:   24,
+					Ptr:    8,
+					Pad:    14,
+				},
+				"file.go:A": {
+					Fields: []string{"a", "b"},
+					Size:   16,
+					Pad:    2,
+				},
+				"file.go:C": {
+					Fields: []string{"c"},
+					Size:   8,
+				},
+			},
+			r: collections.Layouts{
+				"file.go:A": {
+					Fields: []string{"a", "b"},
+					Size:   16,
+					Pad:    2,
+				},
+				"file.go:B": {
+					Fields: []string{"b", "a", "c"},
+					Size:   16,
+					Ptr:    8,
+					Pad:    6,
+				},
+			},
+			b: []byte(`
+| Struct Key | Baseline Size | Current Size | Size Difference | Baseline Pad | Current Pad | Pad Difference | Baseline Ptr | Current Ptr | Fields Order |
+| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
+| file.go:A | 16 bytes | 16 bytes | +0 bytes | 2 bytes | 2 bytes | +0 bytes | 0 bytes | 0 bytes | same |
+| file.go:B | 24 bytes | 16 bytes | -8 bytes | 14 bytes | 6 bytes | -8 bytes | 8 bytes | 8 bytes | changed |
+| Total | 40 bytes | 32 bytes | -8 bytes | 16 bytes | 8 bytes | -8 bytes | - | - | - |
+`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			b, err := RegressMdt(tcase.o, tcase.r)
+			// check
+			// format actual and expected identically
+			actual := string(b)
+			expected := string(tcase.b[1:])
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("name %v actual %v doesn't equal to expected %v", name, actual, expected)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package fmtio
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/1pkg/gopium/collections"
+)
+
+// SavingsMdt defines savings bytes implementation
+// which serializes packages structs sizes savings
+// collection to formatted markdown table byte slice
+func SavingsMdt(ss collections.Savings) ([]byte, error) {
+	// prepare buffer and totals
+	var buf bytes.Buffer
+	var t collections.Saving
+	// make packages order predictable
+	pkgs := make([]string, 0, len(ss))
+	for pkg := range ss {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+	// write header
+	// no error should be
+	// checked as it uses
+	// buffered writer
+	_, _ = buf.WriteString("| Package | Structs | Original Size with Pad | Current Size with Pad | Absolute Difference | Relative Difference |\n")
+	_, _ = buf.WriteString("| :---: | :---: | :---: | :---: | :---: | :---: |\n")
+	for _, pkg := range pkgs {
+		// write saving info
+		// and increment totals
+		s := ss[pkg]
+		_, _ = buf.WriteString(srow(pkg, s))
+		t.Structs += s.Structs
+		t.Original += s.Original
+		t.Current += s.Current
+	}
+	// write total info
+	_, _ = buf.WriteString(srow("Total", t))
+	return buf.Bytes(), nil
+}
+
+// srow helps to format single
+// savings markdown table row
+func srow(name string, s collections.Saving) string {
+	// zero divide guard
+	var rel float64
+	if s.Original > 0 {
+		rel = float64(s.Current-s.Original) / float64(s.Original) * 100.0
+	}
+	return fmt.Sprintf(
+		"| %s | %d | %d bytes | %d bytes | %+d bytes | %+.2f%% |\n",
+		name,
+		s.Structs,
+		s.Original,
+		s.Current,
+		s.Current-s.Original,
+		rel,
+	)
+}
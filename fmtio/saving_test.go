@@ -0,0 +1,54 @@
+package fmtio
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+)
+
+func TestSavingsMdt(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		ss  collections.Savings
+		r   []byte
+		err error
+	}{
+		"empty collection should return only total results": {
+			ss: collections.Savings{},
+			r: []byte(`
+| Package | Structs | Original Size with Pad | Current Size with Pad | Absolute Difference | Relative Difference |
+| :---: | :---: | :---: | :---: | :---: | :---: |
+| Total | 0 | 0 bytes | 0 bytes | +0 bytes | +0.00% |
+`),
+		},
+		"non empty collection should return expected results": {
+			ss: collections.Savings{
+				"pkg/b": {Structs: 1, Original: 24, Current: 24},
+				"pkg/a": {Structs: 2, Original: 40, Current: 32},
+				"pkg/c": {Structs: 1, Original: 0, Current: 0},
+			},
+			r: []byte(`
+| Package | Structs | Original Size with Pad | Current Size with Pad | Absolute Difference | Relative Difference |
+| :---: | :---: | :---: | :---: | :---: | :---: |
+| pkg/a | 2 | 40 bytes | 32 bytes | -8 bytes | -20.00% |
+| pkg/b | 1 | 24 bytes | 24 bytes | +0 bytes | +0.00% |
+| pkg/c | 1 | 0 bytes | 0 bytes | +0 bytes | +0.00% |
+| Total | 4 | 64 bytes | 56 bytes | -8 bytes | -12.50% |
+`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := SavingsMdt(tcase.ss)
+			// check
+			if !reflect.DeepEqual(r, tcase.r[1:]) {
+				t.Errorf("actual %v doesn't equal to expected %v", string(r), string(tcase.r[1:]))
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package fmtio
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/1pkg/gopium/collections"
+)
+
+// SkipsMdt defines skips bytes implementation
+// which serializes skipped structs collection
+// to formatted markdown table byte slice
+func SkipsMdt(ss collections.Skips) ([]byte, error) {
+	// prepare buffer
+	var buf bytes.Buffer
+	// write header
+	// no error should be
+	// checked as it uses
+	// buffered writer
+	_, _ = buf.WriteString("| Struct Key | Struct Location | Skipped By Filter |\n")
+	_, _ = buf.WriteString("| :---: | :---: | :---: |\n")
+	for _, s := range ss.Sorted() {
+		// write skip info
+		// no error should be
+		// checked as it uses
+		// buffered writer
+		_, _ = buf.WriteString(fmt.Sprintf("| %s | %s | %s |\n", s.Struct, s.Loc, s.Filter))
+	}
+	return buf.Bytes(), nil
+}
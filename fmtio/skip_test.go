@@ -0,0 +1,50 @@
+package fmtio
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+)
+
+func TestSkipsMdt(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		ss  collections.Skips
+		r   []byte
+		err error
+	}{
+		"empty collection should return empty results": {
+			ss: collections.Skips{},
+			r: []byte(`
+| Struct Key | Struct Location | Skipped By Filter |
+| :---: | :---: | :---: |
+`),
+		},
+		"non empty collection should return expected results": {
+			ss: collections.Skips{
+				{Struct: "f.B", Loc: "pkg/file.go", Filter: "exported"},
+				{Struct: "A", Loc: "pkg/file.go", Filter: `include "^B"`},
+			},
+			r: []byte(`
+| Struct Key | Struct Location | Skipped By Filter |
+| :---: | :---: | :---: |
+| A | pkg/file.go | include "^B" |
+| f.B | pkg/file.go | exported |
+`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := SkipsMdt(tcase.ss)
+			// check
+			if !reflect.DeepEqual(r, tcase.r[1:]) {
+				t.Errorf("actual %v doesn't equal to expected %v", string(r), string(tcase.r[1:]))
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
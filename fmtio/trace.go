@@ -0,0 +1,64 @@
+package fmtio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+)
+
+// TraceJsonb defines trace bytes implementation
+// which uses json marshal with indent
+// to serialize pipeline stages collection to byte slice
+func TraceJsonb(ss collections.Stages) ([]byte, error) {
+	// just use json marshal with indent
+	return json.MarshalIndent(ss.Sorted(), "", "\t")
+}
+
+// TraceMdt defines trace bytes implementation
+// which serializes pipeline stages collection
+// to formatted markdown table byte slice
+func TraceMdt(ss collections.Stages) ([]byte, error) {
+	// prepare buffer
+	var buf bytes.Buffer
+	// write header
+	// no error should be
+	// checked as it uses
+	// buffered writer
+	_, _ = buf.WriteString("| Struct Key | Stage | Strategy | Duration | Size with Pad | Size Delta | Fields Order |\n")
+	_, _ = buf.WriteString("| :---: | :---: | :---: | :---: | :---: | :---: | :---: |\n")
+	var key string
+	var stage int
+	for _, s := range ss.Sorted() {
+		// number stages
+		// inside the same struct
+		if s.Struct != key {
+			key, stage = s.Struct, 0
+		}
+		stage++
+		// collect struct fields order
+		fields := make([]string, 0, len(s.Result.Fields))
+		for _, f := range s.Result.Fields {
+			fields = append(fields, f.Name)
+		}
+		// write stage info
+		// no error should be
+		// checked as it uses
+		// buffered writer
+		_, _ = buf.WriteString(
+			fmt.Sprintf(
+				"| %s | %d | %s | %s | %d bytes | %+d bytes | %s |\n",
+				s.Struct,
+				stage,
+				s.Strategy,
+				s.Duration,
+				s.Size,
+				s.Delta,
+				strings.Join(fields, ", "),
+			),
+		)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,158 @@
+package fmtio
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestTraceJsonb(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		ss  collections.Stages
+		r   []byte
+		err error
+	}{
+		"empty collection should return empty results": {
+			ss: collections.Stages{},
+			r:  []byte(`[]`),
+		},
+		"non empty collection should return expected results": {
+			ss: collections.Stages{
+				{
+					Struct:   "A",
+					Loc:      "pkg/file.go:10",
+					Strategy: "memory_pack",
+					Result: gopium.Struct{
+						Name: "A",
+						Fields: []gopium.Field{
+							{Name: "a", Type: "int64", Size: 8, Align: 8},
+						},
+					},
+					Duration: time.Millisecond,
+					Size:     8,
+					Delta:    -8,
+				},
+			},
+			r: []byte(`[
+	{
+		"Result": {
+			"Name": "A",
+			"Doc": null,
+			"Comment": null,
+			"Directives": null,
+			"Fields": [
+				{
+					"Name": "a",
+					"Type": "int64",
+					"Size": 8,
+					"Align": 8,
+					"Ptr": 0,
+					"Tag": "",
+					"Exported": false,
+					"Embedded": false,
+					"Doc": null,
+					"Comment": null,
+					"Nested": null
+				}
+			]
+		},
+		"Struct": "A",
+		"Loc": "pkg/file.go:10",
+		"Strategy": "memory_pack",
+		"Duration": 1000000,
+		"Size": 8,
+		"Delta": -8
+	}
+]`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := TraceJsonb(tcase.ss)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", string(r), string(tcase.r))
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestTraceMdt(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		ss  collections.Stages
+		r   []byte
+		err error
+	}{
+		"empty collection should return empty results": {
+			ss: collections.Stages{},
+			r: []byte(`
+| Struct Key | Stage | Strategy | Duration | Size with Pad | Size Delta | Fields Order |
+| :---: | :---: | :---: | :---: | :---: | :---: | :---: |
+`),
+		},
+		"non empty collection should return expected results": {
+			ss: collections.Stages{
+				{
+					Struct:   "f.B",
+					Strategy: "filter_pads",
+					Result: gopium.Struct{
+						Name:   "B",
+						Fields: []gopium.Field{{Name: "b"}},
+					},
+					Duration: 2 * time.Microsecond,
+				},
+				{
+					Struct:   "A",
+					Strategy: "memory_pack",
+					Result: gopium.Struct{
+						Name:   "A",
+						Fields: []gopium.Field{{Name: "b"}, {Name: "a"}, {Name: "c"}},
+					},
+					Duration: time.Millisecond,
+					Size:     16,
+					Delta:    -8,
+				},
+				{
+					Struct:   "A",
+					Strategy: "separate_padding_cpu_l1_top",
+					Result: gopium.Struct{
+						Name:   "A",
+						Fields: []gopium.Field{{Name: "_"}, {Name: "b"}, {Name: "a"}, {Name: "c"}},
+					},
+					Duration: 3 * time.Millisecond,
+					Size:     80,
+					Delta:    64,
+				},
+			},
+			r: []byte(`
+| Struct Key | Stage | Strategy | Duration | Size with Pad | Size Delta | Fields Order |
+| :---: | :---: | :---: | :---: | :---: | :---: | :---: |
+| A | 1 | memory_pack | 1ms | 16 bytes | -8 bytes | b, a, c |
+| A | 2 | separate_padding_cpu_l1_top | 3ms | 80 bytes | +64 bytes | _, b, a, c |
+| f.B | 1 | filter_pads | 2µs | 0 bytes | +0 bytes | b |
+`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := TraceMdt(tcase.ss)
+			// check
+			if !reflect.DeepEqual(r, tcase.r[1:]) {
+				t.Errorf("actual %v doesn't equal to expected %v", string(r), string(tcase.r[1:]))
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
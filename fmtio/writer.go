@@ -33,6 +33,12 @@ func (f File) Generate(loc string) (io.WriteCloser, error) {
 	return os.Create(filepath.Join(path, fmt.Sprintf("%s.%s", f.Name, f.Ext)))
 }
 
+// Open file implementation
+func (f File) Open(loc string) (io.ReadCloser, error) {
+	path := filepath.Dir(loc)
+	return os.Open(filepath.Join(path, fmt.Sprintf("%s.%s", f.Name, f.Ext)))
+}
+
 // Files defines writer implementation
 // which creates underlying files list
 // with provided ext on provided loc
@@ -1,8 +1,15 @@
 module github.com/1pkg/gopium
 
-go 1.14
+go 1.18
 
 require (
-	golang.org/x/sync v0.0.0-20200317015054-43a5402ce75a
-	golang.org/x/tools v0.0.0-20200606014950-c42cb6316fb6
+	github.com/BurntSushi/toml v0.3.1
+	golang.org/x/sync v0.0.0-20220722155255-886fb9371eb4
+	golang.org/x/tools v0.1.12
+	gopkg.in/yaml.v2 v2.3.0
+)
+
+require (
+	golang.org/x/mod v0.6.0-dev.0.20220419223038-86c51ed26bb4 // indirect
+	golang.org/x/sys v0.0.0-20220722155257-8c9f86f7a55f // indirect
 )
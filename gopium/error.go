@@ -0,0 +1,104 @@
+package gopium
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// list of gopium errors kinds
+var (
+	ErrParse    = errors.New("parse")
+	ErrStrategy = errors.New("strategy")
+	ErrFormat   = errors.New("format")
+	ErrWrite    = errors.New("write")
+)
+
+// Error defines structured gopium error
+// that wraps failure cause with failure kind
+// and failed struct identity and position if any,
+// error matches its kind and cause with errors.Is
+type Error struct {
+	Kind error  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Err  error  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	ID   string `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Pos  string `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// Error error implementation
+func (e Error) Error() string {
+	msg := fmt.Sprintf("%v error", e.Kind)
+	if e.ID != "" {
+		msg = fmt.Sprintf("%s on struct %q", msg, e.ID)
+	}
+	if e.Pos != "" {
+		msg = fmt.Sprintf("%s at %s", msg, e.Pos)
+	}
+	return fmt.Sprintf("%s: %v", msg, e.Err)
+}
+
+// Unwrap returns error cause
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// Is checks if error has target kind
+func (e Error) Is(target error) bool {
+	return e.Kind == target
+}
+
+// Errors defines collection of gopium errors
+// collected by keep going visiting,
+// collection matches any of its errors
+// with errors.Is and errors.As
+type Errors []error
+
+// Error error implementation
+func (errs Errors) Error() string {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d errors happened\n%s", len(errs), strings.Join(msgs, "\n"))
+}
+
+// Is checks if any of errors
+// matches target error
+func (errs Errors) Is(target error) bool {
+	for _, err := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of errors
+// that matches target type
+func (errs Errors) As(target interface{}) bool {
+	for _, err := range errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// keepKey defines context key
+// for keep going visiting flag
+type keepKey struct{}
+
+// WithKeepGoing attaches keep going visiting flag
+// to visiting context, so structs failures
+// are collected instead of aborting visiting
+func WithKeepGoing(ctx context.Context, keep bool) context.Context {
+	return context.WithValue(ctx, keepKey{}, keep)
+}
+
+// KeepGoing returns keep going visiting flag
+// from visiting context if any
+func KeepGoing(ctx context.Context) bool {
+	keep, _ := ctx.Value(keepKey{}).(bool)
+	return keep
+}
@@ -12,6 +12,8 @@ import (
 type Locator interface {
 	ID(token.Pos) string
 	Loc(token.Pos) string
+	Directives(token.Pos) []string
+	Instances() []*types.Named
 	Locator(string) (Locator, bool)
 	Fset(string, *token.FileSet) (*token.FileSet, bool)
 	Root() *token.FileSet
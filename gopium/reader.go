@@ -0,0 +1,9 @@
+package gopium
+
+import "io"
+
+// Reader defines abstraction for
+// io readers generation
+type Reader interface {
+	Open(string) (io.ReadCloser, error)
+}
@@ -1,6 +1,9 @@
 package gopium
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Strategy defines custom action abstraction
 // that applies some action payload on struct
@@ -18,3 +21,73 @@ type StrategyName string
 type StrategyBuilder interface {
 	Build(...StrategyName) (Strategy, error)
 }
+
+// locKey defines context key
+// for visited struct location
+type locKey struct{}
+
+// WithLoc attaches visited struct location
+// to strategy application context
+func WithLoc(ctx context.Context, loc string) context.Context {
+	return context.WithValue(ctx, locKey{}, loc)
+}
+
+// Loc returns visited struct location
+// from strategy application context if any
+func Loc(ctx context.Context) (string, bool) {
+	loc, ok := ctx.Value(locKey{}).(string)
+	return loc, ok
+}
+
+// Tracer defines strategies pipeline tracer abstraction
+// that records struct before and after
+// each pipeline stage along with stage duration
+type Tracer interface {
+	Trace(StrategyName, Struct, Struct, time.Duration)
+}
+
+// tracerKey defines context key
+// for strategies pipeline tracer
+type tracerKey struct{}
+
+// WithTracer attaches strategies pipeline tracer
+// to strategy application context,
+// nil tracer detaches any tracer
+func WithTracer(ctx context.Context, t Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, t)
+}
+
+// Trace returns strategies pipeline tracer
+// from strategy application context if any
+func Trace(ctx context.Context) (Tracer, bool) {
+	t, ok := ctx.Value(tracerKey{}).(Tracer)
+	return t, ok
+}
+
+// Diagnoser defines strategies diagnostics
+// collector abstraction that collects
+// non fatal strategies diagnostics
+// like exceeded struct budgets
+type Diagnoser interface {
+	Diagnose(error)
+}
+
+// diagnoserKey defines context key
+// for strategies diagnoser
+type diagnoserKey struct{}
+
+// WithDiagnoser attaches strategies diagnoser
+// to strategy application context,
+// so strategies report diagnostics to it
+// instead of failing, nil diagnoser
+// detaches any diagnoser
+func WithDiagnoser(ctx context.Context, d Diagnoser) context.Context {
+	return context.WithValue(ctx, diagnoserKey{}, d)
+}
+
+// Diagnose returns strategies diagnoser
+// from strategy application context if any
+func Diagnose(ctx context.Context) (Diagnoser, bool) {
+	d, ok := ctx.Value(diagnoserKey{}).(Diagnoser)
+	return d, ok
+}
@@ -1,24 +1,29 @@
 package gopium
 
 // Field defines single structure field
-// data transfer object abstraction
+// data transfer object abstraction,
+// fields of anonymous struct types
+// hold their nested struct
 type Field struct {
 	Name     string   `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
 	Type     string   `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
 	Size     int64    `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
 	Align    int64    `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Ptr      int64    `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
 	Tag      string   `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
 	Exported bool     `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
 	Embedded bool     `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
 	Doc      []string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
 	Comment  []string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 114 bytes; struct align: 8 bytes; struct aligned size: 120 bytes; - 🌺 gopium @1pkg
+	Nested   *Struct  `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 130 bytes; struct align: 8 bytes; struct aligned size: 136 bytes; - 🌺 gopium @1pkg
 
 // Struct defines single structure
 // data transfer object abstraction
 type Struct struct {
-	Name    string   `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-	Doc     []string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-	Comment []string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-	Fields  []Field  `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 88 bytes; struct align: 8 bytes; struct aligned size: 88 bytes; - 🌺 gopium @1pkg
+	Name       string   `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Doc        []string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Comment    []string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Directives []string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Fields     []Field  `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 112 bytes; struct align: 8 bytes; struct aligned size: 112 bytes; - 🌺 gopium @1pkg
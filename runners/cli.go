@@ -3,10 +3,11 @@ package runners
 import (
 	"context"
 	"fmt"
-	"go/build"
 	"go/parser"
+	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -22,13 +23,18 @@ import (
 // Cli defines cli runner implementation
 // that is able to run full gopium cli application
 type Cli struct {
-	snames []gopium.StrategyName  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	v      visitor                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	wb     gopium.WalkerBuilder   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	sb     gopium.StrategyBuilder `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	wname  gopium.WalkerName      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_      [40]byte               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
+	snames   []gopium.StrategyName           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	rules    []rule                          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	patterns []string                        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	crules   []Rule                          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	v        visitor                         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	wb       gopium.WalkerBuilder            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	sb       gopium.StrategyBuilder          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	wname    gopium.WalkerName               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	rev      string                          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	xp       *typepkg.ParserXToolPackagesAst `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_        [8]byte                         `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 192 bytes; struct align: 8 bytes; struct aligned size: 192 bytes; - 🌺 gopium @1pkg
 
 // NewCli helps to spawn new cli application runner
 // from list of received parameters or returns error
@@ -37,17 +43,30 @@ func NewCli(
 	compiler,
 	arch string,
 	cpucaches []int,
+	matrix []string,
 	// package parser vars
 	pkg,
 	path string,
 	benvs,
 	bflags []string,
+	tests string,
 	// gopium walker vars
 	walker,
 	regex string,
+	includes,
+	excludes,
+	files []string,
+	minsize,
+	maxsize,
+	minwaste int,
+	exported bool,
+	directives []string,
 	deep,
 	backref bool,
+	rev string,
 	stgs []string,
+	crules []Rule,
+	cpresets map[string][]string,
 	// gopium printer vars
 	indent,
 	tabwidth int,
@@ -55,6 +74,8 @@ func NewCli(
 	usegofmt bool,
 	// gopium global vars
 	timeout int,
+	keepgoing,
+	trace bool,
 ) (*Cli, error) {
 	// cast caches to int64
 	caches := make([]int64, 0, len(cpucaches))
@@ -64,26 +85,65 @@ func NewCli(
 	// set up maven
 	m, err := typepkg.NewMavenGoTypes(compiler, arch, caches...)
 	if err != nil {
-		return nil, fmt.Errorf("can't set up maven %v", err)
+		return nil, fmt.Errorf("can't set up maven %w", err)
 	}
-	// replace package template
-	path = strings.Replace(path, "{{package}}", pkg, 1)
-	// set root to gopath only if
-	// not absolute path has been provided
-	var root string
-	if !filepath.IsAbs(path) {
-		root = build.Default.GOPATH
+	// resolve package name, root and path
+	pkg, root, path, err := resolve(pkg, path, benvs, bflags)
+	if err != nil {
+		return nil, err
+	}
+	// check package test variant
+	variant := typepkg.TestVariant(tests)
+	switch variant {
+	case typepkg.TestNone, typepkg.TestInternal, typepkg.TestExternal, typepkg.TestAll:
+	default:
+		return nil, fmt.Errorf("can't use such package test variant %q", tests)
 	}
 	// set up parser
 	xp := &typepkg.ParserXToolPackagesAst{
 		Pattern:    pkg,
 		Root:       root,
 		Path:       path,
+		Tests:      variant,
 		ModeTypes:  packages.LoadAllSyntax,
 		ModeAst:    parser.ParseComments | parser.AllErrors,
 		BuildEnv:   benvs,
 		BuildFlags: bflags,
 	}
+	// set up matrix targets
+	// with parsers and mavens synced
+	// to each target os, arch and tags
+	var targets []walkers.Target
+	for _, entry := range matrix {
+		goos, goarch, tags, err := target(entry)
+		if err != nil {
+			return nil, err
+		}
+		tm, err := typepkg.NewMavenGoTypes(compiler, goarch, caches...)
+		if err != nil {
+			return nil, fmt.Errorf("can't set up target %q maven %w", entry, err)
+		}
+		// target envs are appended to current envs
+		// so target os and arch take precedence
+		txp := *xp
+		txp.BuildEnv = append(append(os.Environ(), benvs...), "GOOS="+goos, "GOARCH="+goarch)
+		txp.BuildFlags = append([]string{}, bflags...)
+		if len(tags) > 0 {
+			txp.BuildFlags = append(txp.BuildFlags, "-tags="+strings.Join(tags, ","))
+		}
+		targets = append(targets, walkers.Target{Parser: &txp, Exposer: tm, Name: entry})
+	}
+	// set up baseline parser
+	// only if revision has been provided
+	var bp gopium.TypeParser
+	if rev != "" {
+		bp = typepkg.ParserGitRevision{
+			Rev:     rev,
+			Pattern: pkg,
+			Root:    root,
+			Path:    path,
+		}
+	}
 	// set up printer
 	var p gopium.Printer
 	if usegofmt {
@@ -94,53 +154,353 @@ func NewCli(
 	// compile regexp
 	cregex, err := regexp.Compile(regex)
 	if err != nil {
-		return nil, fmt.Errorf("can't compile such regexp %v", err)
+		return nil, fmt.Errorf("can't compile such regexp %w", err)
+	}
+	// set up selection filters
+	filters, err := newFilters(includes, excludes, files, minsize, maxsize, minwaste, exported, directives)
+	if err != nil {
+		return nil, err
 	}
 	// cast timeout to second duration
 	stimeout := time.Duration(timeout) * time.Second
 	// set up visitor
 	v := visitor{
 		regex:   cregex,
+		filters: filters,
 		timeout: stimeout,
+		keep:    keepgoing,
+		trace:   trace,
 	}
 	// set walker and strategy builders
 	wb := walkers.Builder{
-		Parser:  xp,
-		Exposer: m,
-		Printer: p,
-		Deep:    deep,
-		Bref:    backref,
+		Parser:   xp,
+		Baseline: bp,
+		Exposer:  m,
+		Matrix:   targets,
+		Printer:  p,
+		Deep:     deep,
+		Bref:     backref,
+	}
+	sb := strategies.Builder{Curator: m, Trace: trace}
+	// cast config presets to strategy names
+	// presets names are always prefixed with @
+	if len(cpresets) > 0 {
+		sb.Presets = make(map[gopium.StrategyName][]gopium.StrategyName, len(cpresets))
+		for preset, stgs := range cpresets {
+			if !strings.HasPrefix(preset, "@") {
+				preset = "@" + preset
+			}
+			snames := make([]gopium.StrategyName, 0, len(stgs))
+			for _, strategy := range stgs {
+				snames = append(snames, gopium.StrategyName(strategy))
+			}
+			sb.Presets[gopium.StrategyName(preset)] = snames
+		}
 	}
-	sb := strategies.Builder{Curator: m}
 	// cast strategies strings to strategy names
 	snames := make([]gopium.StrategyName, 0, len(stgs))
 	for _, strategy := range stgs {
 		snames = append(snames, gopium.StrategyName(strategy))
 	}
+	// resolve config rules
+	// relevant to the package
+	rules, err := newRules(pkg, crules)
+	if err != nil {
+		return nil, fmt.Errorf("can't resolve config rules %w", err)
+	}
+	if len(rules) == 0 {
+		rules = nil
+	}
 	// cast walker string to walker name
 	wname := gopium.WalkerName(walker)
 	// combine cli runner
-	return &Cli{
+	cli := &Cli{
 		v:      v,
 		wb:     wb,
 		sb:     sb,
 		wname:  wname,
 		snames: snames,
-	}, nil
+		rules:  rules,
+	}
+	// in case of package patterns
+	// or multiple package test variants
+	// config rules are resolved
+	// per each matched package variant
+	patterns := split(pkg)
+	if patterns == nil && (variant == typepkg.TestExternal || variant == typepkg.TestAll) {
+		patterns = []string{"."}
+	}
+	if patterns != nil {
+		cli.xp = xp
+		cli.patterns = patterns
+		cli.crules = crules
+		cli.rev = rev
+		cli.rules = nil
+	}
+	return cli, nil
 }
 
 // Run cli implementation
 func (cli *Cli) Run(ctx context.Context) error {
+	// in case of package patterns
+	// visit all matched packages
+	if cli.patterns != nil {
+		return cli.packages(ctx)
+	}
+	return cli.run(ctx, cli.wb, cli.rules)
+}
+
+// packages helps to load all packages
+// matched by package patterns at once
+// and then visit packages one by one
+// with separate walker and config rules,
+// so each package results are written separately
+func (cli *Cli) packages(ctx context.Context) error {
+	// load all matched packages
+	pps, err := cli.xp.Packages(ctx, cli.patterns...)
+	if err != nil {
+		return fmt.Errorf("can't load packages %w", err)
+	}
+	// in case of backref without matrix targets
+	// visit packages in import order with
+	// module layouts shared between packages
+	var mod *walkers.Module
+	if b, ok := cli.wb.(walkers.Builder); ok && b.Bref && len(b.Matrix) == 0 {
+		pps = order(pps)
+		mod = walkers.NewModule()
+		ctx = walkers.WithModule(ctx, mod)
+	}
+	// load all matched packages
+	// for each matrix target
+	tpps := make([]map[string]*typepkg.ParserXToolPackage, 0)
+	if b, ok := cli.wb.(walkers.Builder); ok {
+		for _, t := range b.Matrix {
+			txp, ok := t.Parser.(*typepkg.ParserXToolPackagesAst)
+			if !ok {
+				return fmt.Errorf("can't load target %q packages", t.Name)
+			}
+			pps, err := txp.Packages(ctx, cli.patterns...)
+			if err != nil {
+				return fmt.Errorf("can't load target %q packages %w", t.Name, err)
+			}
+			mpps := make(map[string]*typepkg.ParserXToolPackage, len(pps))
+			for _, pp := range pps {
+				mpps[pp.Package.PkgPath] = pp
+			}
+			tpps = append(tpps, mpps)
+		}
+	}
+	var errs gopium.Errors
+	for _, pp := range pps {
+		// resolve config rules
+		// relevant to the package,
+		// external test packages share
+		// rules with their packages
+		pkg := pp.Package.PkgPath
+		rules, err := newRules(strings.TrimSuffix(pkg, "_test"), cli.crules)
+		if err != nil {
+			return fmt.Errorf("can't resolve config rules %w", err)
+		}
+		if len(rules) == 0 {
+			rules = nil
+		}
+		// in case of keep going visiting
+		// collect package error and
+		// continue with next packages
+		if err := cli.run(ctx, cli.builder(pp, tpps), rules); err != nil {
+			err = fmt.Errorf("package %q %w", pkg, err)
+			if !cli.v.keep {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	// write module savings report
+	// only for sizes reports walkers
+	if mod != nil && (cli.wname == walkers.SizeAlignFileMdt || cli.wname == walkers.DependenciesFileMdt) {
+		if err := cli.module(mod); err != nil {
+			return err
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// builder helps to set up walker builder
+// package parsers for matched package,
+// baseline parser is located at the same
+// root and path as package ast parser
+func (cli *Cli) builder(pp *typepkg.ParserXToolPackage, tpps []map[string]*typepkg.ParserXToolPackage) gopium.WalkerBuilder {
+	b, ok := cli.wb.(walkers.Builder)
+	if !ok {
+		return cli.wb
+	}
+	pkg := pp.Package.PkgPath
+	b.Parser = pp
+	if cli.rev != "" {
+		b.Baseline = typepkg.ParserGitRevision{
+			Rev:     cli.rev,
+			Pattern: pkg,
+			Root:    pp.Ast.Root,
+			Path:    pp.Ast.Path,
+		}
+	}
+	// set up package matrix targets parsers,
+	// targets without the package are skipped
+	var targets []walkers.Target
+	for i, t := range b.Matrix {
+		if tpp, ok := tpps[i][pkg]; ok {
+			t.Parser = tpp
+			targets = append(targets, t)
+		}
+	}
+	b.Matrix = targets
+	return b
+}
+
+// module helps to write module packages
+// transitive structs sizes savings report
+// to single file inside root directory
+func (cli *Cli) module(mod *walkers.Module) error {
+	// skip empty writes
+	savings := mod.Savings()
+	if len(savings) == 0 {
+		return nil
+	}
+	buf, err := fmtio.SavingsMdt(savings)
+	if err != nil {
+		return fmt.Errorf("can't format module savings %w", err)
+	}
+	writer := fmtio.File{Name: gopium.NAME + "_module", Ext: fmtio.MD}
+	wc, err := writer.Generate(filepath.Join(cli.xp.Root, cli.xp.Path, gopium.NAME))
+	if err != nil {
+		return fmt.Errorf("can't write module savings %w", err)
+	}
+	if _, err := wc.Write(buf); err != nil {
+		return fmt.Errorf("can't write module savings %w", err)
+	}
+	return wc.Close()
+}
+
+// order helps to order packages parsers
+// in import order, so imported packages
+// are placed before packages that import them,
+// it also sets parsers imported packages
+// to locate imported structs identities,
+// packages inside import cycles of test variants
+// are kept in their original order
+func order(pps []*typepkg.ParserXToolPackage) []*typepkg.ParserXToolPackage {
+	// index parsers by package paths
+	// and collect their matched imports
+	index := make(map[string][]int, len(pps))
+	for i, pp := range pps {
+		index[pp.Package.PkgPath] = append(index[pp.Package.PkgPath], i)
+	}
+	deps := make([][]int, len(pps))
+	for i, pp := range pps {
+		pp.Imports = nil
+		for path, imp := range pp.Package.Imports {
+			if _, ok := index[path]; !ok || path == pp.Package.PkgPath {
+				continue
+			}
+			pp.Imports = append(pp.Imports, imp)
+			deps[i] = append(deps[i], index[path]...)
+		}
+		sort.Slice(pp.Imports, func(i, j int) bool {
+			return pp.Imports[i].PkgPath < pp.Imports[j].PkgPath
+		})
+	}
+	// place packages one by one
+	// once all their imports are placed,
+	// on import cycle place the first
+	// remaining package as is
+	ordered := make([]*typepkg.ParserXToolPackage, 0, len(pps))
+	placed := make([]bool, len(pps))
+	for len(ordered) < len(pps) {
+		next := -1
+		for i := range pps {
+			if placed[i] {
+				continue
+			}
+			if next == -1 {
+				next = i
+			}
+			ready := true
+			for _, dep := range deps[i] {
+				ready = ready && placed[dep]
+			}
+			if ready {
+				next = i
+				break
+			}
+		}
+		placed[next] = true
+		ordered = append(ordered, pps[next])
+	}
+	return ordered
+}
+
+// run helps to build strategy and walker
+// and then visit single package
+func (cli *Cli) run(ctx context.Context, wb gopium.WalkerBuilder, crules []rule) error {
 	// build strategy
-	stg, err := cli.v.strategy(cli.sb, cli.snames)
+	stg, err := cli.strategy(cli.sb, crules)
 	if err != nil {
 		return err
 	}
+	// in case of matrix targets
+	// build targets strategies
+	// synced with targets curators
+	if b, ok := wb.(walkers.Builder); ok && len(b.Matrix) > 0 {
+		if sb, ok := cli.sb.(strategies.Builder); ok {
+			targets := make([]walkers.Target, 0, len(b.Matrix))
+			for _, t := range b.Matrix {
+				if c, ok := t.Exposer.(gopium.Curator); ok {
+					sb.Curator = c
+					if t.Strategy, err = cli.strategy(sb, crules); err != nil {
+						return err
+					}
+				}
+				targets = append(targets, t)
+			}
+			b.Matrix = targets
+			wb = b
+		}
+	}
 	// build walker
-	w, err := cli.v.walker(cli.wb, cli.wname)
+	w, err := cli.v.walker(wb, cli.wname)
 	if err != nil {
 		return err
 	}
 	// run visitor visiting
 	return cli.v.visit(ctx, w, stg)
 }
+
+// strategy helps to build strategy
+// with provided strategy builder,
+// in case of any config rules
+// rules strategies are built as well
+// and strategy is used as default one
+func (cli *Cli) strategy(sb gopium.StrategyBuilder, crules []rule) (gopium.Strategy, error) {
+	// build strategy
+	stg, err := cli.v.strategy(sb, cli.snames)
+	if err != nil {
+		return nil, err
+	}
+	// in case of any config rules
+	// build rules strategies and
+	// use strategy as default one
+	if len(crules) > 0 {
+		rs := make([]rule, 0, len(crules))
+		for _, r := range crules {
+			if r.stg, err = cli.v.strategy(sb, r.snames); err != nil {
+				return nil, err
+			}
+			rs = append(rs, r)
+		}
+		stg = rules{rules: rs, def: stg}
+	}
+	return stg, nil
+}
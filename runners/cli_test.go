@@ -3,8 +3,11 @@ package runners
 import (
 	"context"
 	"errors"
+	"fmt"
 	"go/build"
 	"go/parser"
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"testing"
@@ -27,29 +30,50 @@ func TestNewCli(t *testing.T) {
 	if !reflect.DeepEqual(err, nil) {
 		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
 	}
+	m386, err := typepkg.NewMavenGoTypes("gc", "386", 2, 4, 8)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	_, brerr := regexp.Compile("[")
+	_, prerr := regexp.Compile("(")
 	table := map[string]struct {
 		// target platform vars
 		compiler  string
 		arch      string
 		cpucaches []int
+		matrix    []string
 		// package parser vars
 		pkg    string
 		path   string
 		benvs  []string
 		bflags []string
+		tests  string
 		// walker vars
-		walker  string
-		regex   string
-		deep    bool
-		backref bool
-		stgs    []string
+		walker     string
+		regex      string
+		includes   []string
+		excludes   []string
+		files      []string
+		minsize    int
+		maxsize    int
+		minwaste   int
+		exported   bool
+		directives []string
+		deep       bool
+		backref    bool
+		rev        string
+		stgs       []string
+		crules     []Rule
+		presets    map[string][]string
 		// printer vars
 		indent   int
 		tabwidth int
 		usespace bool
 		usegofmt bool
 		// global vars
-		timeout int
+		timeout   int
+		keepgoing bool
+		trace     bool
 		// test vars
 		cli *Cli
 		err error
@@ -64,6 +88,7 @@ func TestNewCli(t *testing.T) {
 			path:   "test-path",
 			benvs:  []string{},
 			bflags: []string{},
+			tests:  "internal",
 			// walker vars
 			walker:  "test-w",
 			regex:   `.*`,
@@ -87,6 +112,7 @@ func TestNewCli(t *testing.T) {
 						Pattern:    "test-pkg",
 						Root:       build.Default.GOPATH,
 						Path:       "test-path",
+						Tests:      typepkg.TestInternal,
 						ModeTypes:  packages.LoadAllSyntax,
 						ModeAst:    parser.ParseComments | parser.AllErrors,
 						BuildEnv:   []string{},
@@ -112,6 +138,7 @@ func TestNewCli(t *testing.T) {
 			path:   "test-path",
 			benvs:  []string{},
 			bflags: []string{},
+			tests:  "internal",
 			// walker vars
 			walker:  "test-w",
 			regex:   `.*`,
@@ -124,18 +151,23 @@ func TestNewCli(t *testing.T) {
 			usespace: true,
 			usegofmt: true,
 			// global vars
-			timeout: 5,
+			timeout:   5,
+			keepgoing: true,
+			trace:     true,
 			// test vars
 			cli: &Cli{
 				v: visitor{
 					regex:   regexp.MustCompile(`.*`),
 					timeout: 5 * time.Second,
+					keep:    true,
+					trace:   true,
 				},
 				wb: walkers.Builder{
 					Parser: &typepkg.ParserXToolPackagesAst{
 						Pattern:    "test-pkg",
 						Root:       build.Default.GOPATH,
 						Path:       "test-path",
+						Tests:      typepkg.TestInternal,
 						ModeTypes:  packages.LoadAllSyntax,
 						ModeAst:    parser.ParseComments | parser.AllErrors,
 						BuildEnv:   []string{},
@@ -146,7 +178,7 @@ func TestNewCli(t *testing.T) {
 					Deep:    true,
 					Bref:    true,
 				},
-				sb:     strategies.Builder{Curator: m},
+				sb:     strategies.Builder{Curator: m, Trace: true},
 				wname:  "test-w",
 				snames: []gopium.StrategyName{"test-stg"},
 			},
@@ -161,6 +193,7 @@ func TestNewCli(t *testing.T) {
 			path:   tests.OnOS("windows", "c:\\test-path", "/test-path").(string),
 			benvs:  []string{},
 			bflags: []string{},
+			tests:  "internal",
 			// walker vars
 			walker:  "test-w",
 			regex:   `.*`,
@@ -183,6 +216,7 @@ func TestNewCli(t *testing.T) {
 					Parser: &typepkg.ParserXToolPackagesAst{
 						Pattern:    "test-pkg",
 						Path:       tests.OnOS("windows", "c:\\test-path", "/test-path").(string),
+						Tests:      typepkg.TestInternal,
 						ModeTypes:  packages.LoadAllSyntax,
 						ModeAst:    parser.ParseComments | parser.AllErrors,
 						BuildEnv:   []string{},
@@ -198,22 +232,27 @@ func TestNewCli(t *testing.T) {
 				snames: []gopium.StrategyName{"test-stg"},
 			},
 		},
-		"new cli should return error on invalid compiler arch combination": {
+		"new cli should return expected cli on valid parameters with package patterns": {
 			// target platform vars
-			compiler:  "cg",
-			arch:      "64amd64",
+			compiler:  "gc",
+			arch:      "amd64",
 			cpucaches: []int{2, 4, 8},
 			// package parser vars
-			pkg:    "test-pkg",
-			path:   "test-path",
+			pkg:    "./...,test-pkg",
+			path:   "src/{{package}}",
 			benvs:  []string{},
 			bflags: []string{},
+			tests:  "internal",
 			// walker vars
 			walker:  "test-w",
 			regex:   `.*`,
 			deep:    true,
 			backref: true,
+			rev:     "test-rev",
 			stgs:    []string{"test-stg"},
+			crules: []Rule{
+				{Package: "test-pkg", Strategies: []string{"test-stg"}},
+			},
 			// printer vars
 			indent:   4,
 			tabwidth: 4,
@@ -221,9 +260,48 @@ func TestNewCli(t *testing.T) {
 			// global vars
 			timeout: 5,
 			// test vars
-			err: errors.New(`can't set up maven unsuported compiler "cg" arch "64amd64" combination`),
+			cli: &Cli{
+				v: visitor{
+					regex:   regexp.MustCompile(`.*`),
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAst{
+						Pattern:    "./...,test-pkg",
+						Tests:      typepkg.TestInternal,
+						ModeTypes:  packages.LoadAllSyntax,
+						ModeAst:    parser.ParseComments | parser.AllErrors,
+						BuildEnv:   []string{},
+						BuildFlags: []string{},
+					},
+					Baseline: typepkg.ParserGitRevision{
+						Rev:     "test-rev",
+						Pattern: "./...,test-pkg",
+					},
+					Exposer: m,
+					Printer: fmtio.NewGoprinter(4, 4, true),
+					Deep:    true,
+					Bref:    true,
+				},
+				sb:     strategies.Builder{Curator: m},
+				wname:  "test-w",
+				snames: []gopium.StrategyName{"test-stg"},
+				xp: &typepkg.ParserXToolPackagesAst{
+					Pattern:    "./...,test-pkg",
+					Tests:      typepkg.TestInternal,
+					ModeTypes:  packages.LoadAllSyntax,
+					ModeAst:    parser.ParseComments | parser.AllErrors,
+					BuildEnv:   []string{},
+					BuildFlags: []string{},
+				},
+				patterns: []string{"./...", "test-pkg"},
+				crules: []Rule{
+					{Package: "test-pkg", Strategies: []string{"test-stg"}},
+				},
+				rev: "test-rev",
+			},
 		},
-		"new cli should return error on regex compile error": {
+		"new cli should return expected cli on valid parameters with revision": {
 			// target platform vars
 			compiler:  "gc",
 			arch:      "amd64",
@@ -233,11 +311,13 @@ func TestNewCli(t *testing.T) {
 			path:   "test-path",
 			benvs:  []string{},
 			bflags: []string{},
+			tests:  "internal",
 			// walker vars
 			walker:  "test-w",
-			regex:   `[`,
+			regex:   `.*`,
 			deep:    true,
 			backref: true,
+			rev:     "HEAD",
 			stgs:    []string{"test-stg"},
 			// printer vars
 			indent:   4,
@@ -246,94 +326,1112 @@ func TestNewCli(t *testing.T) {
 			// global vars
 			timeout: 5,
 			// test vars
-			err: errors.New("can't compile such regexp error parsing regexp: missing closing ]: `[`"),
-		},
-	}
-	for name, tcase := range table {
-		t.Run(name, func(t *testing.T) {
-			// exec
-			cli, err := NewCli(
-				tcase.compiler,
-				tcase.arch,
-				tcase.cpucaches,
-				tcase.pkg,
-				tcase.path,
-				tcase.benvs,
-				tcase.bflags,
-				tcase.walker,
-				tcase.regex,
-				tcase.deep,
-				tcase.backref,
-				tcase.stgs,
-				tcase.indent,
-				tcase.tabwidth,
-				tcase.usespace,
-				tcase.usegofmt,
-				tcase.timeout,
-			)
-			// check
-			if !reflect.DeepEqual(cli, tcase.cli) {
-				t.Errorf("actual %v doesn't equal to expected %v", cli, tcase.cli)
-			}
-			if !reflect.DeepEqual(err, tcase.err) {
-				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
-			}
-		})
-	}
-}
-
-func TestCliRun(t *testing.T) {
-	// prepare
-	table := map[string]struct {
-		cli *Cli
-		err error
-	}{
-		"cli should return error on strategy builder error": {
 			cli: &Cli{
-				v:  visitor{},
-				sb: mocks.StrategyBuilder{Err: errors.New("test-1")},
+				v: visitor{
+					regex:   regexp.MustCompile(`.*`),
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAst{
+						Pattern:    "test-pkg",
+						Root:       build.Default.GOPATH,
+						Path:       "test-path",
+						Tests:      typepkg.TestInternal,
+						ModeTypes:  packages.LoadAllSyntax,
+						ModeAst:    parser.ParseComments | parser.AllErrors,
+						BuildEnv:   []string{},
+						BuildFlags: []string{},
+					},
+					Baseline: typepkg.ParserGitRevision{
+						Rev:     "HEAD",
+						Pattern: "test-pkg",
+						Root:    build.Default.GOPATH,
+						Path:    "test-path",
+					},
+					Exposer: m,
+					Printer: fmtio.NewGoprinter(4, 4, true),
+					Deep:    true,
+					Bref:    true,
+				},
+				sb:     strategies.Builder{Curator: m},
+				wname:  "test-w",
+				snames: []gopium.StrategyName{"test-stg"},
 			},
-			err: errors.New("can't build such strategy [] test-1"),
 		},
-		"cli should return error on walker builder error": {
-			cli: &Cli{
-				v:  visitor{},
-				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
-				wb: mocks.WalkerBuilder{Err: errors.New("test-2")},
+		"new cli should return expected cli on valid parameters with config rules": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []int{2, 4, 8},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			tests:  "internal",
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			crules: []Rule{
+				{Package: "test-pkg/...", Struct: "^A", Strategies: []string{"test-stg-1"}, MinSize: 8},
+				{Package: "other-pkg", Strategies: []string{"test-stg-2"}},
+				{File: "*.go", Strategies: []string{"test-stg-3", "test-stg-4"}},
 			},
-			err: errors.New(`can't build such walker "" test-2`),
-		},
-		"cli should return error on visiting error": {
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
 			cli: &Cli{
-				v:  visitor{},
-				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
-				wb: mocks.WalkerBuilder{Walker: mocks.Walker{Err: errors.New("test-3")}},
+				v: visitor{
+					regex:   regexp.MustCompile(`.*`),
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAst{
+						Pattern:    "test-pkg",
+						Root:       build.Default.GOPATH,
+						Path:       "test-path",
+						Tests:      typepkg.TestInternal,
+						ModeTypes:  packages.LoadAllSyntax,
+						ModeAst:    parser.ParseComments | parser.AllErrors,
+						BuildEnv:   []string{},
+						BuildFlags: []string{},
+					},
+					Exposer: m,
+					Printer: fmtio.NewGoprinter(4, 4, true),
+					Deep:    true,
+					Bref:    true,
+				},
+				sb:     strategies.Builder{Curator: m},
+				wname:  "test-w",
+				snames: []gopium.StrategyName{},
+				rules: []rule{
+					{
+						regex:  regexp.MustCompile(`^A`),
+						snames: []gopium.StrategyName{"test-stg-1"},
+						min:    8,
+					},
+					{
+						regex:  regexp.MustCompile(``),
+						file:   "*.go",
+						snames: []gopium.StrategyName{"test-stg-3", "test-stg-4"},
+					},
+				},
 			},
-			err: errors.New("visiting error happened test-3"),
 		},
-		"cli should return error on timeout": {
+		"new cli should return expected cli on valid parameters with config presets": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []int{2, 4, 8},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			tests:  "internal",
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"@test-1"},
+			presets: map[string][]string{
+				"test-1":  {"@test-2", "test-stg"},
+				"@test-2": {"test-stg"},
+			},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
 			cli: &Cli{
-				v:  visitor{timeout: time.Nanosecond},
-				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
-				wb: mocks.WalkerBuilder{Walker: mocks.Walker{Wait: 100 * time.Millisecond}},
+				v: visitor{
+					regex:   regexp.MustCompile(`.*`),
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAst{
+						Pattern:    "test-pkg",
+						Root:       build.Default.GOPATH,
+						Path:       "test-path",
+						Tests:      typepkg.TestInternal,
+						ModeTypes:  packages.LoadAllSyntax,
+						ModeAst:    parser.ParseComments | parser.AllErrors,
+						BuildEnv:   []string{},
+						BuildFlags: []string{},
+					},
+					Exposer: m,
+					Printer: fmtio.NewGoprinter(4, 4, true),
+					Deep:    true,
+					Bref:    true,
+				},
+				sb: strategies.Builder{
+					Curator: m,
+					Presets: map[gopium.StrategyName][]gopium.StrategyName{
+						"@test-1": {"@test-2", "test-stg"},
+						"@test-2": {"test-stg"},
+					},
+				},
+				wname:  "test-w",
+				snames: []gopium.StrategyName{"@test-1"},
 			},
-			err: errors.New("visiting error happened context deadline exceeded"),
 		},
-		"cli should return expected results on visiting": {
-			cli: &Cli{
-				v:  visitor{},
-				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
-				wb: mocks.WalkerBuilder{Walker: mocks.Walker{}},
+		"new cli should return error on invalid config rules": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []int{2, 4, 8},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			tests:  "internal",
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			crules: []Rule{
+				{Struct: "[", Strategies: []string{"test-stg"}},
 			},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			err: fmt.Errorf("can't resolve config rules %w", fmt.Errorf("can't compile rule #%d struct regexp %q %w", 0, "[", brerr)),
 		},
-	}
-	for name, tcase := range table {
-		t.Run(name, func(t *testing.T) {
-			// exec
-			err := tcase.cli.Run(context.Background())
-			// check
-			if !reflect.DeepEqual(err, tcase.err) {
-				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+		"new cli should return error on invalid package test variant": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []int{2, 4, 8},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			tests:  "test-tests",
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			err: errors.New(`can't use such package test variant "test-tests"`),
+		},
+		"new cli should return expected cli on valid parameters with external test variant": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []int{2, 4, 8},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			tests:  "external",
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			cli: &Cli{
+				v: visitor{
+					regex:   regexp.MustCompile(`.*`),
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAst{
+						Pattern:    "test-pkg",
+						Root:       build.Default.GOPATH,
+						Path:       "test-path",
+						Tests:      typepkg.TestExternal,
+						ModeTypes:  packages.LoadAllSyntax,
+						ModeAst:    parser.ParseComments | parser.AllErrors,
+						BuildEnv:   []string{},
+						BuildFlags: []string{},
+					},
+					Exposer: m,
+					Printer: fmtio.NewGoprinter(4, 4, true),
+					Deep:    true,
+					Bref:    true,
+				},
+				sb:     strategies.Builder{Curator: m},
+				wname:  "test-w",
+				snames: []gopium.StrategyName{"test-stg"},
+				xp: &typepkg.ParserXToolPackagesAst{
+					Pattern:    "test-pkg",
+					Root:       build.Default.GOPATH,
+					Path:       "test-path",
+					Tests:      typepkg.TestExternal,
+					ModeTypes:  packages.LoadAllSyntax,
+					ModeAst:    parser.ParseComments | parser.AllErrors,
+					BuildEnv:   []string{},
+					BuildFlags: []string{},
+				},
+				patterns: []string{"."},
+			},
+		},
+		"new cli should return error on invalid compiler arch combination": {
+			// target platform vars
+			compiler:  "cg",
+			arch:      "64amd64",
+			cpucaches: []int{2, 4, 8},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			tests:  "internal",
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			err: fmt.Errorf("can't set up maven %w", fmt.Errorf("unsuported compiler %q arch %q combination", "cg", "64amd64")),
+		},
+		"new cli should return expected cli on valid parameters with target matrix": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []int{2, 4, 8},
+			matrix:    []string{"linux/386/netgo/osusergo"},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{"TEST=1"},
+			bflags: []string{},
+			tests:  "internal",
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			cli: &Cli{
+				v: visitor{
+					regex:   regexp.MustCompile(`.*`),
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAst{
+						Pattern:    "test-pkg",
+						Root:       build.Default.GOPATH,
+						Path:       "test-path",
+						Tests:      typepkg.TestInternal,
+						ModeTypes:  packages.LoadAllSyntax,
+						ModeAst:    parser.ParseComments | parser.AllErrors,
+						BuildEnv:   []string{"TEST=1"},
+						BuildFlags: []string{},
+					},
+					Exposer: m,
+					Matrix: []walkers.Target{
+						{
+							Parser: &typepkg.ParserXToolPackagesAst{
+								Pattern:    "test-pkg",
+								Root:       build.Default.GOPATH,
+								Path:       "test-path",
+								Tests:      typepkg.TestInternal,
+								ModeTypes:  packages.LoadAllSyntax,
+								ModeAst:    parser.ParseComments | parser.AllErrors,
+								BuildEnv:   append(os.Environ(), "TEST=1", "GOOS=linux", "GOARCH=386"),
+								BuildFlags: []string{"-tags=netgo,osusergo"},
+							},
+							Exposer: m386,
+							Name:    "linux/386/netgo/osusergo",
+						},
+					},
+					Printer: fmtio.NewGoprinter(4, 4, true),
+					Deep:    true,
+					Bref:    true,
+				},
+				sb:     strategies.Builder{Curator: m},
+				wname:  "test-w",
+				snames: []gopium.StrategyName{"test-stg"},
+			},
+		},
+		"new cli should return error on invalid target matrix entry": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []int{2, 4, 8},
+			matrix:    []string{"linux/amd64", "linux"},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			tests:  "internal",
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			err: errors.New(`can't parse such target matrix entry "linux"`),
+		},
+		"new cli should return error on invalid target matrix arch": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []int{2, 4, 8},
+			matrix:    []string{"linux/64amd64"},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			tests:  "internal",
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			err: fmt.Errorf("can't set up target %q maven %w", "linux/64amd64", fmt.Errorf("unsuported compiler %q arch %q combination", "gc", "64amd64")),
+		},
+		"new cli should return error on regex compile error": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []int{2, 4, 8},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			tests:  "internal",
+			// walker vars
+			walker:  "test-w",
+			regex:   `[`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			err: fmt.Errorf("can't compile such regexp %w", brerr),
+		},
+		"new cli should return expected cli on valid parameters with selection filters": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []int{2, 4, 8},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			tests:  "internal",
+			// walker vars
+			walker:     "test-w",
+			regex:      `.*`,
+			includes:   []string{"^A", "^B"},
+			excludes:   []string{"Test$"},
+			files:      []string{"*_gen.go"},
+			minsize:    8,
+			maxsize:    64,
+			minwaste:   4,
+			exported:   true,
+			directives: []string{"gopium:budget", "ignore"},
+			deep:       true,
+			backref:    true,
+			stgs:       []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			cli: &Cli{
+				v: visitor{
+					regex: regexp.MustCompile(`.*`),
+					filters: walkers.Filters{
+						Include:    []*regexp.Regexp{regexp.MustCompile("^A"), regexp.MustCompile("^B")},
+						Exclude:    []*regexp.Regexp{regexp.MustCompile("Test$")},
+						Files:      []string{"*_gen.go"},
+						Directives: []string{"budget", "ignore"},
+						MinSize:    8,
+						MaxSize:    64,
+						MinWaste:   4,
+						Exported:   true,
+					},
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAst{
+						Pattern:    "test-pkg",
+						Root:       build.Default.GOPATH,
+						Path:       "test-path",
+						Tests:      typepkg.TestInternal,
+						ModeTypes:  packages.LoadAllSyntax,
+						ModeAst:    parser.ParseComments | parser.AllErrors,
+						BuildEnv:   []string{},
+						BuildFlags: []string{},
+					},
+					Exposer: m,
+					Printer: fmtio.NewGoprinter(4, 4, true),
+					Deep:    true,
+					Bref:    true,
+				},
+				sb:     strategies.Builder{Curator: m},
+				wname:  "test-w",
+				snames: []gopium.StrategyName{"test-stg"},
+			},
+		},
+		"new cli should return error on include regex compile error": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []int{2, 4, 8},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			tests:  "internal",
+			// walker vars
+			walker:   "test-w",
+			regex:    `.*`,
+			includes: []string{"^A", "["},
+			deep:     true,
+			backref:  true,
+			stgs:     []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			err: fmt.Errorf("can't compile such include regexp %q %w", "[", brerr),
+		},
+		"new cli should return error on exclude regex compile error": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []int{2, 4, 8},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			tests:  "internal",
+			// walker vars
+			walker:   "test-w",
+			regex:    `.*`,
+			excludes: []string{"("},
+			deep:     true,
+			backref:  true,
+			stgs:     []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			err: fmt.Errorf("can't compile such exclude regexp %q %w", "(", prerr),
+		},
+		"new cli should return error on invalid file glob": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []int{2, 4, 8},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			tests:  "internal",
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			files:   []string{"["},
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			err: fmt.Errorf("can't match such file glob %q %w", "[", filepath.ErrBadPattern),
+		},
+		"new cli should return error on invalid size bounds": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []int{2, 4, 8},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			tests:  "internal",
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			minsize: 64,
+			maxsize: 8,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			err: errors.New("min size 64 is bigger than max size 8"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			cli, err := NewCli(
+				tcase.compiler,
+				tcase.arch,
+				tcase.cpucaches,
+				tcase.matrix,
+				tcase.pkg,
+				tcase.path,
+				tcase.benvs,
+				tcase.bflags,
+				tcase.tests,
+				tcase.walker,
+				tcase.regex,
+				tcase.includes,
+				tcase.excludes,
+				tcase.files,
+				tcase.minsize,
+				tcase.maxsize,
+				tcase.minwaste,
+				tcase.exported,
+				tcase.directives,
+				tcase.deep,
+				tcase.backref,
+				tcase.rev,
+				tcase.stgs,
+				tcase.crules,
+				tcase.presets,
+				tcase.indent,
+				tcase.tabwidth,
+				tcase.usespace,
+				tcase.usegofmt,
+				tcase.timeout,
+				tcase.keepgoing,
+				tcase.trace,
+			)
+			// check
+			if !reflect.DeepEqual(cli, tcase.cli) {
+				t.Errorf("actual %v doesn't equal to expected %v", cli, tcase.cli)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestCliRun(t *testing.T) {
+	// prepare
+	m, err := typepkg.NewMavenGoTypes("gc", "386", 64, 64, 64)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	table := map[string]struct {
+		cli *Cli
+		err error
+	}{
+		"cli should return error on strategy builder error": {
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Err: errors.New("test-1")},
+			},
+			err: fmt.Errorf("can't build such strategy [] %w", errors.New("test-1")),
+		},
+		"cli should return error on walker builder error": {
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
+				wb: mocks.WalkerBuilder{Err: errors.New("test-2")},
+			},
+			err: fmt.Errorf("can't build such walker %q %w", "", errors.New("test-2")),
+		},
+		"cli should return error on visiting error": {
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
+				wb: mocks.WalkerBuilder{Walker: mocks.Walker{Err: errors.New("test-3")}},
+			},
+			err: fmt.Errorf("visiting error happened %w", errors.New("test-3")),
+		},
+		"cli should return error on timeout": {
+			cli: &Cli{
+				v:  visitor{timeout: time.Nanosecond},
+				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
+				wb: mocks.WalkerBuilder{Walker: mocks.Walker{Wait: 100 * time.Millisecond}},
+			},
+			err: fmt.Errorf("visiting error happened %w", context.DeadlineExceeded),
+		},
+		"cli should return error on config rule strategy builder error": {
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Err: errors.New("test-4")},
+				rules: []rule{
+					{snames: []gopium.StrategyName{"test-stg"}},
+				},
+			},
+			err: fmt.Errorf("can't build such strategy [] %w", errors.New("test-4")),
+		},
+		"cli should return expected results on visiting with config rules": {
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
+				wb: mocks.WalkerBuilder{Walker: mocks.Walker{}},
+				rules: []rule{
+					{snames: []gopium.StrategyName{"test-stg"}},
+				},
+			},
+		},
+		"cli should return error on package patterns loading error": {
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
+				wb: mocks.WalkerBuilder{Walker: mocks.Walker{}},
+				xp: &typepkg.ParserXToolPackagesAst{
+					Path:      tests.Gopium,
+					ModeTypes: packages.LoadFiles,
+				},
+				patterns: []string{"./tests/data/empty"},
+			},
+			err: fmt.Errorf("can't load packages %w", fmt.Errorf("packages %q weren't found at %q", "./tests/data/empty", tests.Gopium)),
+		},
+		"cli should return error on package patterns config rules error": {
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
+				wb: mocks.WalkerBuilder{Walker: mocks.Walker{}},
+				xp: &typepkg.ParserXToolPackagesAst{
+					Path:       tests.Gopium,
+					ModeTypes:  packages.LoadFiles,
+					BuildFlags: []string{"-tags=tests_data"},
+				},
+				patterns: []string{"./tests/data/single"},
+				crules: []Rule{
+					{Package: "[", Strategies: []string{"test-stg"}},
+				},
+			},
+			err: fmt.Errorf("can't resolve config rules %w", fmt.Errorf("can't match rule #%d package glob %q %w", 0, "[", filepath.ErrBadPattern)),
+		},
+		"cli should return error on package patterns visiting error": {
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
+				wb: mocks.WalkerBuilder{Walker: mocks.Walker{Err: errors.New("test-5")}},
+				xp: &typepkg.ParserXToolPackagesAst{
+					Path:       tests.Gopium,
+					ModeTypes:  packages.LoadFiles,
+					BuildFlags: []string{"-tags=tests_data"},
+				},
+				patterns: []string{"./tests/data/single", "./tests/data/flat"},
+			},
+			err: fmt.Errorf("package %q %w", "github.com/1pkg/gopium/tests/data/flat", fmt.Errorf("visiting error happened %w", errors.New("test-5"))),
+		},
+		"cli should return collected errors on package patterns keep going visiting errors": {
+			cli: &Cli{
+				v:  visitor{keep: true},
+				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
+				wb: mocks.WalkerBuilder{Walker: mocks.Walker{Err: errors.New("test-6")}},
+				xp: &typepkg.ParserXToolPackagesAst{
+					Path:       tests.Gopium,
+					ModeTypes:  packages.LoadFiles,
+					BuildFlags: []string{"-tags=tests_data"},
+				},
+				patterns: []string{"./tests/data/single", "./tests/data/flat"},
+			},
+			err: gopium.Errors{
+				fmt.Errorf("package %q %w", "github.com/1pkg/gopium/tests/data/flat", fmt.Errorf("visiting error happened %w", errors.New("test-6"))),
+				fmt.Errorf("package %q %w", "github.com/1pkg/gopium/tests/data/single", fmt.Errorf("visiting error happened %w", errors.New("test-6"))),
+			},
+		},
+		"cli should return error on package patterns walker builder error": {
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
+				wb: walkers.Builder{},
+				xp: &typepkg.ParserXToolPackagesAst{
+					Path:       tests.Gopium,
+					ModeTypes:  packages.LoadFiles,
+					BuildFlags: []string{"-tags=tests_data"},
+				},
+				patterns: []string{"./tests/data/single", "./tests/data/flat"},
+				crules: []Rule{
+					{Package: "github.com/1pkg/gopium/tests/data/...", Strategies: []string{"test-stg"}},
+				},
+				rev: "test-rev",
+			},
+			err: fmt.Errorf("package %q %w", "github.com/1pkg/gopium/tests/data/flat", fmt.Errorf("can't build such walker %q %w", "", fmt.Errorf("walker %q wasn't found", ""))),
+		},
+		"cli should return error on package patterns target loading error": {
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
+				wb: walkers.Builder{
+					Matrix: []walkers.Target{
+						{
+							Parser: &typepkg.ParserXToolPackagesAst{
+								Path:      tests.Gopium,
+								ModeTypes: packages.LoadFiles,
+							},
+							Name: "linux/amd64",
+						},
+					},
+				},
+				xp: &typepkg.ParserXToolPackagesAst{
+					Path:       tests.Gopium,
+					ModeTypes:  packages.LoadFiles,
+					BuildFlags: []string{"-tags=tests_data"},
+				},
+				patterns: []string{"./tests/data/single"},
+			},
+			err: fmt.Errorf("can't load target %q packages %w", "linux/amd64", fmt.Errorf("packages %q weren't found at %q", "./tests/data/single", tests.Gopium)),
+		},
+		"cli should return error on package patterns target parser error": {
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
+				wb: walkers.Builder{
+					Matrix: []walkers.Target{{Parser: mocks.Parser{}, Name: "linux/amd64"}},
+				},
+				xp: &typepkg.ParserXToolPackagesAst{
+					Path:       tests.Gopium,
+					ModeTypes:  packages.LoadFiles,
+					BuildFlags: []string{"-tags=tests_data"},
+				},
+				patterns: []string{"./tests/data/single"},
+			},
+			err: errors.New(`can't load target "linux/amd64" packages`),
+		},
+		"cli should return error on package patterns with target walker builder error": {
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
+				wb: walkers.Builder{
+					Matrix: []walkers.Target{
+						{
+							Parser: &typepkg.ParserXToolPackagesAst{
+								Path:       tests.Gopium,
+								ModeTypes:  packages.LoadFiles,
+								BuildFlags: []string{"-tags=tests_data"},
+							},
+							Name: "linux/amd64",
+						},
+					},
+				},
+				xp: &typepkg.ParserXToolPackagesAst{
+					Path:       tests.Gopium,
+					ModeTypes:  packages.LoadFiles,
+					BuildFlags: []string{"-tags=tests_data"},
+				},
+				patterns: []string{"./tests/data/single"},
+			},
+			err: fmt.Errorf("package %q %w", "github.com/1pkg/gopium/tests/data/single", fmt.Errorf("can't build such walker %q %w", "", fmt.Errorf("walker %q wasn't found", ""))),
+		},
+		"cli should return error on target walker builder error": {
+			cli: &Cli{
+				v:      visitor{},
+				sb:     strategies.Builder{},
+				wb:     walkers.Builder{Matrix: []walkers.Target{{Exposer: m, Name: "linux/386"}}},
+				snames: []gopium.StrategyName{strategies.PadSys},
+				rules: []rule{
+					{snames: []gopium.StrategyName{strategies.Pack}},
+				},
+			},
+			err: fmt.Errorf("can't build such walker %q %w", "", fmt.Errorf("walker %q wasn't found", "")),
+		},
+		"cli should return expected results on visiting": {
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
+				wb: mocks.WalkerBuilder{Walker: mocks.Walker{}},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			err := tcase.cli.Run(context.Background())
+			// check
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestCliRunErrorsIs(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errTest := errors.New("test")
+	table := map[string]struct {
+		ctx context.Context
+		cli *Cli
+		is  error
+	}{
+		"cli should return wrapped error on package patterns loading cancelation": {
+			ctx: cctx,
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
+				wb: mocks.WalkerBuilder{Walker: mocks.Walker{}},
+				xp: &typepkg.ParserXToolPackagesAst{
+					Path:      tests.Gopium,
+					ModeTypes: packages.LoadFiles,
+				},
+				patterns: []string{"./tests/data/single"},
+			},
+			is: context.Canceled,
+		},
+		"cli should return wrapped error on strategy builder error": {
+			ctx: context.Background(),
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Err: errTest},
+				wb: mocks.WalkerBuilder{Walker: mocks.Walker{}},
+			},
+			is: errTest,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			err := tcase.cli.Run(tcase.ctx)
+			// check
+			if !errors.Is(err, tcase.is) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.is)
+			}
+		})
+	}
+}
+
+func TestCliBuilder(t *testing.T) {
+	// prepare
+	xp := &typepkg.ParserXToolPackagesAst{
+		Path:       tests.Gopium,
+		ModeTypes:  packages.LoadFiles,
+		BuildFlags: []string{"-tags=tests_data"},
+	}
+	pps, err := xp.Packages(context.Background(), "./tests/data/single")
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	pp := pps[0]
+	pkg := "github.com/1pkg/gopium/tests/data/single"
+	dir := filepath.Join(tests.Gopium, "tests", "data", "single")
+	tpp := &typepkg.ParserXToolPackage{Package: &packages.Package{PkgPath: pkg}}
+	tpps := []map[string]*typepkg.ParserXToolPackage{{pkg: tpp}, {}}
+	table := map[string]struct {
+		cli *Cli
+		wb  gopium.WalkerBuilder
+	}{
+		"cli should return non walkers builder as is": {
+			cli: &Cli{wb: mocks.WalkerBuilder{}, rev: "HEAD"},
+			wb:  mocks.WalkerBuilder{},
+		},
+		"cli should return builder with package parser": {
+			cli: &Cli{wb: walkers.Builder{Deep: true}},
+			wb:  walkers.Builder{Deep: true, Parser: pp},
+		},
+		"cli should return builder with package baseline parser located at package dir": {
+			cli: &Cli{wb: walkers.Builder{Deep: true}, rev: "HEAD"},
+			wb: walkers.Builder{
+				Deep:   true,
+				Parser: pp,
+				Baseline: typepkg.ParserGitRevision{
+					Rev:     "HEAD",
+					Pattern: pkg,
+					Root:    pp.Ast.Root,
+					Path:    pp.Ast.Path,
+				},
+			},
+		},
+		"cli should return builder with package matrix targets parsers": {
+			cli: &Cli{
+				wb: walkers.Builder{
+					Deep:   true,
+					Matrix: []walkers.Target{{Name: "linux/amd64"}, {Name: "linux/386"}},
+				},
+			},
+			wb: walkers.Builder{
+				Deep:   true,
+				Parser: pp,
+				Matrix: []walkers.Target{{Parser: tpp, Name: "linux/amd64"}},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			wb := tcase.cli.builder(pp, tpps)
+			// check
+			if !reflect.DeepEqual(wb, tcase.wb) {
+				t.Errorf("actual %v doesn't equal to expected %v", wb, tcase.wb)
+			}
+			// baseline should be located
+			// at package dir on revision
+			if b, ok := wb.(walkers.Builder); ok && b.Baseline != nil {
+				bp := b.Baseline.(typepkg.ParserGitRevision)
+				if !reflect.DeepEqual(filepath.Join(bp.Root, bp.Path), dir) {
+					t.Errorf("actual %v doesn't equal to expected %v", filepath.Join(bp.Root, bp.Path), dir)
+				}
+			}
+		})
+	}
+}
+
+func TestOrder(t *testing.T) {
+	// prepare
+	a := &packages.Package{PkgPath: "a", Imports: map[string]*packages.Package{}}
+	b := &packages.Package{PkgPath: "b", Imports: map[string]*packages.Package{}}
+	c := &packages.Package{PkgPath: "c", Imports: map[string]*packages.Package{}}
+	x := &packages.Package{PkgPath: "x"}
+	a.Imports["c"] = c
+	a.Imports["b"] = b
+	a.Imports["x"] = x
+	b.Imports["c"] = c
+	cy1 := &packages.Package{PkgPath: "cy1", Imports: map[string]*packages.Package{}}
+	cy2 := &packages.Package{PkgPath: "cy2", Imports: map[string]*packages.Package{}}
+	cy1.Imports["cy2"] = cy2
+	cy2.Imports["cy1"] = cy1
+	table := map[string]struct {
+		pkgs    []*packages.Package
+		order   []string
+		imports map[string][]*packages.Package
+	}{
+		"empty packages should return empty order": {
+			order:   []string{},
+			imports: map[string][]*packages.Package{},
+		},
+		"independent packages should keep their order": {
+			pkgs:    []*packages.Package{x, c},
+			order:   []string{"x", "c"},
+			imports: map[string][]*packages.Package{"x": nil, "c": nil},
+		},
+		"dependent packages should follow import order": {
+			pkgs:  []*packages.Package{a, x, b, c},
+			order: []string{"x", "c", "b", "a"},
+			imports: map[string][]*packages.Package{
+				"a": {b, c, x},
+				"b": {c},
+				"c": nil,
+				"x": nil,
+			},
+		},
+		"dependent packages should skip imports outside of packages": {
+			pkgs:  []*packages.Package{a, b},
+			order: []string{"b", "a"},
+			imports: map[string][]*packages.Package{
+				"a": {b},
+				"b": nil,
+			},
+		},
+		"cyclic packages should place first remaining package": {
+			pkgs:  []*packages.Package{cy2, cy1, c},
+			order: []string{"c", "cy2", "cy1"},
+			imports: map[string][]*packages.Package{
+				"cy1": {cy2},
+				"cy2": {cy1},
+				"c":   nil,
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			pps := make([]*typepkg.ParserXToolPackage, 0, len(tcase.pkgs))
+			for _, pkg := range tcase.pkgs {
+				pps = append(pps, &typepkg.ParserXToolPackage{Package: pkg})
+			}
+			// exec
+			pps = order(pps)
+			// check
+			paths := make([]string, 0, len(pps))
+			imports := make(map[string][]*packages.Package, len(pps))
+			for _, pp := range pps {
+				paths = append(paths, pp.Package.PkgPath)
+				imports[pp.Package.PkgPath] = pp.Imports
+			}
+			if !reflect.DeepEqual(paths, tcase.order) {
+				t.Errorf("actual %v doesn't equal to expected %v", paths, tcase.order)
+			}
+			if !reflect.DeepEqual(imports, tcase.imports) {
+				t.Errorf("actual %v doesn't equal to expected %v", imports, tcase.imports)
 			}
 		})
 	}
@@ -0,0 +1,202 @@
+package runners
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/build"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/1pkg/gopium/typepkg"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// list of discovered config file names
+// in order of discovery priority
+var configs = []string{".gopium.yaml", ".gopium.yml", ".gopium.json", ".gopium.toml"}
+
+// Config defines gopium repository configuration
+// that provides default cli parameters,
+// ordered list of strategies rules and strategies presets,
+// zero values are treated as not set
+type Config struct {
+	CPUCaches []int               `json:"target_cpu_cache_lines_sizes" yaml:"target_cpu_cache_lines_sizes" toml:"target_cpu_cache_lines_sizes" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Rules     []Rule              `json:"rules" yaml:"rules" toml:"rules" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Compiler  string              `json:"target_compiler" yaml:"target_compiler" toml:"target_compiler" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Arch      string              `json:"target_architecture" yaml:"target_architecture" toml:"target_architecture" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Walker    string              `json:"walker" yaml:"walker" toml:"walker" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Regex     string              `json:"walker_regexp" yaml:"walker_regexp" toml:"walker_regexp" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Deep      *bool               `json:"walker_deep" yaml:"walker_deep" toml:"walker_deep" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Backref   *bool               `json:"walker_backref" yaml:"walker_backref" toml:"walker_backref" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Presets   map[string][]string `json:"presets" yaml:"presets" toml:"presets" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Indent    int                 `json:"printer_indent" yaml:"printer_indent" toml:"printer_indent" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	TabWidth  int                 `json:"printer_tab_width" yaml:"printer_tab_width" toml:"printer_tab_width" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	UseSpace  *bool               `json:"printer_use_space" yaml:"printer_use_space" toml:"printer_use_space" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	UseGofmt  *bool               `json:"printer_use_gofmt" yaml:"printer_use_gofmt" toml:"printer_use_gofmt" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_         [24]byte            `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 192 bytes; struct align: 8 bytes; struct aligned size: 192 bytes; - 🌺 gopium @1pkg
+
+// Rule defines single configuration rule
+// that maps structures selected by
+// package glob, file glob, struct regexp and min size
+// to strategies pipeline, empty selectors match anything
+type Rule struct {
+	Strategies []string `json:"strategies" yaml:"strategies" toml:"strategies" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Package    string   `json:"package" yaml:"package" toml:"package" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	File       string   `json:"file" yaml:"file" toml:"file" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Struct     string   `json:"struct" yaml:"struct" toml:"struct" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	MinSize    int64    `json:"min_size" yaml:"min_size" toml:"min_size" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_          [48]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
+
+// LoadConfig helps to load gopium configuration
+// either from provided config file path
+// or from config file discovered upward
+// from package dir, if no config file was
+// discovered empty config is returned
+func LoadConfig(config string, pkg string, path string, benvs []string, bflags []string) (Config, error) {
+	// in case config file path was provided
+	// just read config from it
+	if config != "" {
+		return readConfig(config)
+	}
+	// otherwise go upward from package dir
+	// and try to discover config file
+	_, root, path, err := resolve(pkg, path, benvs, bflags)
+	if err != nil {
+		return Config{}, err
+	}
+	dir, err := filepath.Abs(filepath.Join(root, path))
+	if err != nil {
+		return Config{}, err
+	}
+	for {
+		for _, name := range configs {
+			config := filepath.Join(dir, name)
+			if info, err := os.Stat(config); err == nil && !info.IsDir() {
+				return readConfig(config)
+			}
+		}
+		// stop on filesystem root
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return Config{}, nil
+		}
+		dir = parent
+	}
+}
+
+// readConfig helps to read and strictly decode
+// config file accordingly to its extension
+func readConfig(config string) (Config, error) {
+	var cfg Config
+	buf, err := ioutil.ReadFile(config)
+	if err != nil {
+		return cfg, fmt.Errorf("can't read config %q %w", config, err)
+	}
+	switch ext := filepath.Ext(config); ext {
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(buf, &cfg)
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(buf))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&cfg)
+	case ".toml":
+		var md toml.MetaData
+		md, err = toml.Decode(string(buf), &cfg)
+		// check that all keys were decoded
+		if undec := md.Undecoded(); err == nil && len(undec) > 0 {
+			keys := make([]string, 0, len(undec))
+			for _, key := range undec {
+				keys = append(keys, key.String())
+			}
+			err = fmt.Errorf("unknown keys %s", strings.Join(keys, ", "))
+		}
+	default:
+		err = fmt.Errorf("unsupported config extension %q", ext)
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("can't decode config %q %w", config, err)
+	}
+	return cfg, nil
+}
+
+// resolve helps to resolve package name,
+// root and path from package and path template,
+// if no path template has been provided
+// package is located the same way go command does it
+// and its import path and dir are returned,
+// otherwise root is set to gopath only if
+// not absolute path has been provided,
+// package patterns are resolved to current dir
+// unless path without package template has been provided
+func resolve(pkg string, path string, benvs []string, bflags []string) (string, string, string, error) {
+	// resolve package patterns
+	// to current dir
+	if split(pkg) != nil && (path == "" || strings.Contains(path, "{{package}}")) {
+		return pkg, "", "", nil
+	}
+	// locate package with go command
+	// if no path has been provided
+	if path == "" {
+		xp := &typepkg.ParserXToolPackagesAst{
+			Pattern:    pkg,
+			BuildEnv:   benvs,
+			BuildFlags: bflags,
+		}
+		pkg, dir, err := xp.Locate(context.Background())
+		if err != nil {
+			return "", "", "", fmt.Errorf("can't locate package %w", err)
+		}
+		return pkg, "", dir, nil
+	}
+	// replace package template
+	path = strings.Replace(path, "{{package}}", pkg, 1)
+	// set root to gopath only if
+	// not absolute path has been provided
+	var root string
+	if !filepath.IsAbs(path) {
+		root = build.Default.GOPATH
+	}
+	return pkg, root, path, nil
+}
+
+// split helps to split package
+// to list of packages patterns
+// separated by comma, it returns nil
+// for single package without wildcards
+func split(pkg string) []string {
+	if !strings.Contains(pkg, ",") && !strings.Contains(pkg, "...") {
+		return nil
+	}
+	patterns := make([]string, 0, strings.Count(pkg, ",")+1)
+	for _, pattern := range strings.Split(pkg, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// target helps to parse matrix target entry
+// in `GOOS/GOARCH[/tag/tag]` format
+// to target os, arch and build tags
+func target(entry string) (string, string, []string, error) {
+	parts := strings.Split(strings.TrimSpace(entry), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", nil, fmt.Errorf("can't parse such target matrix entry %q", entry)
+	}
+	tags := make([]string, 0, len(parts)-2)
+	for _, tag := range parts[2:] {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return parts[0], parts[1], tags, nil
+}
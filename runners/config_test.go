@@ -0,0 +1,320 @@
+package runners
+
+import (
+	"errors"
+	"fmt"
+	"go/build"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/tests"
+)
+
+func TestLoadConfig(t *testing.T) {
+	// prepare
+	root, err := ioutil.TempDir("", "gopium")
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	defer os.RemoveAll(root)
+	write := func(name string, content string) string {
+		fpath := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(fpath), 0755); err != nil {
+			t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+		}
+		if err := ioutil.WriteFile(fpath, []byte(content), 0644); err != nil {
+			t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+		}
+		return fpath
+	}
+	write("yaml/.gopium.yaml", `
+target_architecture: arm64
+target_cpu_cache_lines_sizes: [128, 128]
+walker: ast_go
+walker_regexp: ^A
+walker_deep: true
+printer_use_gofmt: false
+rules:
+  - package: 1pkg/...
+    struct: ^A
+    min_size: 16
+    strategies: [memory_pack, cache_rounding_cpu_l1_discrete]
+  - file: "*_gen.go"
+    strategies: [ignore]
+presets:
+  fast: ["@perf", struct_annotate_comment]
+`)
+	write("yaml/pkg/sub/file.go", "package sub")
+	write("json/.gopium.json", `{"walker": "ast_std", "printer_indent": 2, "rules": [{"strategies": ["memory_pack"]}]}`)
+	write("toml/.gopium.toml", `
+target_compiler = "gccgo"
+walker_backref = false
+printer_use_space = true
+
+[[rules]]
+struct = "^B"
+strategies = ["memory_unpack"]
+`)
+	write("invalid/yaml/.gopium.yaml", "walkers: ast_go")
+	write("invalid/json/.gopium.json", `{"walker": 1}`)
+	write("invalid/toml/.gopium.toml", `walkers = "ast_go"`)
+	truncated := write("invalid/truncated.json", `{"walker": `)
+	invalid := write("invalid/config.ini", "walker=ast_go")
+	tfalse, ttrue := false, true
+	table := map[string]struct {
+		config string
+		path   string
+		cfg    Config
+		err    error
+		is     error
+	}{
+		"yaml config should be discovered upward from package dir": {
+			path: filepath.Join(root, "yaml", "pkg", "sub"),
+			cfg: Config{
+				Arch:      "arm64",
+				CPUCaches: []int{128, 128},
+				Walker:    "ast_go",
+				Regex:     "^A",
+				Deep:      &ttrue,
+				UseGofmt:  &tfalse,
+				Rules: []Rule{
+					{
+						Package:    "1pkg/...",
+						Struct:     "^A",
+						MinSize:    16,
+						Strategies: []string{"memory_pack", "cache_rounding_cpu_l1_discrete"},
+					},
+					{
+						File:       "*_gen.go",
+						Strategies: []string{"ignore"},
+					},
+				},
+				Presets: map[string][]string{
+					"fast": {"@perf", "struct_annotate_comment"},
+				},
+			},
+		},
+		"json config should be read from provided config path": {
+			config: filepath.Join(root, "json", ".gopium.json"),
+			path:   filepath.Join(root, "yaml"),
+			cfg: Config{
+				Walker: "ast_std",
+				Indent: 2,
+				Rules: []Rule{
+					{
+						Strategies: []string{"memory_pack"},
+					},
+				},
+			},
+		},
+		"toml config should be discovered in package dir": {
+			path: filepath.Join(root, "toml"),
+			cfg: Config{
+				Compiler: "gccgo",
+				Backref:  &tfalse,
+				UseSpace: &ttrue,
+				Rules: []Rule{
+					{
+						Struct:     "^B",
+						Strategies: []string{"memory_unpack"},
+					},
+				},
+			},
+		},
+		"missing config should return empty config": {
+			path: root,
+		},
+		"missing config path should return error": {
+			config: filepath.Join(root, "missing.yaml"),
+			err:    errors.New("can't read config"),
+			is:     os.ErrNotExist,
+		},
+		"invalid yaml config should return error": {
+			path: filepath.Join(root, "invalid", "yaml"),
+			err:  errors.New("can't decode config"),
+		},
+		"invalid json config should return error": {
+			path: filepath.Join(root, "invalid", "json"),
+			err:  errors.New("can't decode config"),
+		},
+		"truncated json config should return wrapped decode error": {
+			config: truncated,
+			err:    errors.New("can't decode config"),
+			is:     io.ErrUnexpectedEOF,
+		},
+		"invalid toml config should return error": {
+			path: filepath.Join(root, "invalid", "toml"),
+			err:  errors.New("can't decode config"),
+		},
+		"unsupported config extension should return error": {
+			config: invalid,
+			err:    errors.New("can't decode config"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			cfg, err := LoadConfig(tcase.config, "test", tcase.path, nil, nil)
+			// check
+			if !reflect.DeepEqual(cfg, tcase.cfg) {
+				t.Errorf("actual %v doesn't equal to expected %v", cfg, tcase.cfg)
+			}
+			// config errors contain tmp paths
+			// so just check errors prefixes
+			if !strings.HasPrefix(fmt.Sprint(err), fmt.Sprint(tcase.err)) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			if tcase.is != nil && !errors.Is(err, tcase.is) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.is)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		pkg   string
+		path  string
+		rpkg  string
+		root  string
+		rpath string
+		err   bool
+	}{
+		"package path template should be resolved relatively to gopath": {
+			pkg:   "test-pkg",
+			path:  filepath.Join("src", "{{package}}"),
+			rpkg:  "test-pkg",
+			root:  build.Default.GOPATH,
+			rpath: filepath.Join("src", "test-pkg"),
+		},
+		"absolute package path should be resolved as is": {
+			pkg:   "test-pkg",
+			path:  tests.OnOS("windows", "c:\\test-path", "/test-path").(string),
+			rpkg:  "test-pkg",
+			rpath: tests.OnOS("windows", "c:\\test-path", "/test-path").(string),
+		},
+		"package patterns should be resolved to current dir": {
+			pkg:  "./...",
+			path: filepath.Join("src", "{{package}}"),
+			rpkg: "./...",
+		},
+		"package import path should be located with go command": {
+			pkg:   "github.com/1pkg/gopium/runners",
+			rpkg:  "github.com/1pkg/gopium/runners",
+			rpath: filepath.Join(tests.Gopium, "runners"),
+		},
+		"package relative dir should be located with go command": {
+			pkg:   ".",
+			rpkg:  "github.com/1pkg/gopium/runners",
+			rpath: filepath.Join(tests.Gopium, "runners"),
+		},
+		"unknown package should return locate error": {
+			pkg: "./unknown",
+			err: true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			pkg, root, path, err := resolve(tcase.pkg, tcase.path, nil, nil)
+			// check
+			// go command errors contain local paths
+			// so just check errors presence
+			if !reflect.DeepEqual(err != nil, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			if !reflect.DeepEqual(pkg, tcase.rpkg) {
+				t.Errorf("actual %v doesn't equal to expected %v", pkg, tcase.rpkg)
+			}
+			if !reflect.DeepEqual(root, tcase.root) {
+				t.Errorf("actual %v doesn't equal to expected %v", root, tcase.root)
+			}
+			if !reflect.DeepEqual(path, tcase.rpath) {
+				t.Errorf("actual %v doesn't equal to expected %v", path, tcase.rpath)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		pkg      string
+		patterns []string
+	}{
+		"single package shouldn't be split": {
+			pkg: "github.com/1pkg/gopium",
+		},
+		"wildcard package should be split to single pattern": {
+			pkg:      "./...",
+			patterns: []string{"./..."},
+		},
+		"comma separated packages should be split to patterns": {
+			pkg:      "./a, example.com/svc/...,,",
+			patterns: []string{"./a", "example.com/svc/..."},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			patterns := split(tcase.pkg)
+			// check
+			if !reflect.DeepEqual(patterns, tcase.patterns) {
+				t.Errorf("actual %v doesn't equal to expected %v", patterns, tcase.patterns)
+			}
+		})
+	}
+}
+
+func TestTarget(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		entry  string
+		goos   string
+		goarch string
+		tags   []string
+		err    error
+	}{
+		"os arch entry should be parsed to os and arch": {
+			entry:  "linux/amd64",
+			goos:   "linux",
+			goarch: "amd64",
+			tags:   []string{},
+		},
+		"os arch tags entry should be parsed to os, arch and tags": {
+			entry:  " darwin/arm64/netgo//osusergo ",
+			goos:   "darwin",
+			goarch: "arm64",
+			tags:   []string{"netgo", "osusergo"},
+		},
+		"entry without arch should return error": {
+			entry: "linux/",
+			err:   errors.New(`can't parse such target matrix entry "linux/"`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			goos, goarch, tags, err := target(tcase.entry)
+			// check
+			if !reflect.DeepEqual(goos, tcase.goos) {
+				t.Errorf("actual %v doesn't equal to expected %v", goos, tcase.goos)
+			}
+			if !reflect.DeepEqual(goarch, tcase.goarch) {
+				t.Errorf("actual %v doesn't equal to expected %v", goarch, tcase.goarch)
+			}
+			if !reflect.DeepEqual(tags, tcase.tags) {
+				t.Errorf("actual %v doesn't equal to expected %v", tags, tcase.tags)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
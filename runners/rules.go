@@ -0,0 +1,117 @@
+package runners
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// rule defines single resolved config rule
+// that selects structures by
+// file glob, struct regexp and min size
+type rule struct {
+	snames []gopium.StrategyName `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	stg    gopium.Strategy       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	file   string                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	regex  *regexp.Regexp        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	min    int64                 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_      [56]byte              `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
+
+// newRules helps to resolve config rules
+// that match provided package to list of rules
+// or returns error on invalid rules
+func newRules(pkg string, crules []Rule) ([]rule, error) {
+	rules := make([]rule, 0, len(crules))
+	for i, crule := range crules {
+		// check package glob
+		if ok, err := pmatch(crule.Package, pkg); err != nil {
+			return nil, fmt.Errorf("can't match rule #%d package glob %q %w", i, crule.Package, err)
+		} else if !ok {
+			continue
+		}
+		// check file glob
+		if _, err := filepath.Match(crule.File, ""); err != nil {
+			return nil, fmt.Errorf("can't match rule #%d file glob %q %w", i, crule.File, err)
+		}
+		// compile struct regexp
+		regex, err := regexp.Compile(crule.Struct)
+		if err != nil {
+			return nil, fmt.Errorf("can't compile rule #%d struct regexp %q %w", i, crule.Struct, err)
+		}
+		// cast strategies strings to strategy names
+		if len(crule.Strategies) == 0 {
+			return nil, fmt.Errorf("rule #%d strategies list is empty", i)
+		}
+		snames := make([]gopium.StrategyName, 0, len(crule.Strategies))
+		for _, strategy := range crule.Strategies {
+			snames = append(snames, gopium.StrategyName(strategy))
+		}
+		rules = append(rules, rule{
+			regex:  regex,
+			file:   crule.File,
+			snames: snames,
+			min:    crule.MinSize,
+		})
+	}
+	return rules, nil
+}
+
+// pmatch checks if package matches package glob,
+// empty glob matches any package and
+// glob ending with `/...` matches all subpackages
+func pmatch(glob string, pkg string) (bool, error) {
+	switch {
+	case glob == "":
+		return true, nil
+	case strings.HasSuffix(glob, "/..."):
+		prefix := strings.TrimSuffix(glob, "/...")
+		return pkg == prefix || strings.HasPrefix(pkg, prefix+"/"), nil
+	default:
+		return path.Match(glob, pkg)
+	}
+}
+
+// match checks if rule selects structure
+// with provided location and size
+func (r rule) match(st gopium.Struct, loc string, size int64) bool {
+	// check file glob against location base name,
+	// glob was already validated
+	if r.file != "" {
+		if ok, _ := filepath.Match(r.file, filepath.Base(loc)); !ok {
+			return false
+		}
+	}
+	return r.regex.MatchString(st.Name) && size >= r.min
+}
+
+// rules defines strategy implementation
+// that applies first matched rule strategy
+// to structure or default strategy otherwise
+type rules struct {
+	rules []rule          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	def   gopium.Strategy `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_     [24]byte        `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// Apply rules implementation
+func (stg rules) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// grab structure location
+	// and structure size
+	loc, _ := gopium.Loc(ctx)
+	size, _ := collections.SizeAlign(o)
+	// go through all rules in order
+	// and apply first matched rule
+	for _, r := range stg.rules {
+		if r.match(o, loc, size) {
+			return r.stg.Apply(ctx, o)
+		}
+	}
+	return stg.def.Apply(ctx, o)
+}
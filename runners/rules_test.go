@@ -0,0 +1,147 @@
+package runners
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestRules(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		ctx   context.Context
+		rules rules
+		o     gopium.Struct
+		r     gopium.Struct
+		err   error
+	}{
+		"struct without matched rule should be applied to default strategy": {
+			ctx: context.Background(),
+			rules: rules{
+				rules: []rule{
+					{regex: regexp.MustCompile(`^B`), stg: &mocks.Strategy{R: gopium.Struct{Name: "rule"}}},
+				},
+				def: &mocks.Strategy{R: gopium.Struct{Name: "def"}},
+			},
+			o: gopium.Struct{Name: "A"},
+			r: gopium.Struct{Name: "def"},
+		},
+		"struct with matched rule should be applied to first rule strategy": {
+			ctx: context.Background(),
+			rules: rules{
+				rules: []rule{
+					{regex: regexp.MustCompile(`^A`), stg: &mocks.Strategy{R: gopium.Struct{Name: "rule-1"}}},
+					{regex: regexp.MustCompile(``), stg: &mocks.Strategy{R: gopium.Struct{Name: "rule-2"}}},
+				},
+				def: &mocks.Strategy{R: gopium.Struct{Name: "def"}},
+			},
+			o: gopium.Struct{Name: "A"},
+			r: gopium.Struct{Name: "rule-1"},
+		},
+		"struct smaller than rule min size should skip the rule": {
+			ctx: context.Background(),
+			rules: rules{
+				rules: []rule{
+					{regex: regexp.MustCompile(`^A`), min: 16, stg: &mocks.Strategy{R: gopium.Struct{Name: "rule-1"}}},
+					{regex: regexp.MustCompile(``), min: 8, stg: &mocks.Strategy{R: gopium.Struct{Name: "rule-2"}}},
+				},
+				def: &mocks.Strategy{R: gopium.Struct{Name: "def"}},
+			},
+			o: gopium.Struct{Name: "A", Fields: []gopium.Field{{Size: 8, Align: 8}}},
+			r: gopium.Struct{Name: "rule-2"},
+		},
+		"struct with matched file glob should be applied to rule strategy": {
+			ctx: gopium.WithLoc(context.Background(), "/test/file_gen.go"),
+			rules: rules{
+				rules: []rule{
+					{regex: regexp.MustCompile(``), file: "*_test.go", stg: &mocks.Strategy{R: gopium.Struct{Name: "rule-1"}}},
+					{regex: regexp.MustCompile(``), file: "*_gen.go", stg: &mocks.Strategy{R: gopium.Struct{Name: "rule-2"}}},
+				},
+				def: &mocks.Strategy{R: gopium.Struct{Name: "def"}},
+			},
+			o: gopium.Struct{Name: "A"},
+			r: gopium.Struct{Name: "rule-2"},
+		},
+		"struct with matched rule should return rule strategy error": {
+			ctx: context.Background(),
+			rules: rules{
+				rules: []rule{
+					{regex: regexp.MustCompile(`^A`), stg: &mocks.Strategy{Err: errors.New("test")}},
+				},
+				def: &mocks.Strategy{R: gopium.Struct{Name: "def"}},
+			},
+			o:   gopium.Struct{Name: "A"},
+			err: errors.New("test"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.rules.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestPmatch(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		glob string
+		pkg  string
+		ok   bool
+		err  error
+	}{
+		"empty glob should match any package": {
+			pkg: "github.com/1pkg/gopium",
+			ok:  true,
+		},
+		"subpackages glob should match package itself": {
+			glob: "github.com/1pkg/...",
+			pkg:  "github.com/1pkg",
+			ok:   true,
+		},
+		"subpackages glob should match nested package": {
+			glob: "github.com/1pkg/...",
+			pkg:  "github.com/1pkg/gopium/runners",
+			ok:   true,
+		},
+		"subpackages glob shouldn't match package with same prefix": {
+			glob: "github.com/1pkg/...",
+			pkg:  "github.com/1pkgs",
+		},
+		"path glob should match package": {
+			glob: "github.com/*/gopium",
+			pkg:  "github.com/1pkg/gopium",
+			ok:   true,
+		},
+		"invalid path glob should return error": {
+			glob: "[",
+			pkg:  "github.com/1pkg/gopium",
+			err:  errors.New("syntax error in pattern"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			ok, err := pmatch(tcase.glob, tcase.pkg)
+			// check
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
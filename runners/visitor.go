@@ -3,10 +3,13 @@ package runners
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/walkers"
 )
 
 // visitor defines helper
@@ -16,9 +19,13 @@ import (
 // - walker building
 // - visiting
 type visitor struct {
-	regex   *regexp.Regexp `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	timeout time.Duration  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; - 🌺 gopium @1pkg
+	filters walkers.Filters `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	regex   *regexp.Regexp  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	timeout time.Duration   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	keep    bool            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	trace   bool            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [46]byte        `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 192 bytes; struct align: 8 bytes; struct aligned size: 192 bytes; - 🌺 gopium @1pkg
 
 // strategy builds strategy instance
 // by using builder and strategies names
@@ -26,7 +33,7 @@ func (visitor) strategy(b gopium.StrategyBuilder, snames []gopium.StrategyName)
 	// build strategy
 	stg, err := b.Build(snames...)
 	if err != nil {
-		return nil, fmt.Errorf("can't build such strategy %v %v", snames, err)
+		return nil, fmt.Errorf("can't build such strategy %v %w", snames, err)
 	}
 	return stg, nil
 }
@@ -37,7 +44,7 @@ func (visitor) walker(b gopium.WalkerBuilder, wname gopium.WalkerName) (gopium.W
 	// build walker
 	walker, err := b.Build(wname)
 	if err != nil {
-		return nil, fmt.Errorf("can't build such walker %q %v", wname, err)
+		return nil, fmt.Errorf("can't build such walker %q %w", wname, err)
 	}
 	return walker, nil
 }
@@ -51,8 +58,64 @@ func (v visitor) visit(ctx context.Context, w gopium.Walker, stg gopium.Strategy
 		ctx = nctx
 	}
 	// exec visit on walker with strategy
+	// and selection filters, keep going
+	// and tracing flags attached
+	ctx = gopium.WithKeepGoing(walkers.WithFilters(ctx, v.filters), v.keep)
+	ctx = walkers.WithTrace(ctx, v.trace)
 	if err := w.Visit(ctx, v.regex, stg); err != nil {
-		return fmt.Errorf("visiting error happened %v", err)
+		return fmt.Errorf("visiting error happened %w", err)
 	}
 	return nil
 }
+
+// newFilters helps to set up structures
+// selection filters from cli parameters
+// or returns error on invalid filters
+func newFilters(
+	includes,
+	excludes,
+	files []string,
+	minsize,
+	maxsize,
+	minwaste int,
+	exported bool,
+	directives []string,
+) (walkers.Filters, error) {
+	f := walkers.Filters{
+		MinSize:  int64(minsize),
+		MaxSize:  int64(maxsize),
+		MinWaste: int64(minwaste),
+		Exported: exported,
+	}
+	// compile include and exclude regexps
+	for _, include := range includes {
+		regex, err := regexp.Compile(include)
+		if err != nil {
+			return walkers.Filters{}, fmt.Errorf("can't compile such include regexp %q %w", include, err)
+		}
+		f.Include = append(f.Include, regex)
+	}
+	for _, exclude := range excludes {
+		regex, err := regexp.Compile(exclude)
+		if err != nil {
+			return walkers.Filters{}, fmt.Errorf("can't compile such exclude regexp %q %w", exclude, err)
+		}
+		f.Exclude = append(f.Exclude, regex)
+	}
+	// check file globs
+	for _, file := range files {
+		if _, err := filepath.Match(file, ""); err != nil {
+			return walkers.Filters{}, fmt.Errorf("can't match such file glob %q %w", file, err)
+		}
+		f.Files = append(f.Files, file)
+	}
+	// check size bounds
+	if minsize > 0 && maxsize > 0 && minsize > maxsize {
+		return walkers.Filters{}, fmt.Errorf("min size %d is bigger than max size %d", minsize, maxsize)
+	}
+	// trim directives names
+	for _, directive := range directives {
+		f.Directives = append(f.Directives, strings.TrimPrefix(directive, "gopium:"))
+	}
+	return f, nil
+}
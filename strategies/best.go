@@ -0,0 +1,154 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// list of best metrics names
+const (
+	// aligned struct size
+	msize = "size"
+	// struct pointer data size
+	mptrdata = "ptrdata"
+	// number of fields that changed their position
+	mmoved = "moved"
+	// number of cpu l1 cache lines touched by struct fields
+	mlines = "lines"
+	// go runtime allocator size class of struct
+	mclass = "class"
+)
+
+// best defines strategy implementation
+// that applies all candidates strategies
+// concurently on the same struct and picks
+// the result with the lowest metric score,
+// in case of equal scores the earlier candidate wins,
+// the winner is recorded in struct comment annotation
+type best struct {
+	stgs    []gopium.Strategy `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	names   []string          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	curator gopium.Curator    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	metric  string            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [48]byte          `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
+
+// Curator erich best strategy with curator instance
+func (stg best) Curator(curator gopium.Curator) best {
+	stg.curator = curator
+	return stg
+}
+
+// Apply best implementation
+func (stg best) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// in case there are no candidates
+	// just return result back
+	if len(stg.stgs) == 0 {
+		return r, ctx.Err()
+	}
+	// create sync error group
+	// with cancelation context
+	results := make([]gopium.Struct, len(stg.stgs))
+	group, gctx := errgroup.WithContext(ctx)
+	// go through all candidates and apply
+	// them concurently on struct copies
+	for i := range stg.stgs {
+		i := i
+		group.Go(func() error {
+			// apply candidate on struct copy
+			tmp, err := stg.stgs[i].Apply(gctx, collections.CopyStruct(o))
+			// in case of any error
+			// just return error back
+			if err != nil {
+				return err
+			}
+			results[i] = tmp
+			return gctx.Err()
+		})
+	}
+	// wait until all candidates
+	// have been applied and resolved
+	if err := group.Wait(); err != nil {
+		return o, err
+	}
+	// pick the candidate with the lowest score
+	win, wscore := 0, stg.score(o, results[0])
+	for i := 1; i < len(results); i++ {
+		if score := stg.score(o, results[i]); score < wscore {
+			win, wscore = i, score
+		}
+	}
+	r = results[win]
+	// note winner in struct comment
+	note := fmt.Sprintf(
+		"// best of: candidate %q won by %s metric with score %d; - %s",
+		stg.names[win],
+		stg.metric,
+		wscore,
+		gopium.STAMP,
+	)
+	r.Comment = append(r.Comment, note)
+	return r, ctx.Err()
+}
+
+// score helps to calculate result struct
+// metric score, the lower score is the better
+func (stg best) score(o gopium.Struct, r gopium.Struct) int64 {
+	size, _ := collections.SizeAlign(r)
+	switch stg.metric {
+	case mptrdata:
+		return collections.PtrData(r)
+	case mmoved:
+		// count all fields that differ
+		// from original fields in place
+		// and all removed original fields
+		var moved int64
+		for i, f := range r.Fields {
+			if i >= len(o.Fields) || o.Fields[i].Name != f.Name || o.Fields[i].Type != f.Type {
+				moved++
+			}
+		}
+		if flen := len(o.Fields) - len(r.Fields); flen > 0 {
+			moved += int64(flen)
+		}
+		return moved
+	case mlines:
+		// in case cache line is unknown
+		// fallback to struct size
+		line := stg.curator.SysCache(1)
+		if line <= 0 {
+			return size
+		}
+		// count distinct cache lines touched
+		// by fields offsets ranges skipping pads
+		var offset, lines, last int64 = 0, 0, -1
+		collections.WalkStruct(r, 0, func(pad int64, fields ...gopium.Field) {
+			offset += pad
+			for _, f := range fields {
+				if f.Size > 0 && !collections.IsPad(f) {
+					first, end := offset/line, (offset+f.Size-1)/line
+					if first <= last {
+						first = last + 1
+					}
+					if end >= first {
+						lines += end - first + 1
+						last = end
+					}
+				}
+				offset += f.Size
+			}
+		})
+		return lines
+	case mclass:
+		return collections.SizeClass(size)
+	default:
+		return size
+	}
+}
@@ -0,0 +1,164 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestBest(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	o := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{Name: "test1", Type: "bool", Size: 1, Align: 1},
+			{Name: "test2", Type: "*int64", Size: 8, Align: 8, Ptr: 8},
+			{Name: "test3", Type: "bool", Size: 1, Align: 1},
+		},
+	}
+	ptr := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{Name: "test2", Type: "*int64", Size: 8, Align: 8, Ptr: 8},
+			{Name: "test1", Type: "bool", Size: 1, Align: 1},
+			{Name: "test3", Type: "bool", Size: 1, Align: 1},
+		},
+	}
+	spread := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{Name: "test1", Type: "int64", Size: 8, Align: 8},
+			collections.PadField(24),
+			{Name: "test2", Type: "bool", Size: 1, Align: 1},
+		},
+	}
+	table := map[string]struct {
+		best best
+		ctx  context.Context
+		o    gopium.Struct
+		r    gopium.Struct
+		err  error
+	}{
+		"empty candidates should be applied to itself": {
+			best: best{metric: msize},
+			ctx:  context.Background(),
+			o:    o,
+			r:    o,
+		},
+		"size metric should pick the smallest candidate": {
+			best: best{stgs: []gopium.Strategy{ignr, pck}, names: []string{"ignore", "pack"}, metric: msize},
+			ctx:  context.Background(),
+			o:    o,
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{`// best of: candidate "pack" won by size metric with score 16; - 🌺 gopium @1pkg`},
+				Fields:  ptr.Fields,
+			},
+		},
+		"class metric should pick the smallest candidate": {
+			best: best{stgs: []gopium.Strategy{ignr, pck}, names: []string{"ignore", "pack"}, metric: mclass},
+			ctx:  context.Background(),
+			o:    o,
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{`// best of: candidate "pack" won by class metric with score 16; - 🌺 gopium @1pkg`},
+				Fields:  ptr.Fields,
+			},
+		},
+		"ptrdata metric should pick the smallest candidate": {
+			best: best{stgs: []gopium.Strategy{ignr, &mocks.Strategy{R: ptr}}, names: []string{"ignore", "mock"}, metric: mptrdata},
+			ctx:  context.Background(),
+			o:    o,
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{`// best of: candidate "mock" won by ptrdata metric with score 8; - 🌺 gopium @1pkg`},
+				Fields:  ptr.Fields,
+			},
+		},
+		"moved metric should pick the least changed candidate": {
+			best: best{stgs: []gopium.Strategy{pck, fpad, ignr}, names: []string{"pack", "filter_pads", "ignore"}, metric: mmoved},
+			ctx:  context.Background(),
+			o:    o,
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{`// best of: candidate "filter_pads" won by moved metric with score 0; - 🌺 gopium @1pkg`},
+				Fields:  o.Fields,
+			},
+		},
+		"moved metric should count removed fields": {
+			best: best{stgs: []gopium.Strategy{filter{nregex: regexp.MustCompile("test3")}, pck}, names: []string{"filter", "pack"}, metric: mmoved},
+			ctx:  context.Background(),
+			o:    o,
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{`// best of: candidate "filter" won by moved metric with score 1; - 🌺 gopium @1pkg`},
+				Fields:  o.Fields[:2],
+			},
+		},
+		"lines metric should pick the candidate touching less cache lines": {
+			best: best{stgs: []gopium.Strategy{ignr, pck}, names: []string{"ignore", "pack"}, metric: mlines}.Curator(mocks.Maven{SCache: []int64{16}}),
+			ctx:  context.Background(),
+			o:    o,
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{`// best of: candidate "pack" won by lines metric with score 1; - 🌺 gopium @1pkg`},
+				Fields:  ptr.Fields,
+			},
+		},
+		"lines metric should count only cache lines touched by fields": {
+			best: best{stgs: []gopium.Strategy{ignr}, names: []string{"ignore"}, metric: mlines}.Curator(mocks.Maven{SCache: []int64{16}}),
+			ctx:  context.Background(),
+			o:    spread,
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{`// best of: candidate "ignore" won by lines metric with score 2; - 🌺 gopium @1pkg`},
+				Fields:  spread.Fields,
+			},
+		},
+		"lines metric without cache line should fallback to size": {
+			best: best{stgs: []gopium.Strategy{ignr, ignr}, names: []string{"ignore", "ignore"}, metric: mlines}.Curator(mocks.Maven{}),
+			ctx:  context.Background(),
+			o:    o,
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{`// best of: candidate "ignore" won by lines metric with score 24; - 🌺 gopium @1pkg`},
+				Fields:  o.Fields,
+			},
+		},
+		"candidate error should be applied to itself with error": {
+			best: best{stgs: []gopium.Strategy{pck, &mocks.Strategy{Err: errors.New("test")}}, names: []string{"pack", "mock"}, metric: msize},
+			ctx:  context.Background(),
+			o:    o,
+			r:    o,
+			err:  errors.New("test"),
+		},
+		"canceled context should be applied to itself with error": {
+			best: best{stgs: []gopium.Strategy{pck, ignr}, names: []string{"pack", "ignore"}, metric: msize},
+			ctx:  cctx,
+			o:    o,
+			r:    o,
+			err:  context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.best.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of budget presets
+var (
+	bdgt = budget{}
+)
+
+// budget defines strategy implementation
+// that verifies that structure aligned size and align
+// stay within structure declared budget, budget is declared
+// either by `//gopium:budget size=64 align=8` struct directive
+// or by `gopium:"budget:size=64 align=8"` field tag token,
+// in case of context diagnoser exceeded budget is reported
+// to diagnoser as non fatal diagnostic instead of error
+type budget struct{}
+
+// Apply budget implementation
+func (stg budget) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// find and parse struct budget
+	size, align, ok, err := budgetp(r)
+	// in case of any error
+	// just return error back
+	if err != nil {
+		return o, err
+	}
+	// in case struct has no budget
+	// just skip verification
+	if !ok {
+		return r, ctx.Err()
+	}
+	// then verify struct size and align
+	// against declared budget
+	rsize, ralign := collections.SizeAlign(r)
+	var errs []error
+	if size > 0 && rsize > size {
+		errs = append(errs, fmt.Errorf(
+			"struct %q aligned size %d bytes exceeds budget size %d bytes",
+			r.Name,
+			rsize,
+			size,
+		))
+	}
+	if align > 0 && ralign > align {
+		errs = append(errs, fmt.Errorf(
+			"struct %q align %d bytes exceeds budget align %d bytes",
+			r.Name,
+			ralign,
+			align,
+		))
+	}
+	// in case of context diagnoser
+	// report all exceeded budgets to it
+	// otherwise fail on the first one
+	if d, ok := gopium.Diagnose(ctx); ok && d != nil {
+		for _, err := range errs {
+			d.Diagnose(err)
+		}
+		return r, ctx.Err()
+	}
+	if len(errs) > 0 {
+		return o, errs[0]
+	}
+	return r, ctx.Err()
+}
+
+// budgetp helps to find and parse struct budget,
+// struct directive has priority over fields tags,
+// fields tags are checked in fields order as
+// tagged first field might be already reordered
+// - `//gopium:budget size=64 align=8` parsed as directive
+// - `gopium:"budget:size=64 align=8;stg,stg"` parsed as tag token
+func budgetp(st gopium.Struct) (size int64, align int64, ok bool, err error) {
+	// check struct budget directive first
+	if body, ok := directive(st, "budget"); ok {
+		size, align, err = budgetkv(body)
+		return size, align, true, err
+	}
+	// go through all fields tags
+	for _, f := range st.Fields {
+		// grab the field tag
+		tag, ok := reflect.StructTag(f.Tag).Lookup(gopium.NAME)
+		if !ok {
+			continue
+		}
+		if btoken, ok := budgett(tag); ok {
+			size, align, err = budgetkv(strings.TrimPrefix(btoken, "budget:"))
+			return size, align, true, err
+		}
+	}
+	return 0, 0, false, nil
+}
+
+// budgett helps to find budget token
+// inside gopium tag if any
+func budgett(tag string) (string, bool) {
+	for _, token := range esplit(tag, ';') {
+		if strings.HasPrefix(token, "budget:") {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+// budgetkv helps to parse budget
+// `size=64 align=8` key value pairs
+// separated either by spaces or commas
+func budgetkv(body string) (size int64, align int64, err error) {
+	// split body to key value pairs
+	kvs := strings.FieldsFunc(body, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+	if len(kvs) == 0 {
+		return 0, 0, fmt.Errorf("budget %q can't be parsed, neither size nor align was found", body)
+	}
+	for _, kv := range kvs {
+		// parse single pair
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			return 0, 0, fmt.Errorf("budget %q can't be parsed, key value pair %q is invalid", body, kv)
+		}
+		val, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil || val <= 0 {
+			return 0, 0, fmt.Errorf("budget %q can't be parsed, value %q isn't positive integer", body, parts[1])
+		}
+		switch parts[0] {
+		case "size":
+			size = val
+		case "align":
+			align = val
+		default:
+			return 0, 0, fmt.Errorf("budget %q can't be parsed, key %q is unknown", body, parts[0])
+		}
+	}
+	return size, align, nil
+}
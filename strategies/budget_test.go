@@ -0,0 +1,340 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestBudget(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		ctx context.Context
+		o   gopium.Struct
+		r   gopium.Struct
+		err error
+	}{
+		"empty struct should be applied to itself": {
+			ctx: context.Background(),
+		},
+		"non empty struct without budget should be applied to itself": {
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+		},
+		"non empty struct within directive budget should be applied to itself": {
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget size=16 align=8"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test2",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget size=16 align=8"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test2",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+		},
+		"non empty struct within budget should be applied to itself on canceled context": {
+			ctx: cctx,
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget size=8"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget size=8"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+		"non empty struct exceeding directive size budget should be applied to itself with error": {
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"test", "budget size=8"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test2",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"test", "budget size=8"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test2",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			err: errors.New(`struct "test" aligned size 16 bytes exceeds budget size 8 bytes`),
+		},
+		"non empty struct exceeding tag align budget should be applied to itself with error": {
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"budget:size=16,align=4;memory_pack"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"budget:size=16,align=4;memory_pack"`,
+					},
+				},
+			},
+			err: errors.New(`struct "test" align 8 bytes exceeds budget align 4 bytes`),
+		},
+		"non empty struct with invalid budget key should be applied to itself with error": {
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget length=8"},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget length=8"},
+			},
+			err: errors.New(`budget "length=8" can't be parsed, key "length" is unknown`),
+		},
+		"non empty struct with invalid budget value should be applied to itself with error": {
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `gopium:"budget:size=-1"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `gopium:"budget:size=-1"`,
+					},
+				},
+			},
+			err: errors.New(`budget "size=-1" can't be parsed, value "-1" isn't positive integer`),
+		},
+		"non empty struct with empty budget should be applied to itself with error": {
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget"},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget"},
+			},
+			err: errors.New(`budget "" can't be parsed, neither size nor align was found`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := bdgt.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestBudgetDiagnostics(t *testing.T) {
+	// prepare
+	o := gopium.Struct{
+		Name:       "test",
+		Directives: []string{"budget size=8 align=4"},
+		Fields: []gopium.Field{
+			{
+				Name:  "test1",
+				Size:  8,
+				Align: 8,
+			},
+			{
+				Name:  "test2",
+				Size:  4,
+				Align: 4,
+			},
+		},
+	}
+	table := map[string]struct {
+		diagnose bool
+		o        gopium.Struct
+		r        gopium.Struct
+		ds       collections.Diagnostics
+		err      error
+	}{
+		"struct exceeding budget should be applied to itself with error without diagnoser": {
+			o:   o,
+			r:   o,
+			err: errors.New(`struct "test" aligned size 16 bytes exceeds budget size 8 bytes`),
+		},
+		"struct exceeding budget should be applied to itself with diagnostics with diagnoser": {
+			diagnose: true,
+			o:        o,
+			r:        o,
+			ds: collections.Diagnostics{
+				{
+					Struct:  "test",
+					Loc:     "test-loc",
+					Message: `struct "test" aligned size 16 bytes exceeds budget size 8 bytes`,
+				},
+				{
+					Struct:  "test",
+					Loc:     "test-loc",
+					Message: `struct "test" align 8 bytes exceeds budget align 4 bytes`,
+				},
+			},
+		},
+		"struct within budget should be applied to itself without diagnostics with diagnoser": {
+			diagnose: true,
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget size=8"},
+				Fields:     []gopium.Field{{Name: "test", Size: 8, Align: 8}},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget size=8"},
+				Fields:     []gopium.Field{{Name: "test", Size: 8, Align: 8}},
+			},
+		},
+		"struct with invalid budget should be applied to itself with error with diagnoser": {
+			diagnose: true,
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget test=8"},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget test=8"},
+			},
+			err: errors.New(`budget "test=8" can't be parsed, key "test" is unknown`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			ctx := context.Background()
+			d := collections.NewDiagnoser("test", "test-loc")
+			if tcase.diagnose {
+				ctx = gopium.WithDiagnoser(ctx, d)
+			}
+			// exec
+			r, err := bdgt.Apply(ctx, tcase.o)
+			ds := d.Diagnostics()
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(ds, tcase.ds) {
+				t.Errorf("actual %v doesn't equal to expected %v", ds, tcase.ds)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
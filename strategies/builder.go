@@ -21,6 +21,11 @@ const (
 	FShareL2 gopium.StrategyName = "false_sharing_cpu_l2"
 	FShareL3 gopium.StrategyName = "false_sharing_cpu_l3"
 	FShareB  gopium.StrategyName = "false_sharing_bytes_%d"
+	// hinted fields isolations
+	IsolateL1 gopium.StrategyName = "isolate_fields_cpu_l1"
+	IsolateL2 gopium.StrategyName = "isolate_fields_cpu_l2"
+	IsolateL3 gopium.StrategyName = "isolate_fields_cpu_l3"
+	IsolateB  gopium.StrategyName = "isolate_fields_bytes_%d"
 	// cache line pad roundings
 	CacheL1D gopium.StrategyName = "cache_rounding_cpu_l1_discrete"
 	CacheL2D gopium.StrategyName = "cache_rounding_cpu_l2_discrete"
@@ -41,6 +46,10 @@ const (
 	SepL2B  gopium.StrategyName = "separate_padding_cpu_l2_bottom"
 	SepL3B  gopium.StrategyName = "separate_padding_cpu_l3_bottom"
 	SepBB   gopium.StrategyName = "separate_padding_bytes_%d_bottom"
+	// pads representation styles
+	StyleB   gopium.StrategyName = "padding_style_bytes"
+	StyleU64 gopium.StrategyName = "padding_style_uint64"
+	StyleCPU gopium.StrategyName = "padding_style_cache_line"
 	// tag processors and modifiers
 	ProcTag  gopium.StrategyName = "process_tag_group"
 	AddTagS  gopium.StrategyName = "add_tag_group_soft"
@@ -58,143 +67,268 @@ const (
 	NLexDesc gopium.StrategyName = "name_lexicographical_descending"
 	TLexAsc  gopium.StrategyName = "type_lexicographical_ascending"
 	TLexDesc gopium.StrategyName = "type_lexicographical_descending"
+	// size budget verifications
+	Budget gopium.StrategyName = "enforce_budget"
 	// filters and others
 	FPad   gopium.StrategyName = "filter_pads"
 	Ignore gopium.StrategyName = "ignore"
 )
 
+// list of built-in strategies presets names
+const (
+	Compact    gopium.StrategyName = "@compact"
+	Perf       gopium.StrategyName = "@perf"
+	Concurrent gopium.StrategyName = "@concurrent"
+)
+
+// list of built-in strategies presets
+var presets = map[gopium.StrategyName][]gopium.StrategyName{
+	Compact:    {FPad, Pack},
+	Perf:       {Compact, CacheL1D},
+	Concurrent: {FPad, FShareL1},
+}
+
 // Builder defines types gopium.StrategyBuilder implementation
-// that uses gopium.Curator as an exposer and related strategies
+// that uses gopium.Curator as an exposer and related strategies,
+// user defined presets override built-in presets with the same name,
+// in case of trace flag pipe stages are named so they can be traced
 type Builder struct {
-	Curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; - 🌺 gopium @1pkg
+	Curator gopium.Curator                                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Presets map[gopium.StrategyName][]gopium.StrategyName `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Trace   bool                                          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [7]byte                                       `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
 
 // Build Builder implementation
 func (b Builder) Build(names ...gopium.StrategyName) (gopium.Strategy, error) {
+	// build strategies pipe
+	// from original names
+	p, err := b.build(names, names, nil)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// build helps to build strategies pipe from names list,
+// presets are expanded recursively and expressions are compiled,
+// tnames holds original names that are used by tags strategies
+// to keep presets and expressions in tags as is
+// and chain holds currently expanded presets
+// which is used to detect presets cycles
+func (b Builder) build(names []gopium.StrategyName, tnames []gopium.StrategyName, chain []gopium.StrategyName) (pipe, error) {
 	// prepare result strategy pipe
 	p := make(pipe, 0, len(names))
 	for _, name := range names {
 		var stg gopium.Strategy
-		// build strategy by name
+		var err error
+		// build strategy by name kind
 		switch {
-		// pack/unpack mem util
-		case b.marchp(name, Pack):
-			stg = pck
-		case b.marchp(name, Unpack):
-			stg = unpck
-		// explicit sys/type pads
-		case b.marchp(name, PadSys):
-			stg = padsys.Curator(b.Curator)
-		case b.marchp(name, PadTnat):
-			stg = padtnat.Curator(b.Curator)
-		// false sharing guards
-		case b.marchp(name, FShareL1):
-			stg = fsharel1.Curator(b.Curator)
-		case b.marchp(name, FShareL2):
-			stg = fsharel2.Curator(b.Curator)
-		case b.marchp(name, FShareL3):
-			stg = fsharel3.Curator(b.Curator)
-		case b.marchp(name, FShareB):
-			var bytes uint
-			if err := b.scanp(name, FShareB, &bytes); err != nil {
-				return nil, err
-			}
-			stg = fshareb.Bytes(bytes).Curator(b.Curator)
-		// cache line pad roundings
-		case b.marchp(name, CacheL1D):
-			stg = cachel1d.Curator(b.Curator)
-		case b.marchp(name, CacheL2D):
-			stg = cachel2d.Curator(b.Curator)
-		case b.marchp(name, CacheL3D):
-			stg = cachel3d.Curator(b.Curator)
-		case b.marchp(name, CacheBD):
-			var bytes uint
-			if err := b.scanp(name, CacheBD, &bytes); err != nil {
-				return nil, err
-			}
-			stg = cachebd.Bytes(bytes).Curator(b.Curator)
-		case b.marchp(name, CacheL1F):
-			stg = cachel1f.Curator(b.Curator)
-		case b.marchp(name, CacheL2F):
-			stg = cachel2f.Curator(b.Curator)
-		case b.marchp(name, CacheL3F):
-			stg = cachel3f.Curator(b.Curator)
-		case b.marchp(name, CacheBF):
-			var bytes uint
-			if err := b.scanp(name, CacheBF, &bytes); err != nil {
-				return nil, err
-			}
-			stg = cachebf.Bytes(bytes).Curator(b.Curator)
-		// top, bottom separate pads
-		case b.marchp(name, SepSysT):
-			stg = sepsyst.Curator(b.Curator)
-		case b.marchp(name, SepSysB):
-			stg = sepsysb.Curator(b.Curator)
-		case b.marchp(name, SepL1T):
-			stg = sepl1t.Curator(b.Curator)
-		case b.marchp(name, SepL2T):
-			stg = sepl2t.Curator(b.Curator)
-		case b.marchp(name, SepL3T):
-			stg = sepl3t.Curator(b.Curator)
-		case b.marchp(name, SepBT):
-			var bytes uint
-			if err := b.scanp(name, SepBT, &bytes); err != nil {
-				return nil, err
-			}
-			stg = sepbt.Bytes(bytes).Curator(b.Curator)
-		case b.marchp(name, SepL1B):
-			stg = sepl1b.Curator(b.Curator)
-		case b.marchp(name, SepL2B):
-			stg = sepl2b.Curator(b.Curator)
-		case b.marchp(name, SepL3B):
-			stg = sepl3b.Curator(b.Curator)
-		case b.marchp(name, SepBB):
-			var bytes uint
-			if err := b.scanp(name, SepBB, &bytes); err != nil {
-				return nil, err
-			}
-			stg = sepbb.Bytes(bytes).Curator(b.Curator)
-		// tag processors and modifiers
-		case b.marchp(name, ProcTag):
-			stg = ptag.Builder(b)
-		case b.marchp(name, AddTagS):
-			stg = tags.Names(names...)
-		case b.marchp(name, AddTagF):
-			stg = tagf.Names(names...)
-		case b.marchp(name, AddTagSD):
-			stg = tagsd.Names(names...)
-		case b.marchp(name, AddTagFD):
-			stg = tagfd.Names(names...)
-		case b.marchp(name, RmTagF):
-			stg = tagf
-		// doc and comment annotations
-		case b.marchp(name, FNoteDoc):
-			stg = fnotedoc
-		case b.marchp(name, FNoteCom):
-			stg = fnotecom
-		case b.marchp(name, StNoteDoc):
-			stg = stnotedoc
-		case b.marchp(name, StNoteCom):
-			stg = stnotecom
-		// lexicographical, length, embedded, exported sorts
-		case b.marchp(name, NLexAsc):
-			stg = nlexasc
-		case b.marchp(name, NLexDesc):
-			stg = nlexdesc
-		case b.marchp(name, TLexAsc):
-			stg = tlexasc
-		case b.marchp(name, TLexDesc):
-			stg = tlexdesc
-		// filters and others
-		case b.marchp(name, FPad):
-			stg = fpad
-		case b.marchp(name, Ignore):
-			stg = ignr
+		case strings.HasPrefix(string(name), "@"):
+			stg, err = b.preset(name, tnames, chain)
+		case expression(name):
+			stg, err = b.compile(name, tnames, chain)
 		default:
-			return nil, fmt.Errorf("strategy %q wasn't found", name)
+			stg, err = b.single(name, tnames)
+		}
+		// in case of any error
+		// just return it back
+		if err != nil {
+			return nil, err
 		}
 		// append strategy to pipe
-		p = append(p, stg)
+		// flatten nested pipes
+		// and name stages for tracing
+		switch np, ok := stg.(pipe); {
+		case ok:
+			p = append(p, np...)
+		case b.Trace:
+			p = append(p, stage{stg: stg, name: name})
+		default:
+			p = append(p, stg)
+		}
+	}
+	return p, nil
+}
+
+// single helps to build single
+// registered strategy by name
+func (b Builder) single(name gopium.StrategyName, tnames []gopium.StrategyName) (gopium.Strategy, error) {
+	var stg gopium.Strategy
+	// build strategy by name
+	switch {
+	// pack/unpack mem util
+	case b.marchp(name, Pack):
+		stg = pck
+	case b.marchp(name, Unpack):
+		stg = unpck
+	// explicit sys/type pads
+	case b.marchp(name, PadSys):
+		stg = padsys.Curator(b.Curator)
+	case b.marchp(name, PadTnat):
+		stg = padtnat.Curator(b.Curator)
+	// false sharing guards
+	case b.marchp(name, FShareL1):
+		stg = fsharel1.Curator(b.Curator)
+	case b.marchp(name, FShareL2):
+		stg = fsharel2.Curator(b.Curator)
+	case b.marchp(name, FShareL3):
+		stg = fsharel3.Curator(b.Curator)
+	case b.marchp(name, FShareB):
+		var bytes uint
+		if err := b.scanp(name, FShareB, &bytes); err != nil {
+			return nil, err
+		}
+		stg = fshareb.Bytes(bytes).Curator(b.Curator)
+	// hinted fields isolations
+	case b.marchp(name, IsolateL1):
+		stg = isolatel1.Curator(b.Curator)
+	case b.marchp(name, IsolateL2):
+		stg = isolatel2.Curator(b.Curator)
+	case b.marchp(name, IsolateL3):
+		stg = isolatel3.Curator(b.Curator)
+	case b.marchp(name, IsolateB):
+		var bytes uint
+		if err := b.scanp(name, IsolateB, &bytes); err != nil {
+			return nil, err
+		}
+		stg = isolateb.Bytes(bytes).Curator(b.Curator)
+	// cache line pad roundings
+	case b.marchp(name, CacheL1D):
+		stg = cachel1d.Curator(b.Curator)
+	case b.marchp(name, CacheL2D):
+		stg = cachel2d.Curator(b.Curator)
+	case b.marchp(name, CacheL3D):
+		stg = cachel3d.Curator(b.Curator)
+	case b.marchp(name, CacheBD):
+		var bytes uint
+		if err := b.scanp(name, CacheBD, &bytes); err != nil {
+			return nil, err
+		}
+		stg = cachebd.Bytes(bytes).Curator(b.Curator)
+	case b.marchp(name, CacheL1F):
+		stg = cachel1f.Curator(b.Curator)
+	case b.marchp(name, CacheL2F):
+		stg = cachel2f.Curator(b.Curator)
+	case b.marchp(name, CacheL3F):
+		stg = cachel3f.Curator(b.Curator)
+	case b.marchp(name, CacheBF):
+		var bytes uint
+		if err := b.scanp(name, CacheBF, &bytes); err != nil {
+			return nil, err
+		}
+		stg = cachebf.Bytes(bytes).Curator(b.Curator)
+	// top, bottom separate pads
+	case b.marchp(name, SepSysT):
+		stg = sepsyst.Curator(b.Curator)
+	case b.marchp(name, SepSysB):
+		stg = sepsysb.Curator(b.Curator)
+	case b.marchp(name, SepL1T):
+		stg = sepl1t.Curator(b.Curator)
+	case b.marchp(name, SepL2T):
+		stg = sepl2t.Curator(b.Curator)
+	case b.marchp(name, SepL3T):
+		stg = sepl3t.Curator(b.Curator)
+	case b.marchp(name, SepBT):
+		var bytes uint
+		if err := b.scanp(name, SepBT, &bytes); err != nil {
+			return nil, err
+		}
+		stg = sepbt.Bytes(bytes).Curator(b.Curator)
+	case b.marchp(name, SepL1B):
+		stg = sepl1b.Curator(b.Curator)
+	case b.marchp(name, SepL2B):
+		stg = sepl2b.Curator(b.Curator)
+	case b.marchp(name, SepL3B):
+		stg = sepl3b.Curator(b.Curator)
+	case b.marchp(name, SepBB):
+		var bytes uint
+		if err := b.scanp(name, SepBB, &bytes); err != nil {
+			return nil, err
+		}
+		stg = sepbb.Bytes(bytes).Curator(b.Curator)
+	// pads representation styles
+	case b.marchp(name, StyleB):
+		stg = stylebytes.Curator(b.Curator)
+	case b.marchp(name, StyleU64):
+		stg = styleu64.Curator(b.Curator)
+	case b.marchp(name, StyleCPU):
+		stg = stylecpu.Curator(b.Curator)
+	// tag processors and modifiers
+	case b.marchp(name, ProcTag):
+		stg = ptag.Builder(b)
+	case b.marchp(name, AddTagS):
+		stg = tags.Names(tnames...)
+	case b.marchp(name, AddTagF):
+		stg = tagf.Names(tnames...)
+	case b.marchp(name, AddTagSD):
+		stg = tagsd.Names(tnames...)
+	case b.marchp(name, AddTagFD):
+		stg = tagfd.Names(tnames...)
+	case b.marchp(name, RmTagF):
+		stg = tagf
+	// doc and comment annotations
+	case b.marchp(name, FNoteDoc):
+		stg = fnotedoc
+	case b.marchp(name, FNoteCom):
+		stg = fnotecom
+	case b.marchp(name, StNoteDoc):
+		stg = stnotedoc
+	case b.marchp(name, StNoteCom):
+		stg = stnotecom
+	// lexicographical, length, embedded, exported sorts
+	case b.marchp(name, NLexAsc):
+		stg = nlexasc
+	case b.marchp(name, NLexDesc):
+		stg = nlexdesc
+	case b.marchp(name, TLexAsc):
+		stg = tlexasc
+	case b.marchp(name, TLexDesc):
+		stg = tlexdesc
+	// size budget verifications
+	case b.marchp(name, Budget):
+		stg = bdgt
+	// filters and others
+	case b.marchp(name, FPad):
+		stg = fpad
+	case b.marchp(name, Ignore):
+		stg = ignr
+	default:
+		return nil, fmt.Errorf("strategy %q wasn't found", name)
+	}
+	return stg, nil
+}
+
+// preset helps to expand preset recursively
+// and build strategies pipe from it,
+// user defined presets are used first
+// and chain is used to detect presets cycles
+func (b Builder) preset(name gopium.StrategyName, tnames []gopium.StrategyName, chain []gopium.StrategyName) (gopium.Strategy, error) {
+	// check that preset isn't
+	// already expanded in chain
+	for _, pname := range chain {
+		if pname == name {
+			cycle := make([]string, 0, len(chain)+1)
+			for _, pname := range append(chain, name) {
+				cycle = append(cycle, string(pname))
+			}
+			return nil, fmt.Errorf("preset %q cycle detected %s", name, strings.Join(cycle, " -> "))
+		}
+	}
+	// find preset by name
+	// user defined first
+	preset, ok := b.Presets[name]
+	if !ok {
+		preset, ok = presets[name]
+	}
+	if !ok {
+		return nil, fmt.Errorf("preset %q wasn't found", name)
+	}
+	// build preset names recursively
+	// with full sliced chain copy
+	p, err := b.build(preset, tnames, append(chain[:len(chain):len(chain)], name))
+	if err != nil {
+		return nil, err
 	}
 	return p, nil
 }
@@ -220,7 +354,7 @@ func (b Builder) scanp(name gopium.StrategyName, pattern gopium.StrategyName, va
 	n := strings.ReplaceAll(string(name), "_", " ")
 	// perform the scan and handle errors
 	if _, err := fmt.Sscanf(n, p, vars...); err != nil {
-		return fmt.Errorf("pattern %q can't be scanned for strategy %q %v", pattern, name, err)
+		return fmt.Errorf("pattern %q can't be scanned for strategy %q %w", pattern, name, err)
 	}
 	return nil
 }
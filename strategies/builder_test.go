@@ -2,6 +2,7 @@ package strategies
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -54,11 +55,45 @@ func TestBuilder(t *testing.T) {
 		},
 		"`false_sharing_bytes_-10` name should return expected error": {
 			names: []gopium.StrategyName{"false_sharing_bytes_-10"},
-			err:   errors.New(`pattern "false_sharing_bytes_%d" can't be scanned for strategy "false_sharing_bytes_-10" expected integer`),
+			err:   fmt.Errorf("pattern %q can't be scanned for strategy %q %w", "false_sharing_bytes_%d", "false_sharing_bytes_-10", errors.New("expected integer")),
 		},
 		"`false_sharing_bytes_err` name should return expected error": {
 			names: []gopium.StrategyName{"false_sharing_bytes_err"},
-			err:   errors.New(`pattern "false_sharing_bytes_%d" can't be scanned for strategy "false_sharing_bytes_err" expected integer`),
+			err:   fmt.Errorf("pattern %q can't be scanned for strategy %q %w", "false_sharing_bytes_%d", "false_sharing_bytes_err", errors.New("expected integer")),
+		},
+		// pads representation styles
+		"`padding_style_bytes` name should return expected strategy": {
+			names: []gopium.StrategyName{StyleB},
+			stg:   pipe([]gopium.Strategy{stylebytes.Curator(b.Curator)}),
+		},
+		"`padding_style_uint64` name should return expected strategy": {
+			names: []gopium.StrategyName{StyleU64},
+			stg:   pipe([]gopium.Strategy{styleu64.Curator(b.Curator)}),
+		},
+		"`padding_style_cache_line` name should return expected strategy": {
+			names: []gopium.StrategyName{StyleCPU},
+			stg:   pipe([]gopium.Strategy{stylecpu.Curator(b.Curator)}),
+		},
+		// hinted fields isolations
+		"`isolate_fields_cpu_l1` name should return expected strategy": {
+			names: []gopium.StrategyName{IsolateL1},
+			stg:   pipe([]gopium.Strategy{isolatel1.Curator(b.Curator)}),
+		},
+		"`isolate_fields_cpu_l2` name should return expected strategy": {
+			names: []gopium.StrategyName{IsolateL2},
+			stg:   pipe([]gopium.Strategy{isolatel2.Curator(b.Curator)}),
+		},
+		"`isolate_fields_cpu_l3` name should return expected strategy": {
+			names: []gopium.StrategyName{IsolateL3},
+			stg:   pipe([]gopium.Strategy{isolatel3.Curator(b.Curator)}),
+		},
+		"`isolate_fields_bytes_128` name should return expected strategy": {
+			names: []gopium.StrategyName{"isolate_fields_bytes_128"},
+			stg:   pipe([]gopium.Strategy{isolateb.Bytes(128).Curator(b.Curator)}),
+		},
+		"`isolate_fields_bytes_err` name should return expected error": {
+			names: []gopium.StrategyName{"isolate_fields_bytes_err"},
+			err:   fmt.Errorf("pattern %q can't be scanned for strategy %q %w", "isolate_fields_bytes_%d", "isolate_fields_bytes_err", errors.New("expected integer")),
 		},
 		// cache line pad roundings
 		"`cache_rounding_cpu_l1_discrete` name should return expected strategy": {
@@ -79,7 +114,7 @@ func TestBuilder(t *testing.T) {
 		},
 		"`cache_rounding_bytes_err_discrete` name should return expected error": {
 			names: []gopium.StrategyName{"cache_rounding_bytes_err_discrete"},
-			err:   errors.New(`pattern "cache_rounding_bytes_%d_discrete" can't be scanned for strategy "cache_rounding_bytes_err_discrete" expected integer`),
+			err:   fmt.Errorf("pattern %q can't be scanned for strategy %q %w", "cache_rounding_bytes_%d_discrete", "cache_rounding_bytes_err_discrete", errors.New("expected integer")),
 		},
 		"`cache_rounding_cpu_l1_full` name should return expected strategy": {
 			names: []gopium.StrategyName{CacheL1F},
@@ -99,7 +134,7 @@ func TestBuilder(t *testing.T) {
 		},
 		"`cache_rounding_bytes_err_full` name should return expected error": {
 			names: []gopium.StrategyName{"cache_rounding_bytes_err_full"},
-			err:   errors.New(`pattern "cache_rounding_bytes_%d_full" can't be scanned for strategy "cache_rounding_bytes_err_full" expected integer`),
+			err:   fmt.Errorf("pattern %q can't be scanned for strategy %q %w", "cache_rounding_bytes_%d_full", "cache_rounding_bytes_err_full", errors.New("expected integer")),
 		},
 		// top, bottom separate pads
 		"`separate_padding_system_alignment_top` name should return expected strategy": {
@@ -128,7 +163,7 @@ func TestBuilder(t *testing.T) {
 		},
 		"`separate_padding_bytes_err_top` name should return expected error": {
 			names: []gopium.StrategyName{"separate_padding_bytes_err_top"},
-			err:   errors.New(`pattern "separate_padding_bytes_%d_top" can't be scanned for strategy "separate_padding_bytes_err_top" expected integer`),
+			err:   fmt.Errorf("pattern %q can't be scanned for strategy %q %w", "separate_padding_bytes_%d_top", "separate_padding_bytes_err_top", errors.New("expected integer")),
 		},
 		"`separate_padding_cpu_l1_bottom` name should return expected strategy": {
 			names: []gopium.StrategyName{SepL1B},
@@ -148,7 +183,7 @@ func TestBuilder(t *testing.T) {
 		},
 		"`separate_padding_bytes_err_bottom` name should return expected error": {
 			names: []gopium.StrategyName{"separate_padding_bytes_err_bottom"},
-			err:   errors.New(`pattern "separate_padding_bytes_%d_bottom" can't be scanned for strategy "separate_padding_bytes_err_bottom" expected integer`),
+			err:   fmt.Errorf("pattern %q can't be scanned for strategy %q %w", "separate_padding_bytes_%d_bottom", "separate_padding_bytes_err_bottom", errors.New("expected integer")),
 		},
 		// tag processors and modifiers
 		"`process_tag_group` name should return expected strategy": {
@@ -209,6 +244,11 @@ func TestBuilder(t *testing.T) {
 			names: []gopium.StrategyName{TLexDesc},
 			stg:   pipe([]gopium.Strategy{tlexdesc}),
 		},
+		// size budget verifications
+		"`enforce_budget` name should return expected strategy": {
+			names: []gopium.StrategyName{Budget},
+			stg:   pipe([]gopium.Strategy{bdgt}),
+		},
 		// filters and others
 		"`filter_pads` name should return expected strategy": {
 			names: []gopium.StrategyName{FPad},
@@ -229,6 +269,27 @@ func TestBuilder(t *testing.T) {
 			names: []gopium.StrategyName{Ignore, AddTagS},
 			stg:   pipe([]gopium.Strategy{ignr, tags.Names(Ignore, AddTagS)}),
 		},
+		// strategies presets
+		"`@compact` preset name should return expected strategy": {
+			names: []gopium.StrategyName{Compact},
+			stg:   pipe([]gopium.Strategy{fpad, pck}),
+		},
+		"`@perf` preset name should return expected strategy": {
+			names: []gopium.StrategyName{Perf},
+			stg:   pipe([]gopium.Strategy{fpad, pck, cachel1d.Curator(mocks.Maven{})}),
+		},
+		"`@concurrent` preset name should return expected strategy": {
+			names: []gopium.StrategyName{Concurrent},
+			stg:   pipe([]gopium.Strategy{fpad, fsharel1.Curator(mocks.Maven{})}),
+		},
+		"preset name inside complex name should return expected strategy with preset in tags": {
+			names: []gopium.StrategyName{Compact, AddTagF},
+			stg:   pipe([]gopium.Strategy{fpad, pck, tagf.Names(Compact, AddTagF)}),
+		},
+		"invalid preset name should return builder error": {
+			names: []gopium.StrategyName{Ignore, "@test"},
+			err:   errors.New(`preset "@test" wasn't found`),
+		},
 		"invalid name inside complex name should return builder error": {
 			names: []gopium.StrategyName{Ignore, "test", AddTagS},
 			err:   errors.New(`strategy "test" wasn't found`),
@@ -248,3 +309,112 @@ func TestBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestBuilderPresets(t *testing.T) {
+	// prepare
+	b := Builder{
+		Curator: mocks.Maven{},
+		Presets: map[gopium.StrategyName][]gopium.StrategyName{
+			"@compact": {Pack},
+			"@test":    {"@compact", Ignore},
+			"@nested":  {"@test", "@perf"},
+			"@cycle-1": {Ignore, "@cycle-2"},
+			"@cycle-2": {"@test", "@cycle-3"},
+			"@cycle-3": {"@cycle-1"},
+			"@self":    {"@self"},
+			"@invalid": {"@test", "test"},
+		},
+	}
+	table := map[string]struct {
+		names []gopium.StrategyName
+		stg   gopium.Strategy
+		err   error
+	}{
+		"user preset should override built-in preset": {
+			names: []gopium.StrategyName{Compact},
+			stg:   pipe([]gopium.Strategy{pck}),
+		},
+		"user preset should be expanded recursively": {
+			names: []gopium.StrategyName{"@nested"},
+			stg:   pipe([]gopium.Strategy{pck, ignr, pck, cachel1d.Curator(mocks.Maven{})}),
+		},
+		"user preset should be expanded the same way several times": {
+			names: []gopium.StrategyName{"@test", Ignore, "@test"},
+			stg:   pipe([]gopium.Strategy{pck, ignr, ignr, pck, ignr}),
+		},
+		"cycled user preset should return builder error": {
+			names: []gopium.StrategyName{Ignore, "@cycle-1"},
+			err:   errors.New(`preset "@cycle-1" cycle detected @cycle-1 -> @cycle-2 -> @cycle-3 -> @cycle-1`),
+		},
+		"self referenced user preset should return builder error": {
+			names: []gopium.StrategyName{"@self"},
+			err:   errors.New(`preset "@self" cycle detected @self -> @self`),
+		},
+		"user preset with invalid name should return builder error": {
+			names: []gopium.StrategyName{"@invalid"},
+			err:   errors.New(`strategy "test" wasn't found`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			stg, err := b.Build(tcase.names...)
+			// check
+			if !reflect.DeepEqual(stg, tcase.stg) {
+				t.Errorf("actual %v doesn't equal to expected %v", stg, tcase.stg)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestBuilderTrace(t *testing.T) {
+	// prepare
+	b := Builder{
+		Curator: mocks.Maven{},
+		Presets: map[gopium.StrategyName][]gopium.StrategyName{
+			"@test": {Pack, Ignore},
+		},
+		Trace: true,
+	}
+	table := map[string]struct {
+		names []gopium.StrategyName
+		stg   gopium.Strategy
+		err   error
+	}{
+		"strategies should be named with their names": {
+			names: []gopium.StrategyName{Pack, Ignore},
+			stg: pipe([]gopium.Strategy{
+				stage{stg: pck, name: Pack},
+				stage{stg: ignr, name: Ignore},
+			}),
+		},
+		"preset strategies should be named with their names": {
+			names: []gopium.StrategyName{"@test", Pack},
+			stg: pipe([]gopium.Strategy{
+				stage{stg: pck, name: Pack},
+				stage{stg: ignr, name: Ignore},
+				stage{stg: pck, name: Pack},
+			}),
+		},
+		"invalid name should return builder error": {
+			names: []gopium.StrategyName{"test"},
+			err:   errors.New(`strategy "test" wasn't found`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			stg, err := b.Build(tcase.names...)
+			// check
+			if !reflect.DeepEqual(stg, tcase.stg) {
+				t.Errorf("actual %v doesn't equal to expected %v", stg, tcase.stg)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
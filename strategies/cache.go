@@ -27,7 +27,8 @@ type cache struct {
 	line    uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	bytes   uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	div     bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_       [31]byte       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	comment bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [30]byte       `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 } // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
 
 // Bytes erich cache strategy with custom bytes
@@ -80,7 +81,7 @@ func (stg cache) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, err
 		// if padding is valid append it
 		if pad := alsize % cachel; pad > 0 {
 			pad = cachel - pad
-			r.Fields = append(r.Fields, collections.PadField(pad))
+			r.Fields = append(r.Fields, padnote(pad, stg.comment, "cache rounding (%s)", lname(stg.line, stg.bytes)))
 		}
 	}
 	return r, ctx.Err()
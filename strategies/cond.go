@@ -0,0 +1,145 @@
+package strategies
+
+import (
+	"context"
+	"go/ast"
+	"regexp"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// predicate defines abstraction
+// that checks struct property
+type predicate interface {
+	Match(gopium.Struct) bool
+}
+
+// pcmp defines predicate implementation
+// that compares struct numeric property
+// `size`, `align` or `fields` with value
+type pcmp struct {
+	prop string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	op   string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	val  int64    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_    [24]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// Match pcmp implementation
+func (p pcmp) Match(st gopium.Struct) bool {
+	// grab struct property value
+	var prop int64
+	switch size, align := collections.SizeAlign(st); p.prop {
+	case "size":
+		prop = size
+	case "align":
+		prop = align
+	case "fields":
+		prop = int64(len(st.Fields))
+	}
+	// compare it with value
+	switch p.op {
+	case "==":
+		return prop == p.val
+	case "!=":
+		return prop != p.val
+	case "<":
+		return prop < p.val
+	case "<=":
+		return prop <= p.val
+	case ">":
+		return prop > p.val
+	case ">=":
+		return prop >= p.val
+	default:
+		return false
+	}
+}
+
+// pflag defines predicate implementation
+// that checks struct boolean property
+// `exported` or `pointers` equals value
+type pflag struct {
+	prop string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	val  bool     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_    [15]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
+
+// Match pflag implementation
+func (p pflag) Match(st gopium.Struct) bool {
+	// grab struct property value
+	var prop bool
+	switch p.prop {
+	case "exported":
+		prop = ast.IsExported(st.Name)
+	case "pointers":
+		prop = collections.PtrData(st) > 0
+	}
+	return prop == p.val
+}
+
+// pregex defines predicate implementation
+// that checks either struct `name` or
+// any struct field `type` matches regex,
+// negated predicate checks that nothing matches
+type pregex struct {
+	prop  string         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	regex *regexp.Regexp `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	neg   bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_     [7]byte        `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
+
+// Match pregex implementation
+func (p pregex) Match(st gopium.Struct) bool {
+	// check struct name or
+	// all struct fields types
+	var match bool
+	switch p.prop {
+	case "name":
+		match = p.regex.MatchString(st.Name)
+	case "type":
+		for _, f := range st.Fields {
+			if p.regex.MatchString(f.Type) {
+				match = true
+				break
+			}
+		}
+	}
+	return match != p.neg
+}
+
+// pdirective defines predicate implementation
+// that checks struct doc directive presence,
+// negated predicate checks directive absence
+type pdirective struct {
+	name string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	neg  bool     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_    [15]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
+
+// Match pdirective implementation
+func (p pdirective) Match(st gopium.Struct) bool {
+	_, ok := directive(st, p.name)
+	return ok != p.neg
+}
+
+// cond defines strategy implementation
+// that applies then strategy to struct
+// only if struct matches predicate
+// and else strategy otherwise
+type cond struct {
+	pred predicate       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	then gopium.Strategy `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	els  gopium.Strategy `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_    [16]byte        `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// Apply cond implementation
+func (stg cond) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// check predicate on original struct
+	// and apply relevant strategy
+	if stg.pred.Match(o) {
+		return stg.then.Apply(ctx, o)
+	}
+	return stg.els.Apply(ctx, o)
+}
@@ -0,0 +1,258 @@
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestCond(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		cond cond
+		ctx  context.Context
+		o    gopium.Struct
+		r    gopium.Struct
+		err  error
+	}{
+		"empty struct should be applied to else strategy": {
+			cond: cond{pred: pcmp{prop: "size", op: ">", val: 0}, then: pck, els: ignr},
+			ctx:  context.Background(),
+		},
+		"matched size struct should be applied to then strategy": {
+			cond: cond{pred: pcmp{prop: "size", op: ">=", val: 16}, then: pck, els: unpck},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1},
+					{Name: "test2", Size: 8, Align: 8},
+					{Name: "test3", Size: 2, Align: 2},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test2", Size: 8, Align: 8},
+					{Name: "test3", Size: 2, Align: 2},
+					{Name: "test1", Size: 1, Align: 1},
+				},
+			},
+		},
+		"unmatched align struct should be applied to else strategy": {
+			cond: cond{pred: pcmp{prop: "align", op: "<", val: 8}, then: pck, els: ignr},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1},
+					{Name: "test2", Size: 8, Align: 8},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1},
+					{Name: "test2", Size: 8, Align: 8},
+				},
+			},
+		},
+		"matched fields struct should be applied to then strategy on canceled context": {
+			cond: cond{pred: pcmp{prop: "fields", op: "==", val: 2}, then: pck, els: ignr},
+			ctx:  cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1},
+					{Name: "test2", Size: 8, Align: 8},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test2", Size: 8, Align: 8},
+					{Name: "test1", Size: 1, Align: 1},
+				},
+			},
+			err: context.Canceled,
+		},
+		"exported struct should be applied to then strategy": {
+			cond: cond{pred: pflag{prop: "exported", val: true}, then: pck, els: ignr},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "Test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1},
+					{Name: "test2", Size: 8, Align: 8},
+				},
+			},
+			r: gopium.Struct{
+				Name: "Test",
+				Fields: []gopium.Field{
+					{Name: "test2", Size: 8, Align: 8},
+					{Name: "test1", Size: 1, Align: 1},
+				},
+			},
+		},
+		"struct without pointers should be applied to else strategy": {
+			cond: cond{pred: pflag{prop: "pointers", val: true}, then: pck, els: ignr},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1},
+					{Name: "test2", Size: 8, Align: 8},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1},
+					{Name: "test2", Size: 8, Align: 8},
+				},
+			},
+		},
+		"struct with pointers should be applied to then strategy": {
+			cond: cond{pred: pflag{prop: "pointers", val: true}, then: pck, els: ignr},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1},
+					{Name: "test2", Size: 8, Align: 8, Ptr: 8},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test2", Size: 8, Align: 8, Ptr: 8},
+					{Name: "test1", Size: 1, Align: 1},
+				},
+			},
+		},
+		"struct with matched name should be applied to then strategy": {
+			cond: cond{pred: pregex{prop: "name", regex: regexp.MustCompile(`^te`)}, then: pck, els: ignr},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1},
+					{Name: "test2", Size: 8, Align: 8},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test2", Size: 8, Align: 8},
+					{Name: "test1", Size: 1, Align: 1},
+				},
+			},
+		},
+		"struct with matched field type should be applied to then strategy": {
+			cond: cond{pred: pregex{prop: "type", regex: regexp.MustCompile(`^atomic\.`)}, then: pck, els: ignr},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Type: "bool", Size: 1, Align: 1},
+					{Name: "test2", Type: "atomic.Value", Size: 8, Align: 8},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test2", Type: "atomic.Value", Size: 8, Align: 8},
+					{Name: "test1", Type: "bool", Size: 1, Align: 1},
+				},
+			},
+		},
+		"struct with matched field type should be applied to else strategy on negated predicate": {
+			cond: cond{pred: pregex{prop: "type", regex: regexp.MustCompile(`^atomic\.`), neg: true}, then: pck, els: ignr},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Type: "bool", Size: 1, Align: 1},
+					{Name: "test2", Type: "atomic.Value", Size: 8, Align: 8},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Type: "bool", Size: 1, Align: 1},
+					{Name: "test2", Type: "atomic.Value", Size: 8, Align: 8},
+				},
+			},
+		},
+		"struct with directive should be applied to then strategy": {
+			cond: cond{pred: pdirective{name: "hot"}, then: pck, els: ignr},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget size=64", "hot"},
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1},
+					{Name: "test2", Size: 8, Align: 8},
+				},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"budget size=64", "hot"},
+				Fields: []gopium.Field{
+					{Name: "test2", Size: 8, Align: 8},
+					{Name: "test1", Size: 1, Align: 1},
+				},
+			},
+		},
+		"struct without directive should be applied to then strategy on negated predicate": {
+			cond: cond{pred: pdirective{name: "hot", neg: true}, then: pck, els: ignr},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"hotter"},
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1},
+					{Name: "test2", Size: 8, Align: 8},
+				},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"hotter"},
+				Fields: []gopium.Field{
+					{Name: "test2", Size: 8, Align: 8},
+					{Name: "test1", Size: 1, Align: 1},
+				},
+			},
+		},
+		"unknown operator should be applied to else strategy": {
+			cond: cond{pred: pcmp{prop: "fields", op: "=~", val: 1}, then: pck, els: ignr},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{{Name: "test1", Size: 1, Align: 1}},
+			},
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{{Name: "test1", Size: 1, Align: 1}},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.cond.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
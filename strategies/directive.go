@@ -0,0 +1,44 @@
+package strategies
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// directive helps to find struct directive
+// by its name and returns trimmed directive body,
+// directive is matched either by exact name
+// or by name followed by space separated body
+// - `//gopium:ignore` found by `ignore` name with empty body
+// - `//gopium:budget size=64` found by `budget` name with `size=64` body
+func directive(st gopium.Struct, name string) (string, bool) {
+	// go through all struct directives
+	for _, dir := range st.Directives {
+		if body := strings.TrimPrefix(dir, name); body != dir &&
+			(body == "" || strings.HasPrefix(body, " ")) {
+			return strings.TrimSpace(body), true
+		}
+	}
+	return "", false
+}
+
+// dstrategies helps to parse strategies directive body
+// to normalized comma separated strategies list
+// - `memory_pack, cache_rounding_cpu_l1_discrete` parsed to `memory_pack,cache_rounding_cpu_l1_discrete`
+// - `filter(name=~'^_$', type=~'^int$') | pack` parsed as is
+func dstrategies(body string) (string, error) {
+	// split body to strategies names
+	// ignoring commas inside expressions
+	names := make([]string, 0, 1)
+	for _, name := range esplit(body, ',') {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", errors.New("directive `strategies` can't be parsed, strategies list is empty")
+	}
+	return strings.Join(names, ","), nil
+}
@@ -0,0 +1,919 @@
+package strategies
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// ekind defines strategy expression token kind
+type ekind int
+
+// list of strategy expression tokens kinds
+const (
+	ekEOF ekind = iota
+	ekIdent
+	ekNumber
+	ekString
+	ekOp
+	ekLParen
+	ekRParen
+	ekComma
+	ekPipe
+)
+
+// etoken defines single strategy expression token
+type etoken struct {
+	text string `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	kind ekind  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	at   int    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
+
+// epipe defines strategy expression pipeline node
+// `call | call | call`
+type epipe struct {
+	calls []ecall  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	src   string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	at    int      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_     [16]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// ecall defines strategy expression call node
+// either `name` or `name(arg, arg, arg)`
+type ecall struct {
+	args  []earg   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	name  string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	at    int      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	paren bool     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_     [15]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// earg defines strategy expression call argument node
+// either named `key op value` or nested pipeline
+type earg struct {
+	val  etoken   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	key  string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	op   string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	pipe *epipe   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	at   int      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_    [48]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
+
+// expression checks if strategy name
+// is strategy expression rather than
+// registered strategy or preset name
+func expression(name gopium.StrategyName) bool {
+	return strings.ContainsAny(string(name), "()|=<>!~\"' ")
+}
+
+// esplit helps to split strategies list by separator
+// ignoring separators inside strategy expressions
+// parentheses and string literals
+func esplit(s string, sep rune) []string {
+	// prepare parts list and state
+	parts := make([]string, 0, 1)
+	depth, quote, start := 0, rune(0), 0
+	for i, r := range s {
+		switch {
+		// skip everything inside quotes
+		// except escaped closing quote
+		case quote != 0:
+			if r == quote && (quote == '\'' || i == 0 || s[i-1] != '\\') {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// elex helps to split strategy expression to tokens
+// or returns positioned error on invalid expression
+func elex(src string) ([]etoken, error) {
+	// prepare tokens list
+	toks := make([]etoken, 0, len(src)/2)
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		switch {
+		// skip all spaces
+		case unicode.IsSpace(c):
+			i++
+		// single char punctuation
+		case c == '(':
+			toks = append(toks, etoken{kind: ekLParen, text: "(", at: i})
+			i++
+		case c == ')':
+			toks = append(toks, etoken{kind: ekRParen, text: ")", at: i})
+			i++
+		case c == ',':
+			toks = append(toks, etoken{kind: ekComma, text: ",", at: i})
+			i++
+		case c == '|':
+			toks = append(toks, etoken{kind: ekPipe, text: "|", at: i})
+			i++
+		// comparison and match operators
+		case strings.ContainsRune("=!<>", c):
+			j := i + 1
+			if j < len(src) && (src[j] == '=' || src[j] == '~') {
+				j++
+			}
+			op := src[i:j]
+			switch op {
+			case "=", "==", "!=", "=~", "!~", "<", "<=", ">", ">=":
+			default:
+				return nil, eerrorf(src, i, "unknown operator %q", op)
+			}
+			toks = append(toks, etoken{kind: ekOp, text: op, at: i})
+			i = j
+		// double quoted string with escapes
+		case c == '"':
+			j := i + 1
+			for ; j < len(src) && src[j] != '"'; j++ {
+				if src[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(src) {
+				return nil, eerrorf(src, i, "string literal isn't terminated")
+			}
+			text, err := strconv.Unquote(src[i : j+1])
+			if err != nil {
+				return nil, eerrorf(src, i, "string literal is invalid %v", err)
+			}
+			toks = append(toks, etoken{kind: ekString, text: text, at: i})
+			i = j + 1
+		// single quoted raw string
+		case c == '\'':
+			j := strings.IndexByte(src[i+1:], '\'')
+			if j < 0 {
+				return nil, eerrorf(src, i, "string literal isn't terminated")
+			}
+			toks = append(toks, etoken{kind: ekString, text: src[i+1 : i+1+j], at: i})
+			i = i + j + 2
+		// signed integer number
+		case unicode.IsDigit(c) || c == '-' && i+1 < len(src) && unicode.IsDigit(rune(src[i+1])):
+			j := i + 1
+			for j < len(src) && unicode.IsDigit(rune(src[j])) {
+				j++
+			}
+			// reject float numbers explicitly
+			// as no argument accepts them
+			if j < len(src) && src[j] == '.' {
+				return nil, eerrorf(src, i, "float number isn't supported, expected integer")
+			}
+			toks = append(toks, etoken{kind: ekNumber, text: src[i:j], at: i})
+			i = j
+		// identifier or preset name
+		case unicode.IsLetter(c) || c == '_' || c == '@':
+			j := i + 1
+			for j < len(src) {
+				r := rune(src[j])
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' && r != '@' && r != '%' {
+					break
+				}
+				j++
+			}
+			toks = append(toks, etoken{kind: ekIdent, text: src[i:j], at: i})
+			i = j
+		default:
+			return nil, eerrorf(src, i, "unexpected character %q", c)
+		}
+	}
+	return append(toks, etoken{kind: ekEOF, at: len(src)}), nil
+}
+
+// eparser defines strategy expression
+// recursive descent parser
+type eparser struct {
+	toks []etoken `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	src  string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	i    int      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_    [16]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// eparse helps to parse strategy expression
+// to pipeline node or returns positioned error
+// - pipe := call ('|' call)*
+// - call := ident ['(' [arg (',' arg)*] ')']
+// - arg := ident op literal | pipe
+// - literal := number | string | ident
+func eparse(src string) (*epipe, error) {
+	// split expression to tokens
+	toks, err := elex(src)
+	if err != nil {
+		return nil, err
+	}
+	// parse whole expression as pipeline
+	p := &eparser{src: src, toks: toks}
+	pipe, err := p.pipe()
+	if err != nil {
+		return nil, err
+	}
+	// check that nothing left
+	if tok := p.peek(); tok.kind != ekEOF {
+		return nil, eerrorf(src, tok.at, "unexpected %s", p.describe(tok))
+	}
+	return pipe, nil
+}
+
+// peek returns current token
+func (p *eparser) peek() etoken {
+	return p.toks[p.i]
+}
+
+// next returns current token
+// and moves to next one
+func (p *eparser) next() etoken {
+	tok := p.toks[p.i]
+	if tok.kind != ekEOF {
+		p.i++
+	}
+	return tok
+}
+
+// describe returns human readable token description
+func (p *eparser) describe(tok etoken) string {
+	if tok.kind == ekEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", p.src[tok.at:tok.at+len(tok.text)])
+}
+
+// pipe parses pipeline node
+func (p *eparser) pipe() (*epipe, error) {
+	pipe := &epipe{at: p.peek().at}
+	for {
+		// parse pipeline call
+		call, err := p.call()
+		if err != nil {
+			return nil, err
+		}
+		pipe.calls = append(pipe.calls, *call)
+		// stop on anything except pipe
+		// and keep pipeline source
+		if tok := p.peek(); tok.kind != ekPipe {
+			pipe.src = strings.TrimSpace(p.src[pipe.at:tok.at])
+			return pipe, nil
+		}
+		p.next()
+	}
+}
+
+// call parses call node
+func (p *eparser) call() (*ecall, error) {
+	// call should start with identifier
+	tok := p.next()
+	if tok.kind != ekIdent {
+		return nil, eerrorf(p.src, tok.at, "expected strategy name but found %s", p.describe(tok))
+	}
+	call := &ecall{name: tok.text, at: tok.at}
+	// in case there are no parentheses
+	// just return plain call
+	if p.peek().kind != ekLParen {
+		return call, nil
+	}
+	p.next()
+	call.paren = true
+	// handle empty arguments list
+	if p.peek().kind == ekRParen {
+		p.next()
+		return call, nil
+	}
+	for {
+		// parse call argument
+		arg, err := p.arg()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, *arg)
+		// either next argument or end of arguments
+		switch tok := p.next(); tok.kind {
+		case ekComma:
+		case ekRParen:
+			return call, nil
+		default:
+			return nil, eerrorf(p.src, tok.at, "expected \",\" or \")\" but found %s", p.describe(tok))
+		}
+	}
+}
+
+// arg parses argument node
+func (p *eparser) arg() (*earg, error) {
+	tok := p.peek()
+	// in case of named argument
+	// parse `key op literal`
+	if tok.kind == ekIdent && p.toks[p.i+1].kind == ekOp {
+		key, op := p.next(), p.next()
+		val := p.next()
+		switch val.kind {
+		case ekNumber, ekString, ekIdent:
+		default:
+			return nil, eerrorf(p.src, val.at, "expected value but found %s", p.describe(val))
+		}
+		return &earg{key: key.text, op: op.text, val: val, at: key.at}, nil
+	}
+	// otherwise parse nested pipeline
+	pipe, err := p.pipe()
+	if err != nil {
+		return nil, err
+	}
+	return &earg{pipe: pipe, at: tok.at}, nil
+}
+
+// eerrorf helps to create
+// positioned strategy expression error
+func eerrorf(src string, at int, format string, args ...interface{}) error {
+	return fmt.Errorf(
+		"expression %q can't be parsed at column %d, %s",
+		src,
+		at+1,
+		fmt.Sprintf(format, args...),
+	)
+}
+
+// ecompiler defines strategy expression
+// compiler that compiles expression nodes
+// to strategies with builder
+type ecompiler struct {
+	b      Builder               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	tnames []gopium.StrategyName `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	chain  []gopium.StrategyName `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	src    string                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_      [40]byte              `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
+
+// compile helps to parse and compile
+// strategy expression to strategies pipe
+func (b Builder) compile(name gopium.StrategyName, tnames []gopium.StrategyName, chain []gopium.StrategyName) (gopium.Strategy, error) {
+	// parse expression first
+	src := string(name)
+	pipe, err := eparse(src)
+	if err != nil {
+		return nil, err
+	}
+	// then compile parsed pipeline
+	c := ecompiler{b: b, src: src, tnames: tnames, chain: chain}
+	p, err := c.pipe(pipe)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// errorf helps to create
+// positioned compilation error
+func (c ecompiler) errorf(at int, format string, args ...interface{}) error {
+	return fmt.Errorf(
+		"expression %q can't be compiled at column %d, %s",
+		c.src,
+		at+1,
+		fmt.Sprintf(format, args...),
+	)
+}
+
+// pipe compiles pipeline node to strategies pipe
+func (c ecompiler) pipe(ep *epipe) (pipe, error) {
+	p := make(pipe, 0, len(ep.calls))
+	for i := range ep.calls {
+		// compile each call
+		stg, err := c.call(&ep.calls[i])
+		if err != nil {
+			return nil, err
+		}
+		// flatten nested pipes
+		// and name stages for tracing
+		switch np, ok := stg.(pipe); {
+		case ok:
+			p = append(p, np...)
+		case c.b.Trace:
+			p = append(p, stage{stg: stg, name: gopium.StrategyName(ep.calls[i].name)})
+		default:
+			p = append(p, stg)
+		}
+	}
+	return p, nil
+}
+
+// call compiles call node to strategy
+func (c ecompiler) call(call *ecall) (gopium.Strategy, error) {
+	// plain names are either short aliases
+	// or registered strategies and presets
+	if !call.paren {
+		name := gopium.StrategyName(call.name)
+		switch {
+		case call.name == "pack":
+			name = Pack
+		case call.name == "unpack":
+			name = Unpack
+		case strings.HasPrefix(call.name, "@"):
+			stg, err := c.b.preset(name, c.tnames, c.chain)
+			if err != nil {
+				return nil, c.errorf(call.at, "%v", err)
+			}
+			return stg, nil
+		}
+		stg, err := c.b.single(name, c.tnames)
+		if err != nil {
+			return nil, c.errorf(call.at, "%v", err)
+		}
+		return stg, nil
+	}
+	// otherwise compile function call
+	switch call.name {
+	case "filter":
+		return c.filter(call)
+	case "explicit_paddings":
+		return c.pad(call)
+	case "false_sharing":
+		return c.fshare(call)
+	case "isolate_fields":
+		return c.isolate(call)
+	case "cache_rounding":
+		return c.cache(call)
+	case "separate_padding":
+		return c.sep(call)
+	case "padding_style":
+		return c.style(call)
+	case "if":
+		return c.cond(call)
+	case "best_of":
+		return c.best(call)
+	default:
+		return nil, c.errorf(call.at, "function %q wasn't found", call.name)
+	}
+}
+
+// named helps to collect named call arguments
+// by their keys, all keys should be unique
+// and belong to provided list of keys
+func (c ecompiler) named(call *ecall, keys ...string) (map[string]earg, error) {
+	args := make(map[string]earg, len(call.args))
+	for _, arg := range call.args {
+		// check that argument is named
+		if arg.pipe != nil {
+			return nil, c.errorf(arg.at, "function %q expects only named arguments %s", call.name, strings.Join(keys, ", "))
+		}
+		// check that argument key is known
+		known := false
+		for _, key := range keys {
+			known = known || key == arg.key
+		}
+		if !known {
+			return nil, c.errorf(arg.at, "function %q unknown argument %q, expected one of %s", call.name, arg.key, strings.Join(keys, ", "))
+		}
+		// check that argument is unique
+		if _, ok := args[arg.key]; ok {
+			return nil, c.errorf(arg.at, "function %q duplicated argument %q", call.name, arg.key)
+		}
+		args[arg.key] = arg
+	}
+	return args, nil
+}
+
+// op helps to check named argument operator
+func (c ecompiler) op(arg earg, ops ...string) error {
+	for _, op := range ops {
+		if arg.op == op {
+			return nil
+		}
+	}
+	return c.errorf(arg.at, "argument %q unexpected operator %q, expected one of %s", arg.key, arg.op, strings.Join(ops, " "))
+}
+
+// uint helps to get named argument
+// as positive integer value
+func (c ecompiler) uint(arg earg) (uint, error) {
+	if err := c.op(arg, "="); err != nil {
+		return 0, err
+	}
+	val, err := strconv.ParseUint(arg.val.text, 10, 64)
+	if arg.val.kind != ekNumber || err != nil || val == 0 {
+		return 0, c.errorf(arg.val.at, "argument %q expects positive integer but found %q", arg.key, arg.val.text)
+	}
+	return uint(val), nil
+}
+
+// enum helps to get named argument
+// as one of provided identifiers
+func (c ecompiler) enum(arg earg, vals ...string) (string, error) {
+	if err := c.op(arg, "="); err != nil {
+		return "", err
+	}
+	for _, val := range vals {
+		if arg.val.kind == ekIdent && arg.val.text == val {
+			return val, nil
+		}
+	}
+	return "", c.errorf(arg.val.at, "argument %q expects one of %s but found %q", arg.key, strings.Join(vals, ", "), arg.val.text)
+}
+
+// str helps to get named argument
+// as non empty string value
+func (c ecompiler) str(arg earg) (string, error) {
+	if err := c.op(arg, "="); err != nil {
+		return "", err
+	}
+	if arg.val.kind != ekString || arg.val.text == "" {
+		return "", c.errorf(arg.val.at, "argument %q expects non empty string but found %q", arg.key, arg.val.text)
+	}
+	return arg.val.text, nil
+}
+
+// regex helps to get named argument
+// as compiled regular expression
+func (c ecompiler) regex(arg earg) (*regexp.Regexp, error) {
+	if err := c.op(arg, "=~"); err != nil {
+		return nil, err
+	}
+	regex, err := regexp.Compile(arg.val.text)
+	if err != nil {
+		return nil, c.errorf(arg.val.at, "argument %q regexp can't be compiled %v", arg.key, err)
+	}
+	return regex, nil
+}
+
+// line helps to get cache line or bytes
+// from `line` or `bytes` named arguments,
+// it returns default line if none provided
+func (c ecompiler) line(call *ecall, args map[string]earg, def uint) (uint, uint, error) {
+	larg, lok := args["line"]
+	barg, bok := args["bytes"]
+	switch {
+	case lok && bok:
+		return 0, 0, c.errorf(call.at, "function %q expects either %q or %q argument", call.name, "line", "bytes")
+	case bok:
+		bytes, err := c.uint(barg)
+		return 0, bytes, err
+	case lok:
+		line, err := c.uint(larg)
+		if err == nil && line > 3 {
+			err = c.errorf(larg.val.at, "argument %q expects cache line 1, 2 or 3 but found %d", "line", line)
+		}
+		return line, 0, err
+	default:
+		return def, 0, nil
+	}
+}
+
+// comment helps to get pads comment flag
+// from `comment` named argument,
+// it returns false if none provided
+func (c ecompiler) comment(args map[string]earg) (bool, error) {
+	arg, ok := args["comment"]
+	if !ok {
+		return false, nil
+	}
+	val, err := c.enum(arg, "true", "false")
+	return val == "true", err
+}
+
+// filter compiles
+// `filter(name=~"regex", type=~"regex")`
+// to filter strategy
+func (c ecompiler) filter(call *ecall) (gopium.Strategy, error) {
+	args, err := c.named(call, "name", "type")
+	if err != nil {
+		return nil, err
+	}
+	var stg filter
+	if arg, ok := args["name"]; ok {
+		if stg.nregex, err = c.regex(arg); err != nil {
+			return nil, err
+		}
+	}
+	if arg, ok := args["type"]; ok {
+		if stg.tregex, err = c.regex(arg); err != nil {
+			return nil, err
+		}
+	}
+	return stg, nil
+}
+
+// pad compiles
+// `explicit_paddings(alignment=system|natural, comment=true|false)`
+// to pad strategy
+func (c ecompiler) pad(call *ecall) (gopium.Strategy, error) {
+	args, err := c.named(call, "alignment", "comment")
+	if err != nil {
+		return nil, err
+	}
+	comment, err := c.comment(args)
+	if err != nil {
+		return nil, err
+	}
+	stg := padsys
+	if arg, ok := args["alignment"]; ok {
+		val, err := c.enum(arg, "system", "natural")
+		if err != nil {
+			return nil, err
+		}
+		if val == "natural" {
+			stg = padtnat
+		}
+	}
+	stg.comment = comment
+	return stg.Curator(c.b.Curator), nil
+}
+
+// fshare compiles
+// `false_sharing(line=1|2|3 | bytes=N, comment=true|false)`
+// to false sharing strategy
+func (c ecompiler) fshare(call *ecall) (gopium.Strategy, error) {
+	args, err := c.named(call, "line", "bytes", "comment")
+	if err != nil {
+		return nil, err
+	}
+	line, bytes, err := c.line(call, args, 1)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := c.comment(args)
+	if err != nil {
+		return nil, err
+	}
+	stg := fshare{line: line, comment: comment}
+	return stg.Bytes(bytes).Curator(c.b.Curator), nil
+}
+
+// isolate compiles
+// `isolate_fields(line=1|2|3 | bytes=N, comment=true|false)`
+// to isolate strategy
+func (c ecompiler) isolate(call *ecall) (gopium.Strategy, error) {
+	args, err := c.named(call, "line", "bytes", "comment")
+	if err != nil {
+		return nil, err
+	}
+	line, bytes, err := c.line(call, args, 1)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := c.comment(args)
+	if err != nil {
+		return nil, err
+	}
+	stg := isolate{line: line, comment: comment}
+	return stg.Bytes(bytes).Curator(c.b.Curator), nil
+}
+
+// cache compiles
+// `cache_rounding(line=1|2|3 | bytes=N, mode=discrete|full, comment=true|false)`
+// to cache rounding strategy
+func (c ecompiler) cache(call *ecall) (gopium.Strategy, error) {
+	args, err := c.named(call, "line", "bytes", "mode", "comment")
+	if err != nil {
+		return nil, err
+	}
+	line, bytes, err := c.line(call, args, 1)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := c.comment(args)
+	if err != nil {
+		return nil, err
+	}
+	stg := cache{line: line, div: true, comment: comment}
+	if arg, ok := args["mode"]; ok {
+		val, err := c.enum(arg, "discrete", "full")
+		if err != nil {
+			return nil, err
+		}
+		stg.div = val == "discrete"
+	}
+	return stg.Bytes(bytes).Curator(c.b.Curator), nil
+}
+
+// sep compiles
+// `separate_padding(line=1|2|3 | bytes=N | alignment=system, side=top|bottom, comment=true|false)`
+// to separate padding strategy
+func (c ecompiler) sep(call *ecall) (gopium.Strategy, error) {
+	args, err := c.named(call, "line", "bytes", "alignment", "side", "comment")
+	if err != nil {
+		return nil, err
+	}
+	comment, err := c.comment(args)
+	if err != nil {
+		return nil, err
+	}
+	stg := sep{top: true, comment: comment}
+	if arg, ok := args["alignment"]; ok {
+		if _, err := c.enum(arg, "system"); err != nil {
+			return nil, err
+		}
+		if _, ok := args["line"]; ok {
+			return nil, c.errorf(call.at, "function %q expects either %q or %q argument", call.name, "alignment", "line")
+		}
+		if _, ok := args["bytes"]; ok {
+			return nil, c.errorf(call.at, "function %q expects either %q or %q argument", call.name, "alignment", "bytes")
+		}
+		stg.sys = true
+	} else {
+		line, bytes, err := c.line(call, args, 1)
+		if err != nil {
+			return nil, err
+		}
+		stg = stg.Bytes(bytes)
+		stg.line = line
+	}
+	if arg, ok := args["side"]; ok {
+		val, err := c.enum(arg, "top", "bottom")
+		if err != nil {
+			return nil, err
+		}
+		stg.top = val == "top"
+	}
+	return stg.Curator(c.b.Curator), nil
+}
+
+// style compiles
+// `padding_style(style=bytes|uint64|cache_line)` or
+// `padding_style(type="pkg/path.Pad", line=1|2|3 | bytes=N, align=N)`
+// with fully qualified pad type
+// to padding style strategy
+func (c ecompiler) style(call *ecall) (gopium.Strategy, error) {
+	args, err := c.named(call, "style", "type", "line", "bytes", "align")
+	if err != nil {
+		return nil, err
+	}
+	sarg, sok := args["style"]
+	targ, tok := args["type"]
+	var stg style
+	switch {
+	case sok && len(args) > 1:
+		return nil, c.errorf(call.at, "function %q expects either %q or %q arguments", call.name, "style", "type")
+	case sok:
+		val, err := c.enum(sarg, "bytes", "uint64", "cache_line")
+		if err != nil {
+			return nil, err
+		}
+		switch val {
+		case "bytes":
+			stg = stylebytes
+		case "uint64":
+			stg = styleu64
+		case "cache_line":
+			stg = stylecpu
+		}
+	case tok:
+		name, err := c.str(targ)
+		if err != nil {
+			return nil, err
+		}
+		// pad type should be fully qualified
+		// with its package path `pkg/path.Pad`
+		if index := strings.LastIndex(name, "."); index <= 0 || index == len(name)-1 || strings.ContainsAny(name, "[]*() ") {
+			return nil, c.errorf(targ.val.at, "argument %q expects fully qualified type name like %q but found %q", targ.key, "pkg/path.Pad", name)
+		}
+		line, bytes, err := c.line(call, args, 1)
+		if err != nil {
+			return nil, err
+		}
+		stg = style{name: name, line: line}.Bytes(bytes)
+		if arg, ok := args["align"]; ok {
+			if stg.align, err = c.uint(arg); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, c.errorf(call.at, "function %q expects either %q or %q arguments", call.name, "style", "type")
+	}
+	return stg.Curator(c.b.Curator), nil
+}
+
+// cond compiles
+// `if(predicate, then_pipe[, else_pipe])`
+// to conditional strategy
+func (c ecompiler) cond(call *ecall) (gopium.Strategy, error) {
+	// check arguments number
+	if alen := len(call.args); alen < 2 || alen > 3 {
+		return nil, c.errorf(call.at, "function %q expects predicate, then and optional else arguments", call.name)
+	}
+	// compile predicate argument
+	pred, err := c.predicate(call.args[0])
+	if err != nil {
+		return nil, err
+	}
+	stg := cond{pred: pred, els: ignr}
+	// compile then and else arguments
+	for i, arg := range call.args[1:] {
+		if arg.pipe == nil {
+			return nil, c.errorf(arg.at, "function %q expects strategy argument", call.name)
+		}
+		p, err := c.pipe(arg.pipe)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			stg.then = p
+		} else {
+			stg.els = p
+		}
+	}
+	return stg, nil
+}
+
+// predicate compiles predicate argument
+// - `size|align|fields op N` to numeric predicate
+// - `exported|pointers[=true|false]` to flag predicate
+// - `name|type =~|!~ "regex"` to regex predicate
+// - `directive =|!= name` to directive predicate
+func (c ecompiler) predicate(arg earg) (predicate, error) {
+	// bare flag predicates are parsed
+	// as single plain pipeline call
+	if arg.pipe != nil {
+		if calls := arg.pipe.calls; len(calls) == 1 && !calls[0].paren {
+			switch calls[0].name {
+			case "exported", "pointers":
+				return pflag{prop: calls[0].name, val: true}, nil
+			}
+		}
+		return nil, c.errorf(arg.at, "expected predicate but found strategy")
+	}
+	switch arg.key {
+	case "size", "align", "fields":
+		if err := c.op(arg, "==", "!=", "<", "<=", ">", ">="); err != nil {
+			return nil, err
+		}
+		val, err := strconv.ParseInt(arg.val.text, 10, 64)
+		if arg.val.kind != ekNumber || err != nil {
+			return nil, c.errorf(arg.val.at, "predicate %q expects integer but found %q", arg.key, arg.val.text)
+		}
+		return pcmp{prop: arg.key, op: arg.op, val: val}, nil
+	case "exported", "pointers":
+		if err := c.op(arg, "=", "!="); err != nil {
+			return nil, err
+		}
+		val, err := strconv.ParseBool(arg.val.text)
+		if arg.val.kind != ekIdent || err != nil {
+			return nil, c.errorf(arg.val.at, "predicate %q expects true or false but found %q", arg.key, arg.val.text)
+		}
+		return pflag{prop: arg.key, val: val == (arg.op == "=")}, nil
+	case "name", "type":
+		if err := c.op(arg, "=~", "!~"); err != nil {
+			return nil, err
+		}
+		regex, err := regexp.Compile(arg.val.text)
+		if err != nil {
+			return nil, c.errorf(arg.val.at, "predicate %q regexp can't be compiled %v", arg.key, err)
+		}
+		return pregex{prop: arg.key, regex: regex, neg: arg.op == "!~"}, nil
+	case "directive":
+		if err := c.op(arg, "=", "!="); err != nil {
+			return nil, err
+		}
+		if arg.val.kind == ekNumber || arg.val.text == "" {
+			return nil, c.errorf(arg.val.at, "predicate %q expects directive name but found %q", arg.key, arg.val.text)
+		}
+		return pdirective{name: arg.val.text, neg: arg.op == "!="}, nil
+	default:
+		return nil, c.errorf(arg.at, "predicate %q wasn't found", arg.key)
+	}
+}
+
+// best compiles
+// `best_of(pipe, pipe, ..., metric=size|ptrdata|moved|lines|class)`
+// to best strategy
+func (c ecompiler) best(call *ecall) (gopium.Strategy, error) {
+	stg := best{metric: msize}
+	metric := false
+	for _, arg := range call.args {
+		// compile metric named argument
+		if arg.pipe == nil {
+			if arg.key != "metric" {
+				return nil, c.errorf(arg.at, "function %q unknown argument %q, expected one of metric", call.name, arg.key)
+			}
+			if metric {
+				return nil, c.errorf(arg.at, "function %q duplicated argument %q", call.name, arg.key)
+			}
+			val, err := c.enum(arg, msize, mptrdata, mmoved, mlines, mclass)
+			if err != nil {
+				return nil, err
+			}
+			stg.metric, metric = val, true
+			continue
+		}
+		// compile candidate pipe argument
+		p, err := c.pipe(arg.pipe)
+		if err != nil {
+			return nil, err
+		}
+		stg.stgs = append(stg.stgs, p)
+		stg.names = append(stg.names, arg.pipe.src)
+	}
+	// check that at least one candidate exists
+	if len(stg.stgs) == 0 {
+		return nil, c.errorf(call.at, "function %q expects at least one strategy argument", call.name)
+	}
+	return stg.Curator(c.b.Curator), nil
+}
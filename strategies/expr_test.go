@@ -0,0 +1,366 @@
+package strategies
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestEsplit(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		s     string
+		sep   rune
+		parts []string
+	}{
+		"empty string should be split to single empty part": {
+			s:     "",
+			sep:   ',',
+			parts: []string{""},
+		},
+		"plain list should be split by separator": {
+			s:     "filter_pads,memory_pack",
+			sep:   ',',
+			parts: []string{"filter_pads", "memory_pack"},
+		},
+		"expressions list should be split by top level separator only": {
+			s:     `filter(name=~"^_$", type=~'a,b') | pack,if(size>64, cache_rounding(line=1, mode=full)),ignore`,
+			sep:   ',',
+			parts: []string{`filter(name=~"^_$", type=~'a,b') | pack`, "if(size>64, cache_rounding(line=1, mode=full))", "ignore"},
+		},
+		"tag should be split by top level separator only": {
+			s:     `group:def;filter(name=~"a;b\";c");pack`,
+			sep:   ';',
+			parts: []string{"group:def", `filter(name=~"a;b\";c")`, "pack"},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			parts := esplit(tcase.s, tcase.sep)
+			// check
+			if !reflect.DeepEqual(parts, tcase.parts) {
+				t.Errorf("actual %v doesn't equal to expected %v", parts, tcase.parts)
+			}
+		})
+	}
+}
+
+func TestBuilderExpressions(t *testing.T) {
+	// prepare
+	b := Builder{
+		Curator: mocks.Maven{},
+		Presets: map[gopium.StrategyName][]gopium.StrategyName{
+			"@expr":  {"pack | @expr"},
+			"@short": {"filter(name=~'^_$') | pack"},
+		},
+	}
+	table := map[string]struct {
+		names []gopium.StrategyName
+		stg   gopium.Strategy
+		err   error
+	}{
+		"pipeline expression should return expected strategy": {
+			names: []gopium.StrategyName{`filter(name=~"^_$") | pack | cache_rounding(line=1)`},
+			stg: pipe([]gopium.Strategy{
+				filter{nregex: regexp.MustCompile(`^_$`)},
+				pck,
+				cachel1d.Curator(mocks.Maven{}),
+			}),
+		},
+		"expression with registered names and presets should return expected strategy": {
+			names: []gopium.StrategyName{"filter_pads | @compact | memory_unpack | false_sharing_bytes_32"},
+			stg: pipe([]gopium.Strategy{
+				fpad,
+				fpad,
+				pck,
+				unpck,
+				fshareb.Bytes(32).Curator(mocks.Maven{}),
+			}),
+		},
+		"expression inside preset should return expected strategy": {
+			names: []gopium.StrategyName{"@short", "memory_unpack"},
+			stg: pipe([]gopium.Strategy{
+				filter{nregex: regexp.MustCompile(`^_$`)},
+				pck,
+				unpck,
+			}),
+		},
+		"parametrized functions should return expected strategies": {
+			names: []gopium.StrategyName{
+				"filter(type=~'^int$', name=~'^a') | explicit_paddings(alignment=natural) | explicit_paddings()",
+				"false_sharing(line=2) | false_sharing(bytes=128) | cache_rounding(bytes=32, mode=full)",
+				"isolate_fields() | isolate_fields(line=3) | isolate_fields(bytes=256)",
+				"separate_padding(alignment=system, side=bottom) | separate_padding(line=3) | separate_padding(bytes=16, side=bottom)",
+			},
+			stg: pipe([]gopium.Strategy{
+				filter{nregex: regexp.MustCompile(`^a`), tregex: regexp.MustCompile(`^int$`)},
+				padtnat.Curator(mocks.Maven{}),
+				padsys.Curator(mocks.Maven{}),
+				fsharel2.Curator(mocks.Maven{}),
+				fshareb.Bytes(128).Curator(mocks.Maven{}),
+				cachebf.Bytes(32).Curator(mocks.Maven{}),
+				isolatel1.Curator(mocks.Maven{}),
+				isolatel3.Curator(mocks.Maven{}),
+				isolateb.Bytes(256).Curator(mocks.Maven{}),
+				sepsysb.Curator(mocks.Maven{}),
+				sepl3t.Curator(mocks.Maven{}),
+				sepbb.Bytes(16).Curator(mocks.Maven{}),
+			}),
+		},
+		"padding style functions should return expected strategies": {
+			names: []gopium.StrategyName{
+				"padding_style(style=bytes) | padding_style(style=uint64) | padding_style(style=cache_line)",
+				"padding_style(type='example.com/pkg.Pad', bytes=32, align=8) | padding_style(type='example.com/pkg.linePad', line=2)",
+			},
+			stg: pipe([]gopium.Strategy{
+				stylebytes.Curator(mocks.Maven{}),
+				styleu64.Curator(mocks.Maven{}),
+				stylecpu.Curator(mocks.Maven{}),
+				style{name: "example.com/pkg.Pad", bytes: 32, align: 8}.Curator(mocks.Maven{}),
+				style{name: "example.com/pkg.linePad", line: 2}.Curator(mocks.Maven{}),
+			}),
+		},
+		"commented paddings functions should return expected strategies": {
+			names: []gopium.StrategyName{
+				"false_sharing(comment=true) | isolate_fields(comment=false) | cache_rounding(comment=true)",
+				"separate_padding(comment=true) | explicit_paddings(comment=true)",
+			},
+			stg: pipe([]gopium.Strategy{
+				fshare{line: 1, comment: true}.Curator(mocks.Maven{}),
+				isolatel1.Curator(mocks.Maven{}),
+				cache{line: 1, div: true, comment: true}.Curator(mocks.Maven{}),
+				sep{line: 1, top: true, comment: true}.Curator(mocks.Maven{}),
+				pad{sys: true, comment: true}.Curator(mocks.Maven{}),
+			}),
+		},
+		"conditional expression should return expected strategy": {
+			names: []gopium.StrategyName{"pack | if(size>64, cache_rounding(line=1) | struct_annotate_comment, unpack)"},
+			stg: pipe([]gopium.Strategy{
+				pck,
+				cond{
+					pred: pcmp{prop: "size", op: ">", val: 64},
+					then: pipe{cachel1d.Curator(mocks.Maven{}), stnotecom},
+					els:  pipe{unpck},
+				},
+			}),
+		},
+		"conditional expression without else should return expected strategy": {
+			names: []gopium.StrategyName{"if(fields<=2, pack)"},
+			stg: pipe([]gopium.Strategy{
+				cond{
+					pred: pcmp{prop: "fields", op: "<=", val: 2},
+					then: pipe{pck},
+					els:  ignr,
+				},
+			}),
+		},
+		"conditional expressions with all predicates should return expected strategy": {
+			names: []gopium.StrategyName{
+				`if(exported, pack) | if(pointers=false, pack) | if(exported!=true, pack)`,
+				`if(name=~"^A", pack) | if(type!~'^atomic\.', pack) | if(directive=hot, pack) | if(directive!="ignore", pack)`,
+			},
+			stg: pipe([]gopium.Strategy{
+				cond{pred: pflag{prop: "exported", val: true}, then: pipe{pck}, els: ignr},
+				cond{pred: pflag{prop: "pointers", val: false}, then: pipe{pck}, els: ignr},
+				cond{pred: pflag{prop: "exported", val: false}, then: pipe{pck}, els: ignr},
+				cond{pred: pregex{prop: "name", regex: regexp.MustCompile(`^A`)}, then: pipe{pck}, els: ignr},
+				cond{pred: pregex{prop: "type", regex: regexp.MustCompile(`^atomic\.`), neg: true}, then: pipe{pck}, els: ignr},
+				cond{pred: pdirective{name: "hot"}, then: pipe{pck}, els: ignr},
+				cond{pred: pdirective{name: "ignore", neg: true}, then: pipe{pck}, els: ignr},
+			}),
+		},
+		"best of expression should return expected strategy": {
+			names: []gopium.StrategyName{"best_of(pack, filter_pads | @compact, ignore, metric=class)"},
+			stg: pipe([]gopium.Strategy{
+				best{
+					stgs:   []gopium.Strategy{pipe{pck}, pipe{fpad, fpad, pck}, pipe{ignr}},
+					names:  []string{"pack", "filter_pads | @compact", "ignore"},
+					metric: mclass,
+				}.Curator(mocks.Maven{}),
+			}),
+		},
+		"best of expression without metric should return expected strategy": {
+			names: []gopium.StrategyName{"best_of(if(size>8, pack) )"},
+			stg: pipe([]gopium.Strategy{
+				best{
+					stgs:   []gopium.Strategy{pipe{cond{pred: pcmp{prop: "size", op: ">", val: 8}, then: pipe{pck}, els: ignr}}},
+					names:  []string{"if(size>8, pack)"},
+					metric: msize,
+				}.Curator(mocks.Maven{}),
+			}),
+		},
+		"expression with tags strategy should keep expression in tags": {
+			names: []gopium.StrategyName{"pack | add_tag_group_force"},
+			stg: pipe([]gopium.Strategy{
+				pck,
+				tagf.Names("pack | add_tag_group_force"),
+			}),
+		},
+		"cycled expression preset should return builder error": {
+			names: []gopium.StrategyName{"@expr"},
+			err:   errors.New(`expression "pack | @expr" can't be compiled at column 8, preset "@expr" cycle detected @expr -> @expr`),
+		},
+		"unterminated string should return positioned parse error": {
+			names: []gopium.StrategyName{`filter(name=~"^_$)`},
+			err:   errors.New(`expression "filter(name=~\"^_$)" can't be parsed at column 14, string literal isn't terminated`),
+		},
+		"unexpected character should return positioned parse error": {
+			names: []gopium.StrategyName{"pack | #"},
+			err:   errors.New(`expression "pack | #" can't be parsed at column 8, unexpected character '#'`),
+		},
+		"unknown operator should return positioned parse error": {
+			names: []gopium.StrategyName{"if(size<>1, pack)"},
+			err:   errors.New(`expression "if(size<>1, pack)" can't be parsed at column 9, expected value but found ">"`),
+		},
+		"missing pipe call should return positioned parse error": {
+			names: []gopium.StrategyName{"pack | "},
+			err:   errors.New(`expression "pack | " can't be parsed at column 8, expected strategy name but found end of expression`),
+		},
+		"missing closing paren should return positioned parse error": {
+			names: []gopium.StrategyName{"cache_rounding(line=1 pack"},
+			err:   errors.New(`expression "cache_rounding(line=1 pack" can't be parsed at column 23, expected "," or ")" but found "pack"`),
+		},
+		"trailing tokens should return positioned parse error": {
+			names: []gopium.StrategyName{"pack)"},
+			err:   errors.New(`expression "pack)" can't be parsed at column 5, unexpected ")"`),
+		},
+		"unknown function should return positioned compile error": {
+			names: []gopium.StrategyName{"pack | test()"},
+			err:   errors.New(`expression "pack | test()" can't be compiled at column 8, function "test" wasn't found`),
+		},
+		"unknown strategy should return positioned compile error": {
+			names: []gopium.StrategyName{"pack | test"},
+			err:   errors.New(`expression "pack | test" can't be compiled at column 8, strategy "test" wasn't found`),
+		},
+		"unknown argument should return positioned compile error": {
+			names: []gopium.StrategyName{"filter(size=~'a')"},
+			err:   errors.New(`expression "filter(size=~'a')" can't be compiled at column 8, function "filter" unknown argument "size", expected one of name, type`),
+		},
+		"duplicated argument should return positioned compile error": {
+			names: []gopium.StrategyName{"filter(name=~'a', name=~'b')"},
+			err:   errors.New(`expression "filter(name=~'a', name=~'b')" can't be compiled at column 19, function "filter" duplicated argument "name"`),
+		},
+		"invalid argument operator should return positioned compile error": {
+			names: []gopium.StrategyName{"filter(name='a')"},
+			err:   errors.New(`expression "filter(name='a')" can't be compiled at column 8, argument "name" unexpected operator "=", expected one of =~`),
+		},
+		"invalid regexp should return positioned compile error": {
+			names: []gopium.StrategyName{"filter(name=~'[')"},
+			err:   errors.New("expression \"filter(name=~'[')\" can't be compiled at column 14, argument \"name\" regexp can't be compiled error parsing regexp: missing closing ]: `[`"),
+		},
+		"invalid cache line should return positioned compile error": {
+			names: []gopium.StrategyName{"cache_rounding(line=4)"},
+			err:   errors.New(`expression "cache_rounding(line=4)" can't be compiled at column 21, argument "line" expects cache line 1, 2 or 3 but found 4`),
+		},
+		"float bytes should return positioned parse error": {
+			names: []gopium.StrategyName{"false_sharing(bytes=1.5)"},
+			err:   errors.New(`expression "false_sharing(bytes=1.5)" can't be parsed at column 21, float number isn't supported, expected integer`),
+		},
+		"both line and bytes should return positioned compile error": {
+			names: []gopium.StrategyName{"false_sharing(bytes=8, line=1)"},
+			err:   errors.New(`expression "false_sharing(bytes=8, line=1)" can't be compiled at column 1, function "false_sharing" expects either "line" or "bytes" argument`),
+		},
+		"invalid enum should return positioned compile error": {
+			names: []gopium.StrategyName{"separate_padding(side=left)"},
+			err:   errors.New(`expression "separate_padding(side=left)" can't be compiled at column 23, argument "side" expects one of top, bottom but found "left"`),
+		},
+		"positional argument should return positioned compile error": {
+			names: []gopium.StrategyName{"explicit_paddings(pack)"},
+			err:   errors.New(`expression "explicit_paddings(pack)" can't be compiled at column 19, function "explicit_paddings" expects only named arguments alignment, comment`),
+		},
+		"invalid comment flag should return positioned compile error": {
+			names: []gopium.StrategyName{"false_sharing(comment=yes)"},
+			err:   errors.New(`expression "false_sharing(comment=yes)" can't be compiled at column 23, argument "comment" expects one of true, false but found "yes"`),
+		},
+		"padding style without arguments should return positioned compile error": {
+			names: []gopium.StrategyName{"padding_style()"},
+			err:   errors.New(`expression "padding_style()" can't be compiled at column 1, function "padding_style" expects either "style" or "type" arguments`),
+		},
+		"padding style with both style and type should return positioned compile error": {
+			names: []gopium.StrategyName{"padding_style(style=uint64, type='Pad')"},
+			err:   errors.New(`expression "padding_style(style=uint64, type='Pad')" can't be compiled at column 1, function "padding_style" expects either "style" or "type" arguments`),
+		},
+		"padding style with empty type should return positioned compile error": {
+			names: []gopium.StrategyName{"padding_style(type='')"},
+			err:   errors.New(`expression "padding_style(type='')" can't be compiled at column 20, argument "type" expects non empty string but found ""`),
+		},
+		"unqualified padding style type should return positioned compile error": {
+			names: []gopium.StrategyName{"padding_style(type='linePad')"},
+			err:   errors.New(`expression "padding_style(type='linePad')" can't be compiled at column 20, argument "type" expects fully qualified type name like "pkg/path.Pad" but found "linePad"`),
+		},
+		"best of without candidates should return positioned compile error": {
+			names: []gopium.StrategyName{"best_of(metric=size)"},
+			err:   errors.New(`expression "best_of(metric=size)" can't be compiled at column 1, function "best_of" expects at least one strategy argument`),
+		},
+		"best of with unknown metric should return positioned compile error": {
+			names: []gopium.StrategyName{"best_of(pack, metric=speed)"},
+			err:   errors.New(`expression "best_of(pack, metric=speed)" can't be compiled at column 22, argument "metric" expects one of size, ptrdata, moved, lines, class but found "speed"`),
+		},
+		"best of with unknown argument should return positioned compile error": {
+			names: []gopium.StrategyName{"best_of(pack, score=size)"},
+			err:   errors.New(`expression "best_of(pack, score=size)" can't be compiled at column 15, function "best_of" unknown argument "score", expected one of metric`),
+		},
+		"best of with duplicated metric should return positioned compile error": {
+			names: []gopium.StrategyName{"best_of(pack, metric=size, metric=size)"},
+			err:   errors.New(`expression "best_of(pack, metric=size, metric=size)" can't be compiled at column 28, function "best_of" duplicated argument "metric"`),
+		},
+		"invalid conditional arguments should return positioned compile error": {
+			names: []gopium.StrategyName{"if(size>1)"},
+			err:   errors.New(`expression "if(size>1)" can't be compiled at column 1, function "if" expects predicate, then and optional else arguments`),
+		},
+		"invalid conditional predicate should return positioned compile error": {
+			names: []gopium.StrategyName{"if(pack, pack)"},
+			err:   errors.New(`expression "if(pack, pack)" can't be compiled at column 4, expected predicate but found strategy`),
+		},
+		"unknown conditional predicate should return positioned compile error": {
+			names: []gopium.StrategyName{"if(length>1, pack)"},
+			err:   errors.New(`expression "if(length>1, pack)" can't be compiled at column 4, predicate "length" wasn't found`),
+		},
+		"invalid conditional value should return positioned compile error": {
+			names: []gopium.StrategyName{"if(size>big, pack)"},
+			err:   errors.New(`expression "if(size>big, pack)" can't be compiled at column 9, predicate "size" expects integer but found "big"`),
+		},
+		"invalid conditional flag value should return positioned compile error": {
+			names: []gopium.StrategyName{"if(exported=yes, pack)"},
+			err:   errors.New(`expression "if(exported=yes, pack)" can't be compiled at column 13, predicate "exported" expects true or false but found "yes"`),
+		},
+		"invalid conditional flag operator should return positioned compile error": {
+			names: []gopium.StrategyName{"if(pointers>true, pack)"},
+			err:   errors.New(`expression "if(pointers>true, pack)" can't be compiled at column 4, argument "pointers" unexpected operator ">", expected one of = !=`),
+		},
+		"invalid conditional regexp should return positioned compile error": {
+			names: []gopium.StrategyName{"if(type=~'(', pack)"},
+			err:   errors.New("expression \"if(type=~'(', pack)\" can't be compiled at column 10, predicate \"type\" regexp can't be compiled error parsing regexp: missing closing ): `(`"),
+		},
+		"invalid conditional directive should return positioned compile error": {
+			names: []gopium.StrategyName{"if(directive=1, pack)"},
+			err:   errors.New(`expression "if(directive=1, pack)" can't be compiled at column 14, predicate "directive" expects directive name but found "1"`),
+		},
+		"invalid conditional call predicate should return positioned compile error": {
+			names: []gopium.StrategyName{"if(exported(), pack)"},
+			err:   errors.New(`expression "if(exported(), pack)" can't be compiled at column 4, expected predicate but found strategy`),
+		},
+		"invalid conditional strategy should return positioned compile error": {
+			names: []gopium.StrategyName{"if(size>1, size>2)"},
+			err:   errors.New(`expression "if(size>1, size>2)" can't be compiled at column 12, function "if" expects strategy argument`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			stg, err := b.Build(tcase.names...)
+			// check
+			if !reflect.DeepEqual(stg, tcase.stg) {
+				t.Errorf("actual %v doesn't equal to expected %v", stg, tcase.stg)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
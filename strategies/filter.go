@@ -11,18 +11,20 @@ import (
 // list of filter presets
 var (
 	// list of filter presets
-	fpad = filter{
-		nregex: regexp.MustCompile(`^_$`),
-	}
+	fpad = filter{pads: true}
 )
 
 // filter defines strategy implementation
 // that filters out all structure fields
-// that matches provided criteria
+// that matches provided criteria,
+// pads criteria matches only gopium pads
+// `_ [N]byte` that aren't pinned by tag
 type filter struct {
 	nregex *regexp.Regexp `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	tregex *regexp.Regexp `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; - 🌺 gopium @1pkg
+	pads   bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_      [15]byte       `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
 
 // Apply filter implementation
 func (stg filter) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
@@ -41,6 +43,10 @@ func (stg filter) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, er
 			if stg.tregex != nil && stg.tregex.MatchString(f.Type) {
 				continue
 			}
+			// check if field is not pinned pad
+			if h, err := hint(f); stg.pads && collections.IsPad(f) && (err != nil || !h.pin) {
+				continue
+			}
 			// if it doesn't append it to fields
 			fields = append(fields, f)
 		}
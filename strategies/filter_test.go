@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/1pkg/gopium/collections"
 	"github.com/1pkg/gopium/gopium"
 )
 
@@ -20,6 +21,37 @@ func TestFilter(t *testing.T) {
 		r      gopium.Struct
 		err    error
 	}{
+		"non empty struct should be applied to expected struct with pads filter": {
+			filter: fpad,
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "_", Type: "noCopy"},
+					{Name: "test1", Type: "int64", Size: 8, Align: 8},
+					collections.PadField(8),
+					{Name: "_", Type: "[0]func()", Align: 8},
+					{Name: "_", Type: "[4]uint8", Size: 4, Align: 1, Tag: `gopium:"pad"`},
+					{Name: "_", Type: "[4]byte", Size: 4, Align: 1, Tag: `gopium:"pad;pin"`},
+					{Name: "_", Type: "cpu.CacheLinePad", Size: 64, Align: 1, Tag: `gopium:"pad;group:def;filter_pads"`},
+					{Name: "_", Type: "[8]byte", Size: 8, Align: 1},
+					{Name: "_", Type: "cpu.CacheLinePad", Size: 64, Align: 1},
+					{Name: "_", Type: "sync.Mutex", Size: 8, Align: 4},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "_", Type: "noCopy"},
+					{Name: "test1", Type: "int64", Size: 8, Align: 8},
+					{Name: "_", Type: "[0]func()", Align: 8},
+					{Name: "_", Type: "[4]byte", Size: 4, Align: 1, Tag: `gopium:"pad;pin"`},
+					{Name: "_", Type: "[8]byte", Size: 8, Align: 1},
+					{Name: "_", Type: "cpu.CacheLinePad", Size: 64, Align: 1},
+					{Name: "_", Type: "sync.Mutex", Size: 8, Align: 4},
+				},
+			},
+		},
 		"empty struct should be applied to empty struct with empty filter": {
 			filter: filter{},
 			ctx:    context.Background(),
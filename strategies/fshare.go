@@ -23,7 +23,9 @@ type fshare struct {
 	curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	line    uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	bytes   uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
+	comment bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [31]byte       `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
 
 // Bytes erich fshare strategy with custom bytes
 func (stg fshare) Bytes(bytes uint) fshare {
@@ -55,7 +57,7 @@ func (stg fshare) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, er
 			// if padding size is valid
 			if pad := f.Size % cachel; pad > 0 {
 				pad = cachel - pad
-				fields = append(fields, collections.PadField(pad))
+				fields = append(fields, padnote(pad, stg.comment, "false sharing guard (%s)", lname(stg.line, stg.bytes)))
 			}
 		}
 		// update resulted fields
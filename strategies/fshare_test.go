@@ -76,6 +76,37 @@ func TestFshare(t *testing.T) {
 			},
 			err: context.Canceled,
 		},
+		"non empty struct should be applied to expected aligned struct with commented pads": {
+			fshare: fshare{line: 1, comment: true},
+			c:      mocks.Maven{SCache: []int64{16, 16, 16}},
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Size: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Size: 8,
+					},
+					{
+						Name:    "_",
+						Type:    "[8]byte",
+						Size:    8,
+						Align:   1,
+						Tag:     `gopium:"pad"`,
+						Comment: []string{"// false sharing guard (L1); - 🌺 gopium @1pkg"},
+					},
+				},
+			},
+		},
 		"non empty struct should be applied to expected aligned struct custom bytes": {
 			fshare: fshareb.Bytes(20),
 			c:      mocks.Maven{SCache: []int64{16, 16, 16}},
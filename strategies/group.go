@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/1pkg/gopium/collections"
@@ -28,6 +29,13 @@ var (
 // note: supports only next fields tags annotation formats
 // `gopium:"stg,stg,stg"` processed as `default` group
 // `gopium:"group:def;stg,stg,stg"` processed as named group
+// `gopium:"group:def:priority=1:isolate;stg,stg,stg"` processed as prioritized isolated named group
+// `gopium:"group:def:priority=1;isolate;stg,stg,stg"` processed as prioritized isolated named group
+// `gopium:"group:def:isolate:style=cache_line;stg,stg,stg"` processed as isolated named group with styled pads
+// and next struct directives
+// `//gopium:strategies stg,stg,stg` processed as `default` group for untagged fields
+// `//gopium:untagged top|bottom` places untagged fields group at struct top or bottom
+// `//gopium:ignore` and `//gopium:file-ignore` skip struct processing
 type group struct {
 	builder Builder `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 } // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; - 🌺 gopium @1pkg
@@ -38,8 +46,18 @@ type container struct {
 	r   gopium.Struct   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	grp string          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	stg gopium.Strategy `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_   [48]byte        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 256 bytes; struct align: 8 bytes; struct aligned size: 256 bytes; - 🌺 gopium @1pkg
+	opt gopt            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_   [48]byte        `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 320 bytes; struct align: 8 bytes; struct aligned size: 320 bytes; - 🌺 gopium @1pkg
+
+// gopt carries single group options
+type gopt struct {
+	style   gopium.StrategyName `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	prio    int                 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	prioed  bool                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	isolate bool                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [6]byte             `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
 
 // Curator erich group strategy with builder instance
 func (stg group) Builder(builder Builder) group {
@@ -51,14 +69,41 @@ func (stg group) Builder(builder Builder) group {
 func (stg group) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
 	// copy original structure to result
 	r := collections.CopyStruct(o)
+	// in case struct or its file
+	// is marked as ignored by directive
+	// just skip struct processing
+	_, ignore := directive(r, "ignore")
+	_, fignore := directive(r, "file-ignore")
+	if ignore || fignore {
+		return r, ctx.Err()
+	}
+	// extract user blank fields
+	// to restore them on their indexes
+	st := collections.CopyStruct(r)
+	st.Fields = st.Fields[:0]
+	bindexes := make([]int, 0)
+	bfields := make([]gopium.Field, 0)
+	for i, f := range r.Fields {
+		if blank(f) {
+			bindexes = append(bindexes, i)
+			bfields = append(bfields, f)
+			continue
+		}
+		st.Fields = append(st.Fields, f)
+	}
 	// parse tag annotation
 	// into containers groups
-	containers, err := stg.parse(r)
+	containers, err := stg.parse(st)
 	// in case of any error
 	// just return error back
 	if err != nil {
 		return o, err
 	}
+	// parse untagged fields placement
+	placement, err := dplacement(r)
+	if err != nil {
+		return o, err
+	}
 	// create sync error group
 	// with cancelation context
 	group, gctx := errgroup.WithContext(ctx)
@@ -100,22 +145,173 @@ func (stg group) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, err
 	if err := group.Wait(); err != nil {
 		return o, err
 	}
-	// sort result containers lexicographicaly
+	// sort result containers by their ranks
+	// prioritized groups go first by priority
+	// and then all other groups lexicographicaly
 	sort.SliceStable(containers, func(i, j int) bool {
-		return containers[i].grp < containers[j].grp
+		ci, cj := &containers[i], &containers[j]
+		if ri, rj := ci.rank(placement), cj.rank(placement); ri != rj {
+			return ri < rj
+		}
+		if ci.opt.prio != cj.opt.prio {
+			return ci.opt.prio < cj.opt.prio
+		}
+		return ci.grp < cj.grp
 	})
 	// combine all results to single result struct
+	// isolate groups with cache line paddings
+	var cachel int64
+	if stg.builder.Curator != nil {
+		cachel = stg.builder.Curator.SysCache(1)
+	}
 	r.Fields = nil
+	ipads := make(map[int]gopium.StrategyName)
 	for i := range containers {
+		// in case group isn't isolated
+		// just append its fields
+		if !containers[i].opt.isolate || cachel <= 0 {
+			r.Fields = append(r.Fields, containers[i].r.Fields...)
+			continue
+		}
+		// otherwise pad group from both sides
+		// and keep pads indexes for styling
+		if pad := (cachel - offset(r.Fields)%cachel) % cachel; pad > 0 {
+			ipads[len(r.Fields)] = containers[i].opt.style
+			r.Fields = append(r.Fields, collections.PadField(pad))
+		}
 		r.Fields = append(r.Fields, containers[i].r.Fields...)
+		if pad := (cachel - offset(r.Fields)%cachel) % cachel; pad > 0 {
+			ipads[len(r.Fields)] = containers[i].opt.style
+			r.Fields = append(r.Fields, collections.PadField(pad))
+		}
+	}
+	// restore user blank fields on their indexes
+	// and shift isolation pads indexes after them
+	for i, index := range bindexes {
+		if index > len(r.Fields) {
+			index = len(r.Fields)
+		}
+		r.Fields = append(r.Fields, gopium.Field{})
+		copy(r.Fields[index+1:], r.Fields[index:])
+		r.Fields[index] = bfields[i]
+		shifted := make(map[int]gopium.StrategyName, len(ipads))
+		for pindex, style := range ipads {
+			if pindex >= index {
+				pindex++
+			}
+			shifted[pindex] = style
+		}
+		ipads = shifted
+	}
+	// restyle isolation pads with their groups
+	// padding styles on the final struct layout
+	styled := make(map[gopium.StrategyName]gopium.Struct)
+	for index, style := range ipads {
+		if style == "" {
+			continue
+		}
+		if _, ok := styled[style]; !ok {
+			sstg, err := stg.builder.Build(style)
+			if err != nil {
+				return o, err
+			}
+			tmp, err := sstg.Apply(ctx, collections.CopyStruct(r))
+			if err != nil {
+				return o, err
+			}
+			styled[style] = tmp
+		}
+		r.Fields[index] = styled[style].Fields[index]
 	}
 	return r, ctx.Err()
 }
 
+// rank helps to define container ordering bucket
+// untagged group on top, prioritized groups,
+// all other groups, untagged group on bottom
+func (cnt container) rank(placement string) int {
+	switch {
+	case cnt.grp == "-" && placement == "top":
+		return 0
+	case cnt.grp == "-" && placement == "bottom":
+		return 3
+	case cnt.opt.prioed:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// offset helps to calculate fields end offset
+func offset(fields []gopium.Field) int64 {
+	var offset int64
+	for _, f := range fields {
+		if f.Align > 0 {
+			offset = collections.Align(offset, f.Align)
+		}
+		offset += f.Size
+	}
+	return offset
+}
+
+// dplacement helps to parse untagged
+// fields group placement directive
+// - `//gopium:untagged top` placed at struct top
+// - `//gopium:untagged bottom` placed at struct bottom
+func dplacement(st gopium.Struct) (string, error) {
+	body, ok := directive(st, "untagged")
+	switch {
+	case !ok:
+		return "", nil
+	case body == "top", body == "bottom":
+		return body, nil
+	default:
+		return "", fmt.Errorf("directive `untagged` can't be parsed, placement %q should be either top or bottom", body)
+	}
+}
+
+// ganchor helps to parse named group anchor
+// `group:name:priority=N:isolate:style=bytes|uint64|cache_line`
+// to group name and options
+func ganchor(anchor string) (string, gopt, error) {
+	var opt gopt
+	parts := strings.Split(strings.Replace(anchor, "group:", "", 1), ":")
+	for _, part := range parts[1:] {
+		switch part = strings.TrimSpace(part); {
+		case part == "isolate":
+			opt.isolate = true
+		case strings.HasPrefix(part, "style="):
+			switch style := strings.TrimPrefix(part, "style="); style {
+			case "bytes":
+				opt.style = StyleB
+			case "uint64":
+				opt.style = StyleU64
+			case "cache_line":
+				opt.style = StyleCPU
+			default:
+				return "", opt, fmt.Errorf("group anchor %q can't be parsed, style %q should be either bytes, uint64 or cache_line", anchor, style)
+			}
+		case strings.HasPrefix(part, "priority="):
+			prio, err := strconv.Atoi(strings.TrimPrefix(part, "priority="))
+			if err != nil {
+				return "", opt, fmt.Errorf("group anchor %q can't be parsed, priority should be integer", anchor)
+			}
+			opt.prio, opt.prioed = prio, true
+		default:
+			return "", opt, fmt.Errorf("group anchor %q can't be parsed, unknown option %q", anchor, part)
+		}
+	}
+	return parts[0], opt, nil
+}
+
 // parse helps to parse structure fields tags
 // into groups container or returns parse error
 // - `gopium:"stg,stg,stg"` parsed to `default` group
 // - `gopium:"group:def;stg,stg,stg"` parsed to named group
+// - `gopium:"budget:size=64;..."` budget token is skipped
+// - `gopium:"pin;..."` fields hints and pad tokens are skipped
+// - `gopium:"group:def;isolate;..."` isolate token after group anchor isolates the group
+// - `//gopium:strategies stg,stg,stg` untagged fields parsed to `default` group
 // - otherwise a parse error returned
 func (stg group) parse(st gopium.Struct) ([]container, error) {
 	// setup temporary groups maps
@@ -123,10 +319,28 @@ func (stg group) parse(st gopium.Struct) ([]container, error) {
 	gfields := make(map[string][]gopium.Field)
 	gstrategies := make(map[string]gopium.Strategy)
 	gstrategiesnames := make(map[string]string)
+	gopts := make(map[string]gopt)
+	// in case struct declares strategies directive
+	// use it as default group strategies list
+	body, dok := directive(st, "strategies")
+	if dok {
+		stgs, err := dstrategies(body)
+		if err != nil {
+			return nil, err
+		}
+		gstrategiesnames[""] = stgs
+	}
 	// go through all struct fields
 	for _, f := range st.Fields {
 		// grab the field tag
 		tag, ok := reflect.StructTag(f.Tag).Lookup(gopium.NAME)
+		// in case tag is empty and struct
+		// declares strategies directive
+		// use default group for the field
+		if !ok && dok {
+			gfields[""] = append(gfields[""], collections.CopyField(f))
+			continue
+		}
 		// in case tag is empty
 		// or marked as skipped
 		if !ok || tag == "-" {
@@ -136,8 +350,31 @@ func (stg group) parse(st gopium.Struct) ([]container, error) {
 		// trim all excess separators
 		tag = strings.Trim(tag, ";")
 		// otherwise parse the tag
-		tokens := strings.Split(tag, ";")
+		// skip budget and hints tokens as they
+		// are processed by relevant strategies
+		// isolate token that directly follows
+		// group anchor is parsed as group option
+		tokens := make([]string, 0, 2)
+		var gisolate bool
+		ttokens := esplit(tag, ';')
+		for i, token := range ttokens {
+			if gisolatet(ttokens, i) {
+				gisolate = true
+				continue
+			}
+			if !strings.HasPrefix(token, "budget:") && !hintt(token) {
+				tokens = append(tokens, token)
+			}
+		}
 		switch tlen := len(tokens); tlen {
+		case 0:
+			// budget or hints only tag is treated
+			// the same way as empty tag
+			if dok {
+				gfields[""] = append(gfields[""], collections.CopyField(f))
+			} else {
+				gfields["-"] = append(gfields["-"], f)
+			}
 		case 1:
 			stgs := tokens[0]
 			// check that strategies list is consistent
@@ -158,8 +395,32 @@ func (stg group) parse(st gopium.Struct) ([]container, error) {
 			if !strings.Contains(group, "group:") {
 				return nil, fmt.Errorf("tag %q can't be parsed, named group `group:` anchor wasn't found", f.Tag)
 			}
-			// remove group anchor
-			group = strings.Replace(group, "group:", "", 1)
+			// parse group anchor name and options
+			group, opt, err := ganchor(group)
+			if err != nil {
+				return nil, err
+			}
+			opt.isolate = opt.isolate || gisolate
+			// check that group options are consistent
+			if gopt, ok := gopts[group]; ok {
+				if opt.prioed && gopt.prioed && opt.prio != gopt.prio {
+					return nil, fmt.Errorf(
+						"inconsistent priority %d for field %q in group %q",
+						opt.prio,
+						f.Name,
+						group,
+					)
+				}
+				// merge options with previous fields
+				if !opt.prioed {
+					opt.prio, opt.prioed = gopt.prio, gopt.prioed
+				}
+				opt.isolate = opt.isolate || gopt.isolate
+				if opt.style == "" {
+					opt.style = gopt.style
+				}
+			}
+			gopts[group] = opt
 			// check that strategies list is consistent
 			if gstg, ok := gstrategiesnames[group]; ok && gstg != stgs {
 				return nil, fmt.Errorf(
@@ -181,7 +442,7 @@ func (stg group) parse(st gopium.Struct) ([]container, error) {
 	// and build pipe strategy from them
 	for grp, gstgs := range gstrategiesnames {
 		// prepare strategy pipe
-		names := strings.Split(gstgs, ",")
+		names := esplit(gstgs, ',')
 		p := make(pipe, 0, len(names))
 		// go through list of strategy name
 		for _, name := range names {
@@ -205,7 +466,9 @@ func (stg group) parse(st gopium.Struct) ([]container, error) {
 		// prepare new empty group container
 		var cnt container
 		// set container group
+		// and group options
 		cnt.grp = grp
+		cnt.opt = gopts[grp]
 		// set container original
 		// struct and its fields
 		cnt.o = collections.CopyStruct(st)
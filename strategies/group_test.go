@@ -176,6 +176,617 @@ func TestGroup(t *testing.T) {
 				},
 			},
 		},
+		"non empty struct with relevant group tag and budget token should be applied to expected struct": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 4,
+						Tag:   `gopium:"budget:size=8;group:def;fields_annotate_doc"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 4,
+						Tag:   `gopium:"budget:size=8;group:def;fields_annotate_doc"`,
+						Doc:   []string{"// field size: 8 bytes; field align: 4 bytes; - 🌺 gopium @1pkg"},
+					},
+				},
+			},
+		},
+		"non empty struct with only budget token should be applied to itself": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 4,
+						Tag:   `gopium:"budget:size=8"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 4,
+						Tag:   `gopium:"budget:size=8"`,
+					},
+				},
+			},
+		},
+		"non empty struct with strategies directive should be applied to expected struct": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"strategies fields_annotate_doc, name_lexicographical_descending"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 4,
+						Tag:   `gopium:"fields_annotate_doc,name_lexicographical_descending"`,
+					},
+					{
+						Name:  "test3",
+						Size:  8,
+						Align: 4,
+						Tag:   `gopium:"-"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"strategies fields_annotate_doc, name_lexicographical_descending"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 4,
+						Tag:   `gopium:"fields_annotate_doc,name_lexicographical_descending"`,
+						Doc:   []string{"// field size: 8 bytes; field align: 4 bytes; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:  "test1",
+						Size:  8,
+						Align: 4,
+						Doc:   []string{"// field size: 8 bytes; field align: 4 bytes; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:  "test3",
+						Size:  8,
+						Align: 4,
+						Tag:   `gopium:"-"`,
+					},
+				},
+			},
+		},
+		"non empty struct with ignore directive should be applied to itself": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"ignore"},
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `gopium:"fields_annotate_doc"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"ignore"},
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `gopium:"fields_annotate_doc"`,
+					},
+				},
+			},
+		},
+		"non empty struct with file ignore directive should be applied to itself": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"file-ignore", "strategies fields_annotate_doc"},
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"file-ignore", "strategies fields_annotate_doc"},
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+		},
+		"non empty struct with empty strategies directive should be applied to itself with error": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"strategies"},
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"strategies"},
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+			err: errors.New("directive `strategies` can't be parsed, strategies list is empty"),
+		},
+		"non empty struct with strategies directive and inconsistent tag should be applied to itself with error": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"strategies fields_annotate_doc"},
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `gopium:"fields_annotate_comment"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"strategies fields_annotate_doc"},
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `gopium:"fields_annotate_comment"`,
+					},
+				},
+			},
+			err: errors.New(`inconsistent strategies list "fields_annotate_comment" for field "test" in default group`),
+		},
+		"non empty struct with preset tag should be applied to expected struct": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"@compact"`,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"@compact"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"@compact"`,
+					},
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"@compact"`,
+					},
+				},
+			},
+		},
+		"non empty struct with hints tags should be applied to expected struct": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"pin;memory_pack"`,
+					},
+					{
+						Name:  "test2",
+						Size:  4,
+						Align: 4,
+						Tag:   `gopium:"memory_pack"`,
+					},
+					{
+						Name:  "test3",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"memory_pack"`,
+					},
+					{
+						Name:  "test4",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"pin"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"pin;memory_pack"`,
+					},
+					{
+						Name:  "test3",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"memory_pack"`,
+					},
+					{
+						Name:  "test2",
+						Size:  4,
+						Align: 4,
+						Tag:   `gopium:"memory_pack"`,
+					},
+					{
+						Name:  "test4",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"pin"`,
+					},
+				},
+			},
+		},
+		"non empty struct with expression tag should be applied to expected struct": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"group:def;filter(name=~'^_$') | if(fields>=2, pack, unpack)"`,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"group:def;filter(name=~'^_$') | if(fields>=2, pack, unpack)"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"group:def;filter(name=~'^_$') | if(fields>=2, pack, unpack)"`,
+					},
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"group:def;filter(name=~'^_$') | if(fields>=2, pack, unpack)"`,
+					},
+				},
+			},
+		},
+		"non empty struct with user blank fields should be applied to expected struct": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "_", Type: "noCopy"},
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:b;memory_pack"`},
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"group:a;memory_pack"`},
+					{Name: "_", Type: "[0]func()", Align: 8, Tag: `gopium:"group:b;memory_pack"`},
+					{Name: "test3", Size: 4, Align: 4, Tag: `gopium:"group:a;memory_pack"`},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "_", Type: "noCopy"},
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"group:a;memory_pack"`},
+					{Name: "test3", Size: 4, Align: 4, Tag: `gopium:"group:a;memory_pack"`},
+					{Name: "_", Type: "[0]func()", Align: 8, Tag: `gopium:"group:b;memory_pack"`},
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:b;memory_pack"`},
+				},
+			},
+		},
+		"non empty struct with prioritized groups tags should be applied to expected struct": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:z:priority=2;memory_pack"`},
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"group:y:priority=1;memory_pack"`},
+					{Name: "test3", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+					{Name: "test4", Size: 2, Align: 2},
+					{Name: "test5", Size: 1, Align: 1, Tag: `gopium:"group:a;memory_pack"`},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"group:y:priority=1;memory_pack"`},
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:z:priority=2;memory_pack"`},
+					{Name: "test3", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+					{Name: "test4", Size: 2, Align: 2},
+					{Name: "test5", Size: 1, Align: 1, Tag: `gopium:"group:a;memory_pack"`},
+				},
+			},
+		},
+		"non empty struct with untagged top directive should be applied to expected struct": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"untagged top"},
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:z:priority=2;memory_pack"`},
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"group:y:priority=1;memory_pack"`},
+					{Name: "test3", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+					{Name: "test4", Size: 2, Align: 2},
+					{Name: "test5", Size: 1, Align: 1, Tag: `gopium:"group:a;memory_pack"`},
+				},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"untagged top"},
+				Fields: []gopium.Field{
+					{Name: "test4", Size: 2, Align: 2},
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"group:y:priority=1;memory_pack"`},
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:z:priority=2;memory_pack"`},
+					{Name: "test3", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+					{Name: "test5", Size: 1, Align: 1, Tag: `gopium:"group:a;memory_pack"`},
+				},
+			},
+		},
+		"non empty struct with untagged bottom directive should be applied to expected struct": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"untagged bottom"},
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:z:priority=2;memory_pack"`},
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"group:y:priority=1;memory_pack"`},
+					{Name: "test3", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+					{Name: "test4", Size: 2, Align: 2},
+					{Name: "test5", Size: 1, Align: 1, Tag: `gopium:"group:a;memory_pack"`},
+				},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"untagged bottom"},
+				Fields: []gopium.Field{
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"group:y:priority=1;memory_pack"`},
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:z:priority=2;memory_pack"`},
+					{Name: "test3", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+					{Name: "test5", Size: 1, Align: 1, Tag: `gopium:"group:a;memory_pack"`},
+					{Name: "test4", Size: 2, Align: 2},
+				},
+			},
+		},
+		"non empty struct with isolated groups tags should be applied to expected struct": {
+			b:   Builder{Curator: mocks.Maven{SCache: []int64{16}}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"group:hot:priority=1:isolate;memory_pack"`},
+					{Name: "test3", Size: 2, Align: 2, Tag: `gopium:"group:hot2:priority=2:isolate;memory_pack"`},
+					{Name: "test4", Size: 1, Align: 1, Tag: `gopium:"group:hot2;memory_pack"`},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"group:hot:priority=1:isolate;memory_pack"`},
+					collections.PadField(8),
+					{Name: "test3", Size: 2, Align: 2, Tag: `gopium:"group:hot2:priority=2:isolate;memory_pack"`},
+					{Name: "test4", Size: 1, Align: 1, Tag: `gopium:"group:hot2;memory_pack"`},
+					collections.PadField(13),
+					{Name: "test1", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+				},
+			},
+		},
+		"non empty struct with isolate token after group anchor should be applied to expected isolated group struct": {
+			b:   Builder{Curator: mocks.Maven{SCache: []int64{16}}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"group:hot:priority=1;isolate;memory_pack"`},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"group:hot:priority=1;isolate;memory_pack"`},
+					collections.PadField(8),
+					{Name: "test1", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+				},
+			},
+		},
+		"non empty struct with isolate hint token before group anchor should be applied to expected not isolated group struct": {
+			b:   Builder{Curator: mocks.Maven{SCache: []int64{16}}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"isolate;group:hot:priority=1;memory_pack"`},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"isolate;group:hot:priority=1;memory_pack"`},
+					{Name: "test1", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+				},
+			},
+		},
+		"non empty struct with styled isolated groups tags should be applied to expected struct": {
+			b:   Builder{Curator: mocks.Maven{SCache: []int64{16}}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "_", Type: "[0]func()", Align: 8},
+					{Name: "test1", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"group:hot:isolate:style=uint64;memory_pack"`},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "_", Type: "[0]func()", Align: 8},
+					{Name: "test1", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+					{Name: "_", Type: "[12]byte", Size: 12, Align: 1, Tag: `gopium:"pad"`},
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"group:hot:isolate:style=uint64;memory_pack"`},
+					{Name: "_", Type: "[1]uint64", Size: 8, Align: 8, Tag: `gopium:"pad"`},
+				},
+			},
+		},
+		"non empty struct with inconsistent groups priorities should be applied to itself with error": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:def:priority=1;memory_pack"`},
+					{Name: "test2", Size: 1, Align: 1, Tag: `gopium:"group:def:priority=2;memory_pack"`},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:def:priority=1;memory_pack"`},
+					{Name: "test2", Size: 1, Align: 1, Tag: `gopium:"group:def:priority=2;memory_pack"`},
+				},
+			},
+			err: errors.New(`inconsistent priority 2 for field "test2" in group "def"`),
+		},
+		"non empty struct with invalid group priority should be applied to itself with error": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:def:priority=high;memory_pack"`},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:def:priority=high;memory_pack"`},
+				},
+			},
+			err: errors.New(`group anchor "group:def:priority=high" can't be parsed, priority should be integer`),
+		},
+		"non empty struct with unknown group style should be applied to itself with error": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:def:isolate:style=words;memory_pack"`},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:def:isolate:style=words;memory_pack"`},
+				},
+			},
+			err: errors.New(`group anchor "group:def:isolate:style=words" can't be parsed, style "words" should be either bytes, uint64 or cache_line`),
+		},
+		"non empty struct with unknown group option should be applied to itself with error": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:def:hot;memory_pack"`},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"group:def:hot;memory_pack"`},
+				},
+			},
+			err: errors.New(`group anchor "group:def:hot" can't be parsed, unknown option "hot"`),
+		},
+		"non empty struct with invalid untagged directive should be applied to itself with error": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"untagged middle"},
+				Fields: []gopium.Field{
+					{Name: "test3", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+					{Name: "test4", Size: 2, Align: 2},
+				},
+			},
+			r: gopium.Struct{
+				Name:       "test",
+				Directives: []string{"untagged middle"},
+				Fields: []gopium.Field{
+					{Name: "test3", Size: 4, Align: 4, Tag: `gopium:"memory_pack"`},
+					{Name: "test4", Size: 2, Align: 2},
+				},
+			},
+			err: errors.New("directive `untagged` can't be parsed, placement \"middle\" should be either top or bottom"),
+		},
 		"non empty struct with invalid tag should be applied to itself with error": {
 			b:   Builder{Curator: mocks.Maven{}},
 			ctx: context.Background(),
@@ -0,0 +1,177 @@
+package strategies
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// fhint defines field placement hint
+// parsed from field gopium tag tokens
+type fhint struct {
+	order   int     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	pin     bool    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	first   bool    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	last    bool    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	ordered bool    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	isolate bool    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [3]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; - 🌺 gopium @1pkg
+
+// hintt checks if tag token is field hint token
+// - `pin` keeps field at its current index
+// - `first` and `last` move field to struct top or bottom
+// - `order:N` moves field to index N
+// - `isolate` isolates field on its own cache line,
+// unless it directly follows named group anchor
+// - `pad` marks gopium pad field
+func hintt(token string) bool {
+	switch token {
+	case "pin", "first", "last", "isolate", "pad":
+		return true
+	default:
+		return strings.HasPrefix(token, "order:")
+	}
+}
+
+// gisolatet checks if tag token at index is
+// group isolation token that directly follows
+// named group anchor `gopium:"group:def;isolate;stg,stg,stg"`
+func gisolatet(tokens []string, i int) bool {
+	return i > 0 &&
+		strings.TrimSpace(tokens[i]) == "isolate" &&
+		strings.HasPrefix(strings.TrimSpace(tokens[i-1]), "group:")
+}
+
+// hint helps to parse field placement hint
+// from field gopium tag tokens,
+// hints could be combined with other tokens
+// `gopium:"pin;group:def;stg,stg,stg"`
+func hint(f gopium.Field) (fhint, error) {
+	var h fhint
+	// grab the field tag
+	// and go through all hint tokens
+	// group isolation tokens are skipped
+	tag := reflect.StructTag(f.Tag).Get(gopium.NAME)
+	tokens := esplit(tag, ';')
+	for i, token := range tokens {
+		if gisolatet(tokens, i) {
+			continue
+		}
+		switch token = strings.TrimSpace(token); {
+		case token == "pin":
+			h.pin = true
+		case token == "first":
+			h.first = true
+		case token == "last":
+			h.last = true
+		case token == "isolate":
+			h.isolate = true
+		case strings.HasPrefix(token, "order:"):
+			order, err := strconv.Atoi(strings.TrimPrefix(token, "order:"))
+			if err != nil || order < 0 {
+				return h, fmt.Errorf("hint %q of field %q can't be parsed, order should be non negative integer", token, f.Name)
+			}
+			h.order, h.ordered = order, true
+		}
+	}
+	// user blank fields are pinned
+	// unless they are hinted explicitly
+	if blank(f) && !h.first && !h.last && !h.ordered {
+		h.pin = true
+	}
+	// check that hints are consistent
+	if cnt := btoi(h.pin) + btoi(h.first) + btoi(h.last) + btoi(h.ordered); cnt > 1 {
+		return h, fmt.Errorf("hints of field %q are inconsistent, only one of pin, first, last, order is expected", f.Name)
+	}
+	return h, nil
+}
+
+// reorder helps to rearrange fields
+// with provided arrange function
+// while respecting fields placement hints,
+// pinned and ordered fields keep their indexes,
+// first and last fields are placed around
+// arranged fields in their original order
+func reorder(fields []gopium.Field, arrange func([]gopium.Field) []gopium.Field) ([]gopium.Field, error) {
+	// prepare fields hints and slots
+	flen := len(fields)
+	hints := make([]fhint, 0, flen)
+	slots := make([]*gopium.Field, flen)
+	hinted := false
+	// go through all fields and
+	// place pinned fields in slots
+	for i := range fields {
+		h, err := hint(fields[i])
+		if err != nil {
+			return nil, err
+		}
+		if h.pin {
+			slots[i] = &fields[i]
+		}
+		hints = append(hints, h)
+		hinted = hinted || h.pin || h.ordered || h.first || h.last
+	}
+	// in case nothing is hinted
+	// just arrange all fields
+	if !hinted {
+		return arrange(fields), nil
+	}
+	// go through all fields and
+	// place ordered fields in slots
+	// and collect the rest of fields
+	firsts := make([]gopium.Field, 0, flen)
+	movables := make([]gopium.Field, 0, flen)
+	lasts := make([]gopium.Field, 0, flen)
+	for i, h := range hints {
+		f := fields[i]
+		switch {
+		case h.pin:
+		case h.ordered:
+			if h.order >= flen {
+				return nil, fmt.Errorf("field %q order %d is out of fields range %d", f.Name, h.order, flen)
+			}
+			if slot := slots[h.order]; slot != nil {
+				return nil, fmt.Errorf("field %q order %d conflicts with field %q", f.Name, h.order, slot.Name)
+			}
+			slots[h.order] = &fields[i]
+		case h.first:
+			firsts = append(firsts, f)
+		case h.last:
+			lasts = append(lasts, f)
+		default:
+			movables = append(movables, f)
+		}
+	}
+	// otherwise fill free slots sequentially
+	// with first, arranged and last fields
+	seq := append(append(firsts, arrange(movables)...), lasts...)
+	result := make([]gopium.Field, 0, flen)
+	for _, slot := range slots {
+		if slot != nil {
+			result = append(result, *slot)
+			continue
+		}
+		result = append(result, seq[0])
+		seq = seq[1:]
+	}
+	return result, nil
+}
+
+// blank checks if field is user blank field
+// `_ [8]byte` or `_ noCopy`, but not gopium pad
+func blank(f gopium.Field) bool {
+	return f.Name == "_" && !collections.IsPad(f)
+}
+
+// btoi helps to convert bool to int
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
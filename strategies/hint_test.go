@@ -0,0 +1,125 @@
+package strategies
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestReorder(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		fields []gopium.Field
+		r      []gopium.Field
+		err    error
+	}{
+		"empty fields should be reordered to empty fields": {},
+		"not hinted fields should be reordered by arrange": {
+			fields: []gopium.Field{
+				{Name: "test1", Size: 1, Align: 1},
+				{Name: "test2", Size: 8, Align: 8},
+				{Name: "test3", Size: 4, Align: 4, Tag: `gopium:"isolate"`},
+			},
+			r: []gopium.Field{
+				{Name: "test2", Size: 8, Align: 8},
+				{Name: "test3", Size: 4, Align: 4, Tag: `gopium:"isolate"`},
+				{Name: "test1", Size: 1, Align: 1},
+			},
+		},
+		"pinned fields should keep their indexes": {
+			fields: []gopium.Field{
+				{Name: "magic", Size: 1, Align: 1, Tag: `gopium:"pin"`},
+				{Name: "test1", Size: 1, Align: 1},
+				{Name: "test2", Size: 8, Align: 8},
+				{Name: "test3", Size: 2, Align: 2, Tag: `json:"test3" gopium:"pin;group:def;memory_pack"`},
+				{Name: "test4", Size: 4, Align: 4},
+			},
+			r: []gopium.Field{
+				{Name: "magic", Size: 1, Align: 1, Tag: `gopium:"pin"`},
+				{Name: "test2", Size: 8, Align: 8},
+				{Name: "test4", Size: 4, Align: 4},
+				{Name: "test3", Size: 2, Align: 2, Tag: `json:"test3" gopium:"pin;group:def;memory_pack"`},
+				{Name: "test1", Size: 1, Align: 1},
+			},
+		},
+		"first last and ordered fields should be placed accordingly": {
+			fields: []gopium.Field{
+				{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"last"`},
+				{Name: "test2", Size: 8, Align: 8},
+				{Name: "test3", Size: 2, Align: 2, Tag: `gopium:"order:1"`},
+				{Name: "test4", Size: 4, Align: 4},
+				{Name: "test5", Size: 1, Align: 1, Tag: `gopium:"first"`},
+				{Name: "test6", Size: 8, Align: 8, Tag: `gopium:"pin"`},
+			},
+			r: []gopium.Field{
+				{Name: "test5", Size: 1, Align: 1, Tag: `gopium:"first"`},
+				{Name: "test3", Size: 2, Align: 2, Tag: `gopium:"order:1"`},
+				{Name: "test2", Size: 8, Align: 8},
+				{Name: "test4", Size: 4, Align: 4},
+				{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"last"`},
+				{Name: "test6", Size: 8, Align: 8, Tag: `gopium:"pin"`},
+			},
+		},
+		"user blank fields should keep their indexes unless hinted while pads are arranged": {
+			fields: []gopium.Field{
+				{Name: "_", Type: "noCopy"},
+				{Name: "test1", Size: 1, Align: 1},
+				{Name: "_", Type: "[8]byte", Size: 8, Align: 1},
+				{Name: "_", Type: "[4]byte", Size: 4, Align: 1, Tag: `gopium:"pad"`},
+				{Name: "test2", Size: 8, Align: 8},
+				{Name: "_", Type: "[0]func()", Align: 8, Tag: `gopium:"last"`},
+				{Name: "test3", Size: 2, Align: 2},
+			},
+			r: []gopium.Field{
+				{Name: "_", Type: "noCopy"},
+				{Name: "test2", Size: 8, Align: 8},
+				{Name: "_", Type: "[8]byte", Size: 8, Align: 1},
+				{Name: "test3", Size: 2, Align: 2},
+				{Name: "_", Type: "[4]byte", Size: 4, Align: 1, Tag: `gopium:"pad"`},
+				{Name: "test1", Size: 1, Align: 1},
+				{Name: "_", Type: "[0]func()", Align: 8, Tag: `gopium:"last"`},
+			},
+		},
+		"ordered field conflicting with pinned field should return error": {
+			fields: []gopium.Field{
+				{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"order:1"`},
+				{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"pin"`},
+			},
+			err: errors.New(`field "test1" order 1 conflicts with field "test2"`),
+		},
+		"out of range ordered field should return error": {
+			fields: []gopium.Field{
+				{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"order:2"`},
+				{Name: "test2", Size: 8, Align: 8},
+			},
+			err: errors.New(`field "test1" order 2 is out of fields range 2`),
+		},
+		"invalid ordered field should return error": {
+			fields: []gopium.Field{
+				{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"order:-1"`},
+			},
+			err: errors.New(`hint "order:-1" of field "test1" can't be parsed, order should be non negative integer`),
+		},
+		"inconsistent hints should return error": {
+			fields: []gopium.Field{
+				{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"first;pin"`},
+			},
+			err: errors.New(`hints of field "test1" are inconsistent, only one of pin, first, last, order is expected`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := reorder(tcase.fields, pck.arrange)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
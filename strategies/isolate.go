@@ -0,0 +1,84 @@
+package strategies
+
+import (
+	"context"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of isolate presets
+var (
+	isolatel1 = isolate{line: 1}
+	isolatel2 = isolate{line: 2}
+	isolatel3 = isolate{line: 3}
+	isolateb  = isolate{}
+)
+
+// isolate defines strategy implementation
+// that isolates structure fields hinted
+// by `gopium:"isolate"` tag token
+// on their own cpu cache lines
+// by adding extra paddings around them
+type isolate struct {
+	curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	line    uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bytes   uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	comment bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [31]byte       `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// Bytes erich isolate strategy with custom bytes
+func (stg isolate) Bytes(bytes uint) isolate {
+	stg.bytes = bytes
+	return stg
+}
+
+// Curator erich isolate strategy with curator instance
+func (stg isolate) Curator(curator gopium.Curator) isolate {
+	stg.curator = curator
+	return stg
+}
+
+// Apply isolate implementation
+func (stg isolate) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// check that struct has fields
+	// and cache line size or bytes are valid
+	if flen, cachel := len(r.Fields), stg.curator.SysCache(stg.line); flen > 0 && (cachel > 0 || stg.bytes > 0) {
+		if stg.line == 0 {
+			cachel = int64(stg.bytes)
+		}
+		// setup resulted fields slice
+		// and current fields offset
+		fields := make([]gopium.Field, 0, flen)
+		var offset int64
+		// go through all fields
+		for _, f := range r.Fields {
+			h, err := hint(f)
+			if err != nil {
+				return o, err
+			}
+			// align current offset to field
+			if f.Align > 0 {
+				offset = collections.Align(offset, f.Align)
+			}
+			// pad isolated field from the top
+			if pad := (cachel - offset%cachel) % cachel; h.isolate && pad > 0 {
+				fields = append(fields, padnote(pad, stg.comment, "isolation guard (%s)", lname(stg.line, stg.bytes)))
+				offset += pad
+			}
+			fields = append(fields, f)
+			offset += f.Size
+			// pad isolated field from the bottom
+			if pad := (cachel - offset%cachel) % cachel; h.isolate && pad > 0 {
+				fields = append(fields, padnote(pad, stg.comment, "isolation guard (%s)", lname(stg.line, stg.bytes)))
+				offset += pad
+			}
+		}
+		// update resulted fields
+		r.Fields = fields
+	}
+	return r, ctx.Err()
+}
@@ -0,0 +1,132 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestIsolate(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		isolate isolate
+		c       gopium.Curator
+		ctx     context.Context
+		o       gopium.Struct
+		r       gopium.Struct
+		err     error
+	}{
+		"empty struct should be applied to empty struct": {
+			isolate: isolatel1,
+			c:       mocks.Maven{SCache: []int64{32}},
+			ctx:     context.Background(),
+		},
+		"non hinted struct should be applied to itself": {
+			isolate: isolatel1,
+			c:       mocks.Maven{SCache: []int64{32}},
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1},
+					{Name: "test2", Size: 8, Align: 8},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1},
+					{Name: "test2", Size: 8, Align: 8},
+				},
+			},
+		},
+		"hinted struct should be applied to expected isolated struct": {
+			isolate: isolatel2,
+			c:       mocks.Maven{SCache: []int64{16, 32}},
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1},
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"isolate"`},
+					{Name: "test3", Size: 4, Align: 4},
+					{Name: "test4", Size: 32, Align: 8, Tag: `gopium:"isolate;pin"`},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1},
+					collections.PadField(24),
+					{Name: "test2", Size: 8, Align: 8, Tag: `gopium:"isolate"`},
+					collections.PadField(24),
+					{Name: "test3", Size: 4, Align: 4},
+					collections.PadField(24),
+					{Name: "test4", Size: 32, Align: 8, Tag: `gopium:"isolate;pin"`},
+				},
+			},
+		},
+		"hinted struct should be applied to expected isolated bytes struct on canceled context": {
+			isolate: isolateb.Bytes(16),
+			c:       mocks.Maven{},
+			ctx:     cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 2, Align: 2},
+					{Name: "test2", Size: 4, Align: 4, Tag: `gopium:"isolate"`},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 2, Align: 2},
+					collections.PadField(12),
+					{Name: "test2", Size: 4, Align: 4, Tag: `gopium:"isolate"`},
+					collections.PadField(12),
+				},
+			},
+			err: context.Canceled,
+		},
+		"invalid hinted struct should be applied to itself with error": {
+			isolate: isolatel1,
+			c:       mocks.Maven{SCache: []int64{32}},
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"isolate;first;last"`},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"isolate;first;last"`},
+				},
+			},
+			err: errors.New(`hints of field "test1" are inconsistent, only one of pin, first, last, order is expected`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			isolate := tcase.isolate.Curator(tcase.c)
+			// exec
+			r, err := isolate.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -19,6 +19,28 @@ func TestNLex(t *testing.T) {
 		r    gopium.Struct
 		err  error
 	}{
+		"hinted struct should be applied to expected struct": {
+			nlex: nlexasc,
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "b", Tag: `gopium:"pin"`},
+					{Name: "d"},
+					{Name: "a", Tag: `gopium:"last"`},
+					{Name: "c"},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "b", Tag: `gopium:"pin"`},
+					{Name: "c"},
+					{Name: "d"},
+					{Name: "a", Tag: `gopium:"last"`},
+				},
+			},
+		},
 		"empty struct should be applied to empty struct": {
 			nlex: nlexasc,
 			ctx:  context.Background(),
@@ -25,15 +25,27 @@ func (stg pack) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, erro
 	// copy original structure to result
 	r := collections.CopyStruct(o)
 	// execute memory sorting
-	sort.SliceStable(r.Fields, func(i, j int) bool {
+	// respecting fields hints
+	fields, err := reorder(r.Fields, stg.arrange)
+	if err != nil {
+		return o, err
+	}
+	r.Fields = fields
+	return r, ctx.Err()
+}
+
+// arrange helps to sort fields
+// to obtain optimal memory utilization
+func (stg pack) arrange(fields []gopium.Field) []gopium.Field {
+	sort.SliceStable(fields, func(i, j int) bool {
 		// first compare aligns of two fields
 		// bigger aligmnet means upper position
-		if r.Fields[i].Align != r.Fields[j].Align {
-			return r.Fields[i].Align > r.Fields[j].Align
+		if fields[i].Align != fields[j].Align {
+			return fields[i].Align > fields[j].Align
 		}
 		// then compare sizes of two fields
 		// bigger size means upper position
-		return r.Fields[i].Size > r.Fields[j].Size
+		return fields[i].Size > fields[j].Size
 	})
-	return r, ctx.Err()
+	return fields
 }
@@ -20,7 +20,8 @@ var (
 type pad struct {
 	curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	sys     bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_       [15]byte       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	comment bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [14]byte       `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 } // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
 
 // Curator erich pad strategy with curator instance
@@ -36,16 +37,16 @@ func (stg pad) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error
 	// prepare fields slice
 	if flen := len(r.Fields); flen > 0 {
 		// set sys align based on sys flag
-		sysaling := stg.curator.SysAlign()
+		sysaling, align := stg.curator.SysAlign(), "system alignment"
 		if !stg.sys {
-			sysaling = 0
+			sysaling, align = 0, "type natural alignment"
 		}
 		// collect all struct fields with pads
 		rfields := make([]gopium.Field, 0, flen)
 		collections.WalkStruct(r, sysaling, func(pad int64, fields ...gopium.Field) {
 			// if pad is vallid append it to fields
 			if pad > 0 {
-				rfields = append(rfields, collections.PadField(pad))
+				rfields = append(rfields, padnote(pad, stg.comment, "explicit padding (%s)", align))
 			}
 			// append field to fields
 			for _, f := range fields {
@@ -2,6 +2,8 @@ package strategies
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/1pkg/gopium/collections"
 	"github.com/1pkg/gopium/gopium"
@@ -9,13 +11,23 @@ import (
 
 // pipe defines strategy implementation
 // that pipes together set of strategies
-// by applying them one after another
+// by applying them one after another,
+// in case of context tracer each stage result
+// is traced along with stage duration
 type pipe []gopium.Strategy
 
 // Apply pipe implementation
 func (stgs pipe) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
 	// copy original structure to result
 	r := collections.CopyStruct(o)
+	// grab context tracer, inner stages
+	// are applied without tracer
+	// so only top pipe stages are traced
+	t, trace := gopium.Trace(ctx)
+	sctx := ctx
+	if trace {
+		sctx = gopium.WithTracer(ctx, nil)
+	}
 	// go through all inner strategies
 	// and apply them one by one
 	for _, stg := range stgs {
@@ -27,15 +39,43 @@ func (stgs pipe) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, err
 			return o, ctx.Err()
 		default:
 		}
-		tmp, err := stg.Apply(ctx, r)
+		start := time.Now()
+		tmp, err := stg.Apply(sctx, r)
 		// in case of any error
 		// return immediately
 		if err != nil {
 			return r, err
 		}
+		// trace stage result
+		if trace {
+			t.Trace(sname(stg), r, tmp, time.Since(start))
+		}
 		// copy result back to
 		// result structure
 		r = tmp
 	}
 	return r, ctx.Err()
 }
+
+// stage defines strategy implementation
+// that names inner strategy with its
+// registered name, so pipe is able to trace it
+type stage struct {
+	stg  gopium.Strategy     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	name gopium.StrategyName `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
+
+// Apply stage implementation
+func (stg stage) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	return stg.stg.Apply(ctx, o)
+}
+
+// sname helps to find strategy name
+// for pipe tracing, unnamed strategies
+// are named after their types
+func sname(stg gopium.Strategy) gopium.StrategyName {
+	if s, ok := stg.(stage); ok {
+		return s.name
+	}
+	return gopium.StrategyName(fmt.Sprintf("%T", stg))
+}
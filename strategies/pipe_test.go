@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/1pkg/gopium/collections"
 	"github.com/1pkg/gopium/gopium"
 	"github.com/1pkg/gopium/tests/mocks"
 )
@@ -67,6 +68,31 @@ func TestPipe(t *testing.T) {
 			},
 			err: context.Canceled,
 		},
+		"user blank fields should survive pads filter and pack pipe": {
+			pipe: pipe([]gopium.Strategy{fpad, pck}),
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Type: "bool", Size: 1, Align: 1},
+					{Name: "_", Type: "[8]byte", Size: 8, Align: 1},
+					{Name: "test2", Type: "int64", Size: 8, Align: 8},
+					collections.PadField(7),
+					{Name: "_", Type: "golang.org/x/sys/cpu.CacheLinePad", Size: 64, Align: 1},
+					{Name: "test3", Type: "int32", Size: 4, Align: 4},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test2", Type: "int64", Size: 8, Align: 8},
+					{Name: "_", Type: "[8]byte", Size: 8, Align: 1},
+					{Name: "test3", Type: "int32", Size: 4, Align: 4},
+					{Name: "_", Type: "golang.org/x/sys/cpu.CacheLinePad", Size: 64, Align: 1},
+					{Name: "test1", Type: "bool", Size: 1, Align: 1},
+				},
+			},
+		},
 		"non empty struct should be applied accordingly to pipe": {
 			pipe: pipe([]gopium.Strategy{fnotecom, fnotedoc}),
 			ctx:  context.Background(),
@@ -157,3 +183,102 @@ func TestPipe(t *testing.T) {
 		})
 	}
 }
+
+func TestPipeTrace(t *testing.T) {
+	// prepare
+	o := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name: "test",
+				Type: "test",
+			},
+		},
+	}
+	com := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name:    "test",
+				Type:    "test",
+				Comment: []string{"// field size: 0 bytes; field align: 0 bytes; - 🌺 gopium @1pkg"},
+			},
+		},
+	}
+	doc := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name:    "test",
+				Type:    "test",
+				Doc:     []string{"// field size: 0 bytes; field align: 0 bytes; - 🌺 gopium @1pkg"},
+				Comment: []string{"// field size: 0 bytes; field align: 0 bytes; - 🌺 gopium @1pkg"},
+			},
+		},
+	}
+	table := map[string]struct {
+		pipe pipe
+		r    gopium.Struct
+		ss   collections.Stages
+		err  error
+	}{
+		"empty pipe should trace nothing": {
+			r: o,
+		},
+		"named and unnamed stages should be traced accordingly": {
+			pipe: pipe([]gopium.Strategy{
+				stage{stg: fnotecom, name: "test-com"},
+				fnotedoc,
+			}),
+			r: doc,
+			ss: collections.Stages{
+				{Struct: "test", Loc: "test-loc", Strategy: "test-com", Result: com},
+				{Struct: "test", Loc: "test-loc", Strategy: "strategies.note", Result: doc},
+			},
+		},
+		"nested pipe stages should be traced as single stage": {
+			pipe: pipe([]gopium.Strategy{
+				stage{stg: pipe([]gopium.Strategy{fnotecom, fnotedoc}), name: "test-nested"},
+			}),
+			r: doc,
+			ss: collections.Stages{
+				{Struct: "test", Loc: "test-loc", Strategy: "test-nested", Result: doc},
+			},
+		},
+		"stages before pipe error should be traced": {
+			pipe: pipe([]gopium.Strategy{
+				stage{stg: fnotecom, name: "test-com"},
+				stage{stg: &mocks.Strategy{Err: errors.New("test error")}, name: "test-err"},
+			}),
+			r: com,
+			ss: collections.Stages{
+				{Struct: "test", Loc: "test-loc", Strategy: "test-com", Result: com},
+			},
+			err: errors.New("test error"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			tr := collections.NewTracer("test", "test-loc")
+			ctx := gopium.WithTracer(context.Background(), tr)
+			// exec
+			r, err := tcase.pipe.Apply(ctx, o)
+			// skip stages durations
+			ss := tr.Stages()
+			for i := range ss {
+				ss[i].Duration = 0
+			}
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(ss, tcase.ss) {
+				t.Errorf("actual %v doesn't equal to expected %v", ss, tcase.ss)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -32,7 +32,8 @@ type sep struct {
 	bytes   uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	sys     bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	top     bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_       [30]byte       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	comment bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [29]byte       `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 } // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
 
 // Bytes erich sep strategy with custom bytes
@@ -62,12 +63,18 @@ func (stg sep) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error
 		if !stg.sys && stg.line == 0 {
 			sep = int64(stg.bytes)
 		}
+		// prepare separator pad
+		size := lname(stg.line, stg.bytes)
+		if stg.sys {
+			size = "system alignment"
+		}
+		pad := padnote(sep, stg.comment, "separate padding (%s)", size)
 		// add field before or after
 		// structure fields list
 		if stg.top {
-			r.Fields = append([]gopium.Field{collections.PadField(sep)}, r.Fields...)
+			r.Fields = append([]gopium.Field{pad}, r.Fields...)
 		} else {
-			r.Fields = append(r.Fields, collections.PadField(sep))
+			r.Fields = append(r.Fields, pad)
 		}
 	}
 	return r, ctx.Err()
@@ -0,0 +1,154 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"go/types"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of style presets
+var (
+	stylebytes = style{}
+	styleu64   = style{u64: true}
+	stylecpu   = style{name: "golang.org/x/sys/cpu.CacheLinePad", line: 1, archs: cpupads}
+)
+
+// cpupads defines `cpu.CacheLinePad` sizes
+// for all architectures supported by `golang.org/x/sys/cpu`
+var cpupads = map[string]int64{
+	"386":      64,
+	"amd64":    64,
+	"amd64p32": 64,
+	"arm":      32,
+	"arm64":    128,
+	"loong64":  64,
+	"mips":     32,
+	"mipsle":   32,
+	"mips64":   32,
+	"mips64le": 32,
+	"ppc64":    128,
+	"ppc64le":  128,
+	"riscv64":  64,
+	"s390x":    256,
+	"wasm":     64,
+}
+
+// style defines strategy implementation
+// that changes structure pads representation
+// to either plain bytes array `_ [N]byte`,
+// uint64 array `_ [N/8]uint64` that keeps uint64 alignment
+// or named pad type `_ cpu.CacheLinePad` for pads
+// that have the same size as the pad type,
+// named pad types with known architectures sizes
+// are used only if target architecture size is known
+// and matches the pad size, pads that can't be restyled
+// without struct layout change are kept as plain bytes arrays
+type style struct {
+	curator gopium.Curator   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	name    string           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	archs   map[string]int64 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	line    uint             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bytes   uint             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	align   uint             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	u64     bool             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [63]byte         `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
+
+// Bytes erich style strategy with custom bytes
+func (stg style) Bytes(bytes uint) style {
+	stg.bytes = bytes
+	return stg
+}
+
+// Curator erich style strategy with curator instance
+func (stg style) Curator(curator gopium.Curator) style {
+	stg.curator = curator
+	return stg
+}
+
+// Apply style implementation
+func (stg style) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// get named pad type size and align
+	var size, align int64 = int64(stg.bytes), int64(stg.align)
+	if stg.line > 0 {
+		size = stg.curator.SysCache(stg.line)
+	}
+	if align == 0 {
+		align = 1
+	}
+	// named pad type with known architectures sizes
+	// is used only if it matches target architecture
+	name := stg.name
+	if stg.archs != nil {
+		if asize, ok := stg.archs[stg.curator.SysArch()]; !ok || asize != size {
+			name = ""
+		}
+	}
+	// get target uint64 alignment if curator
+	// exposes types sizes, it's 4 bytes on 386 or arm
+	var ualign int64 = 8
+	if exp, ok := stg.curator.(gopium.Exposer); ok {
+		if a := exp.Align(types.Typ[types.Uint64]); a > 0 {
+			ualign = a
+		}
+	}
+	// go through all fields
+	// and restyle all pads
+	var offset int64
+	for i, f := range r.Fields {
+		// align current offset to field
+		if f.Align > 0 {
+			offset = collections.Align(offset, f.Align)
+		}
+		// skip non pad fields
+		if !collections.IsPad(f) {
+			offset += f.Size
+			continue
+		}
+		// start with plain bytes pad
+		bp := collections.CopyField(f)
+		bp.Type, bp.Align = collections.PadField(f.Size).Type, 1
+		p := collections.CopyField(bp)
+		switch {
+		case stg.u64 && f.Size > 0 && f.Size%8 == 0 && offset%ualign == 0:
+			p.Type, p.Align = fmt.Sprintf("[%d]uint64", f.Size/8), ualign
+		case name != "" && f.Size > 0 && f.Size == size && offset%align == 0:
+			p.Type, p.Align = name, align
+		}
+		// restyled pad shouldn't change struct size
+		// otherwise fallback to plain bytes pad
+		r.Fields[i] = bp
+		bsize, _ := collections.SizeAlign(r)
+		r.Fields[i] = p
+		if psize, _ := collections.SizeAlign(r); psize != bsize {
+			r.Fields[i] = bp
+		}
+		offset += f.Size
+	}
+	return r, ctx.Err()
+}
+
+// padnote helps to create pad field
+// with optional explanatory comment
+func padnote(pad int64, comment bool, format string, args ...interface{}) gopium.Field {
+	f := collections.PadField(pad)
+	if comment {
+		note := fmt.Sprintf("// %s; - %s", fmt.Sprintf(format, args...), gopium.STAMP)
+		f.Comment = append(f.Comment, note)
+	}
+	return f
+}
+
+// lname helps to describe
+// cache line or bytes size
+func lname(line uint, bytes uint) string {
+	if line == 0 {
+		return fmt.Sprintf("%d bytes", bytes)
+	}
+	return fmt.Sprintf("L%d", line)
+}
@@ -0,0 +1,252 @@
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestStyle(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		style style
+		c     gopium.Curator
+		ctx   context.Context
+		o     gopium.Struct
+		r     gopium.Struct
+		err   error
+	}{
+		"empty struct should be applied to empty struct": {
+			style: styleu64,
+			c:     mocks.Maven{SCache: []int64{64}},
+			ctx:   context.Background(),
+		},
+		"non empty struct should be applied to expected uint64 pads struct": {
+			style: styleu64,
+			c:     mocks.Maven{SCache: []int64{64}},
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 8, Align: 8},
+					collections.PadField(16),
+					{Name: "test2", Size: 1, Align: 1},
+					collections.PadField(7),
+					{Name: "_", Type: "noCopy"},
+					{Name: "_", Type: "[8]byte", Size: 8, Align: 1},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 8, Align: 8},
+					{Name: "_", Type: "[2]uint64", Size: 16, Align: 8, Tag: `gopium:"pad"`},
+					{Name: "test2", Size: 1, Align: 1},
+					collections.PadField(7),
+					{Name: "_", Type: "noCopy"},
+					{Name: "_", Type: "[8]byte", Size: 8, Align: 1},
+				},
+			},
+		},
+		"non empty struct should be applied to expected bytes pads struct on layout change": {
+			style: styleu64,
+			c:     mocks.Maven{SCache: []int64{64}},
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					collections.PadField(8),
+					{Name: "test1", Size: 1, Align: 1},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					collections.PadField(8),
+					{Name: "test1", Size: 1, Align: 1},
+				},
+			},
+		},
+		"non empty struct should be applied to expected cache line pads struct": {
+			style: stylecpu,
+			c:     mocks.Maven{SCache: []int64{32}, SArch: "arm"},
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 8, Align: 8},
+					collections.PadField(24),
+					{
+						Name:    "_",
+						Type:    "[4]uint64",
+						Size:    32,
+						Align:   8,
+						Tag:     `gopium:"pad"`,
+						Comment: []string{"// false sharing guard (L1); - 🌺 gopium @1pkg"},
+					},
+					{Name: "test2", Size: 8, Align: 8},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 8, Align: 8},
+					collections.PadField(24),
+					{
+						Name:    "_",
+						Type:    "golang.org/x/sys/cpu.CacheLinePad",
+						Size:    32,
+						Align:   1,
+						Tag:     `gopium:"pad"`,
+						Comment: []string{"// false sharing guard (L1); - 🌺 gopium @1pkg"},
+					},
+					{Name: "test2", Size: 8, Align: 8},
+				},
+			},
+		},
+		"non empty struct should be applied to expected bytes pads struct on arch cache line pad size mismatch": {
+			style: stylecpu,
+			c:     mocks.Maven{SCache: []int64{32}, SArch: "amd64"},
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 8, Align: 8},
+					collections.PadField(24),
+					collections.PadField(32),
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 8, Align: 8},
+					collections.PadField(24),
+					collections.PadField(32),
+				},
+			},
+		},
+		"non empty struct should be applied to expected bytes pads struct on unknown arch": {
+			style: stylecpu,
+			c:     mocks.Maven{SCache: []int64{64}, SArch: "test"},
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 64, Align: 8},
+					collections.PadField(64),
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 64, Align: 8},
+					collections.PadField(64),
+				},
+			},
+		},
+		"non empty struct should be applied to expected uint64 pads struct with arch uint64 alignment": {
+			style: styleu64,
+			c:     mocks.Maven{SCache: []int64{64}, SArch: "386", Types: map[string]mocks.Type{"uint64": {Size: 8, Align: 4}}},
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 4, Align: 4},
+					collections.PadField(8),
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 4, Align: 4},
+					{Name: "_", Type: "[1]uint64", Size: 8, Align: 4, Tag: `gopium:"pad"`},
+				},
+			},
+		},
+		"non empty struct should be applied to expected user pads struct": {
+			style: style{name: "linePad", align: 8}.Bytes(16),
+			c:     mocks.Maven{SCache: []int64{32}},
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 4, Align: 4},
+					collections.PadField(16),
+					{Name: "test2", Size: 8, Align: 8},
+					collections.PadField(16),
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 4, Align: 4},
+					collections.PadField(16),
+					{Name: "test2", Size: 8, Align: 8},
+					{Name: "_", Type: "linePad", Size: 16, Align: 8, Tag: `gopium:"pad"`},
+				},
+			},
+		},
+		"non empty struct should be applied to expected bytes pads struct": {
+			style: stylebytes,
+			c:     mocks.Maven{SCache: []int64{64}},
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 8, Align: 8},
+					{Name: "_", Type: "[2]uint64", Size: 16, Align: 8, Tag: `gopium:"pad"`},
+					{Name: "_", Type: "golang.org/x/sys/cpu.CacheLinePad", Size: 64, Align: 1, Tag: `gopium:"pad"`},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 8, Align: 8},
+					collections.PadField(16),
+					collections.PadField(64),
+				},
+			},
+		},
+		"non empty struct should be applied to expected struct on canceled context": {
+			style: styleu64,
+			c:     mocks.Maven{SCache: []int64{64}},
+			ctx:   cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 8, Align: 8},
+					collections.PadField(8),
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 8, Align: 8},
+					{Name: "_", Type: "[1]uint64", Size: 8, Align: 8, Tag: `gopium:"pad"`},
+				},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			style := tcase.style.Curator(tcase.c)
+			// exec
+			r, err := style.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/1pkg/gopium/collections"
@@ -25,7 +26,7 @@ type tag struct {
 	tag      string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	force    bool     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	discrete bool     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_        [14]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_        [14]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 } // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
 
 // Names erich tag strategy with strategy names tag
@@ -65,10 +66,22 @@ func (stg tag) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error
 		// in case tag is not empty and
 		// gopium tag doesn't exist - append tag
 		// in case tag is empty - set tag
-		fulltag := fmt.Sprintf(`%s:"%s"`, gopium.NAME, gtag)
+		// note: tag value is quoted so expressions
+		// string literals are escaped and
+		// could be read back from the tag
+		fulltag := fmt.Sprintf(`%s:%s`, gopium.NAME, strconv.Quote(gtag))
 		switch {
 		case ok && stg.force:
-			f.Tag = strings.Replace(f.Tag, tag, gtag, 1)
+			// keep budget and hints tokens if any
+			if ktokens := keept(tag); ktokens != "" {
+				gtag = strings.Trim(fmt.Sprintf("%s;%s", ktokens, gtag), ";")
+			}
+			f.Tag = strings.Replace(
+				f.Tag,
+				fmt.Sprintf(`%s:%s`, gopium.NAME, strconv.Quote(tag)),
+				fmt.Sprintf(`%s:%s`, gopium.NAME, strconv.Quote(gtag)),
+				1,
+			)
 		case ok:
 			break
 		case f.Tag != "":
@@ -79,3 +92,15 @@ func (stg tag) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error
 	}
 	return r, ctx.Err()
 }
+
+// keept helps to collect budget and hints
+// tag tokens that are kept on tag overwrite
+func keept(tag string) string {
+	tokens := make([]string, 0, 1)
+	for _, token := range esplit(tag, ';') {
+		if strings.HasPrefix(token, "budget:") || hintt(token) {
+			tokens = append(tokens, token)
+		}
+	}
+	return strings.Join(tokens, ";")
+}
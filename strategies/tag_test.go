@@ -5,7 +5,9 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/1pkg/gopium/collections"
 	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
 )
 
 func TestTag(t *testing.T) {
@@ -132,6 +134,72 @@ func TestTag(t *testing.T) {
 				},
 			},
 		},
+		"non empty struct should be applied to itself with expected tag should be overwritten keeping budget": {
+			tag: tagf.Names(gopium.StrategyName("test")),
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `json:"test" gopium:"budget:size=8;tag"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `json:"test" gopium:"budget:size=8;test"`,
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to itself with expected tag should be overwritten keeping hints": {
+			tag: tagfd.Names(gopium.StrategyName("test")),
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `json:"test" gopium:"pin;isolate;group:def;tag"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `json:"test" gopium:"pin;isolate;group:gopium-1;test"`,
+					},
+				},
+			},
+		},
+		"pad struct should be applied to itself with expected tag should be overwritten keeping pad marker": {
+			tag: tagf.Names(gopium.StrategyName("test")),
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					collections.PadField(8),
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "_",
+						Type:  "[8]byte",
+						Size:  8,
+						Align: 1,
+						Tag:   `gopium:"pad;test"`,
+					},
+				},
+			},
+		},
 		"complex struct should be applied to itself with expected tag on force": {
 			tag: tagf.Names(gopium.StrategyName("test")),
 			ctx: context.Background(),
@@ -287,3 +355,87 @@ func TestTag(t *testing.T) {
 		})
 	}
 }
+
+func TestTagExpressions(t *testing.T) {
+	// prepare
+	b := Builder{Curator: mocks.Maven{}}
+	o := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name: "a",
+				Type: "int64",
+				Size: 8,
+			},
+			{
+				Name: "test",
+				Type: "string",
+				Tag:  `json:"test" gopium:"memory_pack"`,
+			},
+		},
+	}
+	table := map[string]struct {
+		names []gopium.StrategyName
+		tags  []string
+		vals  []string
+	}{
+		"expression tag should be written escaped": {
+			names: []gopium.StrategyName{`filter(name=~"^_$")`, AddTagF},
+			tags: []string{
+				`gopium:"filter(name=~\"^_$\"),add_tag_group_force"`,
+				`json:"test" gopium:"filter(name=~\"^_$\"),add_tag_group_force"`,
+			},
+			vals: []string{
+				`filter(name=~"^_$"),add_tag_group_force`,
+				`filter(name=~"^_$"),add_tag_group_force`,
+			},
+		},
+		"expression tag with escapes should be written escaped": {
+			names: []gopium.StrategyName{`filter(type=~"\\[\\d+\\]byte")`, AddTagF},
+			tags: []string{
+				`gopium:"filter(type=~\"\\\\[\\\\d+\\\\]byte\"),add_tag_group_force"`,
+				`json:"test" gopium:"filter(type=~\"\\\\[\\\\d+\\\\]byte\"),add_tag_group_force"`,
+			},
+			vals: []string{
+				`filter(type=~"\\[\\d+\\]byte"),add_tag_group_force`,
+				`filter(type=~"\\[\\d+\\]byte"),add_tag_group_force`,
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			stg, err := b.Build(tcase.names...)
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to %v", err, nil)
+			}
+			// exec
+			r, err := stg.Apply(context.Background(), o)
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to %v", err, nil)
+			}
+			tags := make([]string, 0, len(r.Fields))
+			vals := make([]string, 0, len(r.Fields))
+			for _, f := range r.Fields {
+				val, _ := reflect.StructTag(f.Tag).Lookup(gopium.NAME)
+				tags = append(tags, f.Tag)
+				vals = append(vals, val)
+			}
+			// check
+			if !reflect.DeepEqual(tags, tcase.tags) {
+				t.Errorf("actual %v doesn't equal to expected %v", tags, tcase.tags)
+			}
+			if !reflect.DeepEqual(vals, tcase.vals) {
+				t.Errorf("actual %v doesn't equal to expected %v", vals, tcase.vals)
+			}
+			// written tags should be processed back
+			stg, err = b.Build(ProcTag)
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to %v", err, nil)
+			}
+			if _, err := stg.Apply(context.Background(), r); !reflect.DeepEqual(err, nil) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+			}
+		})
+	}
+}
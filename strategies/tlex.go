@@ -19,7 +19,7 @@ var (
 // in ascending or descending order
 type tlex struct {
 	asc bool    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_   [1]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_   [1]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 } // struct size: 2 bytes; struct align: 1 bytes; struct aligned size: 2 bytes; - 🌺 gopium @1pkg
 
 // Apply tlex implementation
@@ -27,12 +27,24 @@ func (stg tlex) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, erro
 	// copy original structure to result
 	r := collections.CopyStruct(o)
 	// then execute lexicographical sorting
-	sort.SliceStable(r.Fields, func(i, j int) bool {
+	// respecting fields hints
+	fields, err := reorder(r.Fields, stg.arrange)
+	if err != nil {
+		return o, err
+	}
+	r.Fields = fields
+	return r, ctx.Err()
+}
+
+// arrange helps to sort fields
+// accordingly to their types
+func (stg tlex) arrange(fields []gopium.Field) []gopium.Field {
+	sort.SliceStable(fields, func(i, j int) bool {
 		// sort depends on type of ordering
 		if stg.asc {
-			return r.Fields[i].Type < r.Fields[j].Type
+			return fields[i].Type < fields[j].Type
 		}
-		return r.Fields[i].Type > r.Fields[j].Type
+		return fields[i].Type > fields[j].Type
 	})
-	return r, ctx.Err()
+	return fields
 }
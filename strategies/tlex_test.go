@@ -2,6 +2,7 @@ package strategies
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -19,6 +20,25 @@ func TestTLex(t *testing.T) {
 		r    gopium.Struct
 		err  error
 	}{
+		"invalid hinted struct should be applied to itself with error": {
+			tlex: tlexdesc,
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Type: "a", Tag: `gopium:"order:2"`},
+					{Name: "test2", Type: "b"},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Type: "a", Tag: `gopium:"order:2"`},
+					{Name: "test2", Type: "b"},
+				},
+			},
+			err: errors.New(`field "test1" order 2 is out of fields range 2`),
+		},
 		"empty struct should be applied to empty struct": {
 			tlex: tlexasc,
 			ctx:  context.Background(),
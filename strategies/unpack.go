@@ -24,24 +24,34 @@ type unpack struct{} // struct size: 0 bytes; struct align: 1 bytes; struct alig
 func (stg unpack) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
 	// copy original structure to result
 	r := collections.CopyStruct(o)
-	// execute pack strategy
-	r, err := pck.Apply(ctx, r)
+	// execute memory unpacking
+	// respecting fields hints
+	fields, err := reorder(r.Fields, stg.arrange)
 	if err != nil {
 		return o, err
 	}
+	r.Fields = fields
+	return r, ctx.Err()
+}
+
+// arrange helps to sort fields
+// to obtain inflated memory utilization
+func (stg unpack) arrange(fields []gopium.Field) []gopium.Field {
+	// execute pack sorting first
+	fields = pck.arrange(fields)
 	// check that struct has fields
-	if flen := len(r.Fields); flen > 0 {
+	if flen := len(fields); flen > 0 {
 		// slice fields by half ceil
 		mid := int(math.Ceil(float64(flen) / 2.0))
-		left, right := r.Fields[:mid], r.Fields[mid:]
-		r.Fields = make([]gopium.Field, 0, flen)
+		left, right := fields[:mid], fields[mid:]
+		fields = make([]gopium.Field, 0, flen)
 		// combine fields in chess order
 		for li, ri := 0, len(right)-1; li < mid; li, ri = li+1, ri-1 {
 			if ri >= 0 {
-				r.Fields = append(r.Fields, right[ri])
+				fields = append(fields, right[ri])
 			}
-			r.Fields = append(r.Fields, left[li])
+			fields = append(fields, left[li])
 		}
 	}
-	return r, ctx.Err()
+	return fields
 }
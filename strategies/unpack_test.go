@@ -21,6 +21,29 @@ func TestUnpack(t *testing.T) {
 		"empty struct should be applied to empty struct": {
 			ctx: context.Background(),
 		},
+		"hinted struct should be applied to expected struct": {
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"first"`},
+					{Name: "test2", Size: 8, Align: 8},
+					{Name: "test3", Size: 4, Align: 4},
+					{Name: "test4", Size: 2, Align: 2},
+					{Name: "test5", Size: 1, Align: 1, Tag: `gopium:"pin"`},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "test1", Size: 1, Align: 1, Tag: `gopium:"first"`},
+					{Name: "test4", Size: 2, Align: 2},
+					{Name: "test2", Size: 8, Align: 8},
+					{Name: "test3", Size: 4, Align: 4},
+					{Name: "test5", Size: 1, Align: 1, Tag: `gopium:"pin"`},
+				},
+			},
+		},
 		"non empty struct should be applied to itself": {
 			ctx: context.Background(),
 			o: gopium.Struct{
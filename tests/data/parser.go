@@ -62,24 +62,19 @@ func (p Parser) ParseTypes(ctx context.Context, src ...byte) (*types.Package, go
 			return tp.pkg, tp.loc, nil
 		}
 		// if not then do actual parsing
-		// and wrap locator with data locator
 		pkg, loc, err := p.p.ParseTypes(ctx, src...)
 		// store result to cache if no error occurred
 		if err == nil {
-			tcache[dir] = typesloc{pkg: pkg, loc: locator{loc: loc}}
+			tcache[dir] = typesloc{pkg: pkg, loc: loc}
 		}
-		return pkg, locator{loc: loc}, err
+		return pkg, loc, err
 	}
 	// otherwise use real parser
-	// also wrap locator with data locator
-	pkg, loc, err := p.p.ParseTypes(ctx, src...)
-	return pkg, locator{loc: loc}, err
+	return p.p.ParseTypes(ctx, src...)
 }
 
 // ParseAst cache parser implementation
 func (p Parser) ParseAst(ctx context.Context, src ...byte) (*ast.Package, gopium.Locator, error) {
 	// it's cheap to parse ast each time
-	// also wrap locator with data locator
-	pkg, loc, err := p.p.ParseAst(ctx, src...)
-	return pkg, locator{loc: loc}, err
+	return p.p.ParseAst(ctx, src...)
 }
@@ -53,6 +53,47 @@ func (fmt Diff) Diff(o gopium.Categorized, r gopium.Categorized) ([]byte, error)
 	return json.MarshalIndent(data, "", "\t")
 }
 
+// Lock defines mock layouts lock implementation
+type Lock struct {
+	Err error `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; - 🌺 gopium @1pkg
+
+// Lock mock implementation
+func (fmt Lock) Lock(ls collections.Layouts) ([]byte, error) {
+	// in case we have error
+	// return it back
+	if fmt.Err != nil {
+		return nil, fmt.Err
+	}
+	// otherwise use json bytes impl
+	return json.MarshalIndent(ls, "", "\t")
+}
+
+// Unlock mock implementation
+func (fmt Lock) Unlock(buf []byte) (collections.Layouts, error) {
+	// in case we have error
+	// return it back
+	if fmt.Err != nil {
+		return nil, fmt.Err
+	}
+	// otherwise use json bytes impl
+	var ls collections.Layouts
+	err := json.Unmarshal(buf, &ls)
+	return ls, err
+}
+
+// Regress mock implementation
+func (fmt Lock) Regress(o collections.Layouts, r collections.Layouts) ([]byte, error) {
+	// in case we have error
+	// return it back
+	if fmt.Err != nil {
+		return nil, fmt.Err
+	}
+	// otherwise use json bytes impl
+	data := []collections.Layouts{o, r}
+	return json.MarshalIndent(data, "", "\t")
+}
+
 // Apply defines mock apply implementation
 type Apply struct {
 	Err error `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+)
+
+// Reader defines mock reader implementation
+type Reader struct {
+	Buf  []byte   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Oerr error    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_    [24]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// Open mock implementation
+func (r Reader) Open(string) (io.ReadCloser, error) {
+	// in case we have error
+	// return it back
+	if r.Oerr != nil {
+		return nil, r.Oerr
+	}
+	// otherwise use buf reader
+	return ioutil.NopCloser(bytes.NewReader(r.Buf)), nil
+}
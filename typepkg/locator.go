@@ -1,10 +1,14 @@
 package typepkg
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
+	"go/ast"
 	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/1pkg/gopium/gopium"
@@ -14,11 +18,15 @@ import (
 // encapsulate pkgs token.FileSets and provides
 // some operations on top of it
 type Locator struct {
+	insts []*types.Named            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	pkg   string                    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	root  *token.FileSet            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	dirs  map[token.Pos][]string    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	ids   map[token.Pos]string      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	extra map[string]*token.FileSet `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	mutex sync.Mutex                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_     [8]byte                   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
+	_     [48]byte                  `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
 
 // NewLocator creates new locator instance
 // from provided file set
@@ -30,23 +38,222 @@ func NewLocator(fset *token.FileSet) *Locator {
 	}
 	return &Locator{
 		root:  fset,
+		dirs:  make(map[token.Pos][]string),
+		ids:   make(map[token.Pos]string),
 		extra: make(map[string]*token.FileSet),
 	}
 }
 
-// ID calculates sha256 hash hex string
-// for specified token.Pos in token.FileSet
-// note: generated ids are ordered inside same loc
-func (l *Locator) ID(p token.Pos) string {
-	// check if such file exists
-	if f := l.root.File(p); f != nil {
-		// generate hash sum
-		h := sha256.Sum256([]byte(f.Name()))
-		sum := hex.EncodeToString(h[:])
-		// generate ordered id
-		return fmt.Sprintf("%s:%d", sum, f.Line(p))
+// Scan goes through all type decls inside
+// provided ast files and collects gopium
+// directives from their docs by type name pos,
+// file level directives like `//gopium:file-ignore`
+// are collected for all type decls inside the file,
+// it also collects type decls identities
+// built from current package path and
+// returns error on colliding identities,
+// note: it should be called once per package
+// with all package files and before locator
+// is shared as directives aren't synced
+func (l *Locator) Scan(files ...*ast.File) (*Locator, error) {
+	// prepare directive prefix
+	prefix := fmt.Sprintf("//%s:", gopium.NAME)
+	fprefix := fmt.Sprintf("%sfile-", prefix)
+	// package level scope and functions
+	// are shared by all package files
+	// which are scanned in files names order
+	// with test files last, so numbering
+	// doesn't depend on files order or
+	// presence of package test files
+	root := &scope{}
+	funcs := make(map[string]int)
+	ids := make(map[string]token.Pos)
+	var err error
+	for _, file := range l.sorted(files) {
+		// collect all file level directives
+		// from any file comment group
+		var fdirs []string
+		for _, cgroup := range file.Comments {
+			for _, com := range cgroup.List {
+				if strings.HasPrefix(com.Text, fprefix) {
+					fdirs = append(fdirs, strings.TrimSpace(strings.TrimPrefix(com.Text, prefix)))
+				}
+			}
+		}
+		// go through all file nodes
+		// including nested scopes decls
+		// keeping track of scopes path
+		scopes := []*scope{root}
+		nodes := make([]ast.Node, 0)
+		ast.Inspect(file, func(node ast.Node) bool {
+			// stop scan on any error
+			if err != nil {
+				return false
+			}
+			// on node exit pop its scope if any
+			if node == nil {
+				if last := nodes[len(nodes)-1]; scopes[len(scopes)-1].node == last {
+					scopes = scopes[:len(scopes)-1]
+				}
+				nodes = nodes[:len(nodes)-1]
+				return true
+			}
+			nodes = append(nodes, node)
+			top := scopes[len(scopes)-1]
+			switch n := node.(type) {
+			case *ast.FuncDecl:
+				// functions scopes are named by
+				// function or receiver and method names,
+				// init and blank functions are additionally
+				// numbered by their order inside the package
+				name := fname(n)
+				if name == "init" || name == "_" {
+					num := funcs[name]
+					funcs[name]++
+					name = fmt.Sprintf("%s.%d", name, num)
+				}
+				scopes = append(scopes, &scope{node: n, body: n.Body, name: name})
+			case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+				// function bodies share function scopes
+				// and all other blocks are numbered
+				// by their order inside parent scope
+				if top.body == n {
+					break
+				}
+				scopes = append(scopes, &scope{node: n, name: strconv.Itoa(top.blocks)})
+				top.blocks++
+			case *ast.GenDecl:
+				// skip all non type decls
+				if n.Tok != token.TYPE {
+					break
+				}
+				// build scope path of type decls
+				path := make([]string, 0, len(scopes))
+				for _, s := range scopes[1:] {
+					path = append(path, s.name)
+				}
+				for _, spec := range n.Specs {
+					ts := spec.(*ast.TypeSpec)
+					id := fmt.Sprintf("%s:%s", l.pkg, strings.Join(append(path, ts.Name.Name), "."))
+					// identities should never collide
+					// otherwise structs are mixed up
+					if pos, ok := ids[id]; ok {
+						err = fmt.Errorf(
+							"type identity %q at %q collides with type at %q",
+							id,
+							l.root.Position(ts.Name.Pos()),
+							l.root.Position(pos),
+						)
+						return false
+					}
+					ids[id] = ts.Name.Pos()
+					l.ids[ts.Name.Pos()] = id
+					// add all file level directives first
+					if len(fdirs) > 0 {
+						l.dirs[ts.Name.Pos()] = append(l.dirs[ts.Name.Pos()], fdirs...)
+					}
+					// use type spec doc if any
+					// otherwise for non grouped
+					// decls use gen decl doc
+					doc := ts.Doc
+					if doc == nil && !n.Lparen.IsValid() {
+						doc = n.Doc
+					}
+					if doc == nil {
+						continue
+					}
+					// collect all directives from doc
+					// except already collected file level
+					for _, com := range doc.List {
+						if strings.HasPrefix(com.Text, prefix) && !strings.HasPrefix(com.Text, fprefix) {
+							dir := strings.TrimSpace(strings.TrimPrefix(com.Text, prefix))
+							l.dirs[ts.Name.Pos()] = append(l.dirs[ts.Name.Pos()], dir)
+						}
+					}
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
-	return ""
+	return l, nil
+}
+
+// sorted helps to sort package files
+// by their names with test files last
+func (l *Locator) sorted(files []*ast.File) []*ast.File {
+	sorted := make([]*ast.File, len(files))
+	copy(sorted, files)
+	name := func(file *ast.File) (bool, string) {
+		if f := l.root.File(file.Pos()); f != nil {
+			name := filepath.Base(f.Name())
+			return strings.HasSuffix(name, "_test.go"), name
+		}
+		return false, ""
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		itest, iname := name(sorted[i])
+		jtest, jname := name(sorted[j])
+		if itest != jtest {
+			return !itest
+		}
+		return iname < jname
+	})
+	return sorted
+}
+
+// Package sets package path that is
+// used to build identities of type decls
+// scanned afterwards, so imported packages
+// could be scanned with their own package paths
+func (l *Locator) Package(path string) *Locator {
+	l.pkg = path
+	return l
+}
+
+// Info sets generic types instantiations
+// recorded by type checker info, so instantiations
+// used only inside expressions like `f(T[int]{})`
+// are available to locator consumers too
+func (l *Locator) Info(info *types.Info) *Locator {
+	if info == nil {
+		return l
+	}
+	// collect instantiated named types
+	// in their identifiers positions order
+	ids := make([]*ast.Ident, 0, len(info.Instances))
+	for id, inst := range info.Instances {
+		if _, ok := inst.Type.(*types.Named); ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.SliceStable(ids, func(i, j int) bool {
+		return ids[i].Pos() < ids[j].Pos()
+	})
+	l.insts = make([]*types.Named, 0, len(ids))
+	for _, id := range ids {
+		l.insts = append(l.insts, info.Instances[id].Type.(*types.Named))
+	}
+	return l
+}
+
+// Instances returns generic types instantiations
+// recorded by type checker info if any
+func (l *Locator) Instances() []*types.Named {
+	return l.insts
+}
+
+// ID returns stable identity for type decl
+// at specified token.Pos in token.FileSet
+// which consists of package path, scope path
+// and type name `github.com/1pkg/gopium/pkg:F.0.A`,
+// where scope path is made of function name and
+// blocks numbers, file and line aren't part of identity,
+// note: identity is available only for scanned type decls
+func (l *Locator) ID(p token.Pos) string {
+	return l.ids[p]
 }
 
 // Loc returns full filepath
@@ -59,6 +266,12 @@ func (l *Locator) Loc(p token.Pos) string {
 	return ""
 }
 
+// Directives returns list of gopium directives
+// collected for specified token.Pos if any
+func (l *Locator) Directives(p token.Pos) []string {
+	return l.dirs[p]
+}
+
 // Locator returns child locator if any
 func (l *Locator) Locator(loc string) (gopium.Locator, bool) {
 	fset, ok := l.Fset(loc, nil)
@@ -89,3 +302,45 @@ func (l *Locator) Fset(loc string, fset *token.FileSet) (*token.FileSet, bool) {
 func (l *Locator) Root() *token.FileSet {
 	return l.root
 }
+
+// scope defines type decls scope path element
+// with its node, optional function body
+// and number of its children blocks
+type scope struct {
+	node   ast.Node       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	name   string         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	body   *ast.BlockStmt `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	blocks int            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_      [16]byte       `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// fname helps to build function scope name
+// from function name and its receiver type name
+// `T.M` if any
+func fname(fdecl *ast.FuncDecl) string {
+	if fdecl.Recv == nil || len(fdecl.Recv.List) == 0 {
+		return fdecl.Name.Name
+	}
+	// unwrap receiver pointer and
+	// type parameters `*T[K]`
+	expr := fdecl.Recv.List[0].Type
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+			continue
+		case *ast.ParenExpr:
+			expr = e.X
+			continue
+		case *ast.IndexExpr:
+			expr = e.X
+			continue
+		case *ast.IndexListExpr:
+			expr = e.X
+			continue
+		case *ast.Ident:
+			return fmt.Sprintf("%s.%s", e.Name, fdecl.Name.Name)
+		}
+		return fdecl.Name.Name
+	}
+}
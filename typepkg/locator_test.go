@@ -1,6 +1,9 @@
 package typepkg
 
 import (
+	"errors"
+	"go/ast"
+	"go/parser"
 	"go/token"
 	"reflect"
 	"testing"
@@ -22,6 +25,8 @@ func TestNewLocatorRoot(t *testing.T) {
 			loc: &Locator{
 				root:  token.NewFileSet(),
 				extra: make(map[string]*token.FileSet),
+				dirs:  make(map[token.Pos][]string),
+				ids:   make(map[token.Pos]string),
 			},
 		},
 		"non nil fset should return custom locator": {
@@ -30,6 +35,8 @@ func TestNewLocatorRoot(t *testing.T) {
 			loc: &Locator{
 				root:  fset,
 				extra: make(map[string]*token.FileSet),
+				dirs:  make(map[token.Pos][]string),
+				ids:   make(map[token.Pos]string),
 			},
 		},
 	}
@@ -66,49 +73,49 @@ func TestLocatorIDLoc(t *testing.T) {
 		id  string
 		loc string
 	}{
-		"valid token pos 1 should be located in expected file": {
+		"valid token pos 1 should be located in expected file without identity": {
 			pos: token.Pos(1),
-			id:  "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08:1",
+			id:  "",
 			loc: "test",
 		},
-		"valid token pos 11 should be located in expected file": {
+		"valid token pos 11 should be located in expected file without identity": {
 			pos: token.Pos(11),
-			id:  "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08:3",
+			id:  "",
 			loc: "test",
 		},
-		"valid token pos 21 should be located in expected file": {
+		"valid token pos 21 should be located in expected file without identity": {
 			pos: token.Pos(21),
-			id:  "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08:3",
+			id:  "",
 			loc: "test",
 		},
-		"valid token pos 22 should be located in expected file": {
+		"valid token pos 22 should be located in expected file without identity": {
 			pos: token.Pos(22),
-			id:  "7395c92698585f5dff68edce8314a30f534e98707af84f11a725cd62b538b139:1",
+			id:  "",
 			loc: "loc-test-id",
 		},
-		"valid token pos 50 should be located in expected file": {
+		"valid token pos 50 should be located in expected file without identity": {
 			pos: token.Pos(50),
-			id:  "7395c92698585f5dff68edce8314a30f534e98707af84f11a725cd62b538b139:2",
+			id:  "",
 			loc: "loc-test-id",
 		},
-		"valid token pos 52 should be located in expected file": {
+		"valid token pos 52 should be located in expected file without identity": {
 			pos: token.Pos(52),
-			id:  "7395c92698585f5dff68edce8314a30f534e98707af84f11a725cd62b538b139:2",
+			id:  "",
 			loc: "loc-test-id",
 		},
-		"valid token pos 53 should be located in expected file": {
+		"valid token pos 53 should be located in expected file without identity": {
 			pos: token.Pos(53),
-			id:  "e65de074e1a2fc5a98d431c9d737f851e27625eca54b73816d4682805938e454:1",
+			id:  "",
 			loc: "id-test-loc",
 		},
-		"valid token pos 99 should be located in expected file": {
+		"valid token pos 99 should be located in expected file without identity": {
 			pos: token.Pos(99),
-			id:  "e65de074e1a2fc5a98d431c9d737f851e27625eca54b73816d4682805938e454:3",
+			id:  "",
 			loc: "id-test-loc",
 		},
-		"valid token pos 100 should be located in expected file": {
+		"valid token pos 100 should be located in expected file without identity": {
 			pos: token.Pos(100),
-			id:  "e65de074e1a2fc5a98d431c9d737f851e27625eca54b73816d4682805938e454:3",
+			id:  "",
 			loc: "id-test-loc",
 		},
 		"invalid token pos 1000 should return default results": {
@@ -137,6 +144,212 @@ func TestLocatorIDLoc(t *testing.T) {
 	}
 }
 
+func TestLocatorScanID(t *testing.T) {
+	// prepare
+	fset := token.NewFileSet()
+	src := `
+package test
+
+type A struct{}
+
+type (
+	B struct{}
+	C struct{}
+)
+
+func f() {
+	type A struct{}
+	{
+		type A struct{}
+	}
+	if true {
+		type B struct{}
+	} else {
+		type B struct{}
+	}
+	switch {
+	case true:
+		type C struct{}
+	}
+}
+
+func (T) m() {
+	type A struct{}
+}
+
+func (*P[K]) m() {
+	type A struct{}
+}
+
+func init() {
+	type A struct{}
+}
+
+func init() {
+	type A struct{}
+}
+
+func _() {
+	type D struct{}; type E struct{}
+}
+
+var g = func() {
+	type U struct{}
+}
+`
+	nsrc := `
+package test
+
+func init() {
+	type A struct{}
+}
+
+func _() {
+	type D struct{}
+}
+
+var h = func() {
+	type U struct{}
+}
+`
+	dsrc := `
+package test
+
+type A struct{}
+`
+	file, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	nfile, err := parser.ParseFile(fset, "next.go", nsrc, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	tfile, err := parser.ParseFile(fset, "a_test.go", nsrc, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	dfile, err := parser.ParseFile(fset, "dup.go", dsrc, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	poses := make([]token.Pos, 0)
+	for _, file := range []*ast.File{file, nfile, tfile} {
+		ast.Inspect(file, func(node ast.Node) bool {
+			if ts, ok := node.(*ast.TypeSpec); ok {
+				poses = append(poses, ts.Name.Pos())
+			}
+			return true
+		})
+	}
+	table := map[string]struct {
+		pkg   string
+		files []*ast.File
+		ids   []string
+		err   error
+	}{
+		"scanned types should return expected identities": {
+			pkg:   "github.com/1pkg/test",
+			files: []*ast.File{file},
+			ids: []string{
+				"github.com/1pkg/test:A",
+				"github.com/1pkg/test:B",
+				"github.com/1pkg/test:C",
+				"github.com/1pkg/test:f.A",
+				"github.com/1pkg/test:f.0.A",
+				"github.com/1pkg/test:f.1.B",
+				"github.com/1pkg/test:f.2.B",
+				"github.com/1pkg/test:f.3.0.C",
+				"github.com/1pkg/test:T.m.A",
+				"github.com/1pkg/test:P.m.A",
+				"github.com/1pkg/test:init.0.A",
+				"github.com/1pkg/test:init.1.A",
+				"github.com/1pkg/test:_.0.D",
+				"github.com/1pkg/test:_.0.E",
+				"github.com/1pkg/test:0.U",
+				"", "", "", "", "", "",
+			},
+		},
+		"scanned types without package should return expected local identities": {
+			files: []*ast.File{file},
+			ids: []string{
+				":A",
+				":B",
+				":C",
+				":f.A",
+				":f.0.A",
+				":f.1.B",
+				":f.2.B",
+				":f.3.0.C",
+				":T.m.A",
+				":P.m.A",
+				":init.0.A",
+				":init.1.A",
+				":_.0.D",
+				":_.0.E",
+				":0.U",
+				"", "", "", "", "", "",
+			},
+		},
+		"scanned types from several files should return package unique identities in files names order": {
+			pkg:   "github.com/1pkg/test",
+			files: []*ast.File{tfile, file, nfile},
+			ids: []string{
+				"github.com/1pkg/test:A",
+				"github.com/1pkg/test:B",
+				"github.com/1pkg/test:C",
+				"github.com/1pkg/test:f.A",
+				"github.com/1pkg/test:f.0.A",
+				"github.com/1pkg/test:f.1.B",
+				"github.com/1pkg/test:f.2.B",
+				"github.com/1pkg/test:f.3.0.C",
+				"github.com/1pkg/test:T.m.A",
+				"github.com/1pkg/test:P.m.A",
+				"github.com/1pkg/test:init.1.A",
+				"github.com/1pkg/test:init.2.A",
+				"github.com/1pkg/test:_.1.D",
+				"github.com/1pkg/test:_.1.E",
+				"github.com/1pkg/test:1.U",
+				"github.com/1pkg/test:init.0.A",
+				"github.com/1pkg/test:_.0.D",
+				"github.com/1pkg/test:0.U",
+				"github.com/1pkg/test:init.3.A",
+				"github.com/1pkg/test:_.2.D",
+				"github.com/1pkg/test:2.U",
+			},
+		},
+		"scanned types with colliding identities should return expected error": {
+			pkg:   "github.com/1pkg/test",
+			files: []*ast.File{file, dfile},
+			err:   errors.New(`type identity "github.com/1pkg/test:A" at "test.go:4:6" collides with type at "dup.go:4:6"`),
+		},
+		"not scanned types should return empty identities": {
+			pkg: "github.com/1pkg/test",
+			ids: []string{"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", ""},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			loc, err := NewLocator(fset).Package(tcase.pkg).Scan(tcase.files...)
+			// check
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// process checks only on success
+			if tcase.err == nil {
+				ids := make([]string, 0, len(poses))
+				for _, pos := range poses {
+					ids = append(ids, loc.ID(pos))
+				}
+				if !reflect.DeepEqual(ids, tcase.ids) {
+					t.Errorf("actual %v doesn't equal to expected %v", ids, tcase.ids)
+				}
+			}
+		})
+	}
+}
+
 func TestLocatorFset(t *testing.T) {
 	// prepare
 	fset := token.NewFileSet()
@@ -191,3 +404,99 @@ func TestLocatorFset(t *testing.T) {
 		})
 	}
 }
+
+func TestLocatorScanDirectives(t *testing.T) {
+	// prepare
+	fset := token.NewFileSet()
+	src := `
+package test
+
+//gopium:budget size=8
+// A doc
+type A struct{}
+
+// B doc
+type B struct{}
+
+type (
+	//gopium:budget align=4
+	//gopium:ignore
+	C struct{}
+	D struct{}
+)
+
+func f() {
+	//gopium:budget size=16
+	type E struct{}
+}
+`
+	fsrc := `
+//gopium:file-ignore
+
+package test
+
+//gopium:budget size=8
+type F struct{}
+`
+	file, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	ffile, err := parser.ParseFile(fset, "ftest.go", fsrc, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	poses := make(map[string]token.Pos)
+	for _, file := range []*ast.File{file, ffile} {
+		ast.Inspect(file, func(node ast.Node) bool {
+			if ts, ok := node.(*ast.TypeSpec); ok {
+				poses[ts.Name.Name] = ts.Name.Pos()
+			}
+			return true
+		})
+	}
+	locator, err := NewLocator(fset).Scan(file, ffile)
+	if err != nil {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	table := map[string]struct {
+		pos  token.Pos
+		dirs []string
+	}{
+		"type with directive should return expected directives": {
+			pos:  poses["A"],
+			dirs: []string{"budget size=8"},
+		},
+		"type without directive should return no directives": {
+			pos: poses["B"],
+		},
+		"grouped type with directives should return expected directives": {
+			pos:  poses["C"],
+			dirs: []string{"budget align=4", "ignore"},
+		},
+		"grouped type without directive should return no directives": {
+			pos: poses["D"],
+		},
+		"nested type with directive should return expected directives": {
+			pos:  poses["E"],
+			dirs: []string{"budget size=16"},
+		},
+		"type inside ignored file should return expected directives": {
+			pos:  poses["F"],
+			dirs: []string{"file-ignore", "budget size=8"},
+		},
+		"invalid pos should return no directives": {
+			pos: token.NoPos,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			dirs := locator.Directives(tcase.pos)
+			// check
+			if !reflect.DeepEqual(dirs, tcase.dirs) {
+				t.Errorf("actual %v doesn't equal to expected %v", dirs, tcase.dirs)
+			}
+		})
+	}
+}
@@ -9,9 +9,10 @@ import (
 // that uses types.Sizes Sizeof in order to get type info
 type MavenGoTypes struct {
 	sizes  types.Sizes    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	arch   string         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	caches map[uint]int64 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_      [8]byte        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
+	_      [24]byte       `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
 
 // NewMavenGoTypes creates instance of MavenGoTypes
 // and requires compiler and arch for types.Sizes initialization
@@ -26,12 +27,18 @@ func NewMavenGoTypes(compiler, arch string, caches ...int64) (MavenGoTypes, erro
 	if sizes := types.SizesFor(compiler, arch); sizes != nil {
 		return MavenGoTypes{
 			sizes:  sizes,
+			arch:   arch,
 			caches: cm,
 		}, nil
 	}
 	return MavenGoTypes{}, fmt.Errorf("unsuported compiler %q arch %q combination", compiler, arch)
 }
 
+// SysArch MavenGoTypes implementation
+func (m MavenGoTypes) SysArch() string {
+	return m.arch
+}
+
 // SysWord MavenGoTypes implementation
 func (m MavenGoTypes) SysWord() int64 {
 	return m.sizes.(*types.StdSizes).WordSize
@@ -67,3 +74,52 @@ func (m MavenGoTypes) Size(t types.Type) int64 {
 func (m MavenGoTypes) Align(t types.Type) int64 {
 	return m.sizes.Alignof(t)
 }
+
+// Ptr MavenGoTypes implementation
+// note: mimics gc compiler ptrdata calculation
+func (m MavenGoTypes) Ptr(t types.Type) int64 {
+	// pointer word size
+	word := m.sizes.Sizeof(types.Typ[types.UnsafePointer])
+	switch tp := t.Underlying().(type) {
+	case *types.Basic:
+		// only strings and unsafe pointers
+		// basic types contain pointers
+		switch tp.Kind() {
+		case types.String, types.UnsafePointer:
+			return word
+		}
+	case *types.Pointer, *types.Chan, *types.Map, *types.Signature, *types.Slice:
+		// pointer data is placed at the
+		// first word of such types
+		return word
+	case *types.Interface:
+		// both words of interface
+		// are pointers
+		return 2 * word
+	case *types.Array:
+		// pointer data of array ends
+		// inside of its last element
+		n := tp.Len()
+		if n == 0 {
+			return 0
+		}
+		if ptr := m.Ptr(tp.Elem()); ptr > 0 {
+			return (n-1)*m.Size(tp.Elem()) + ptr
+		}
+	case *types.Struct:
+		// pointer data of struct ends
+		// inside of its last pointer field
+		n := tp.NumFields()
+		fields := make([]*types.Var, 0, n)
+		for i := 0; i < n; i++ {
+			fields = append(fields, tp.Field(i))
+		}
+		offsets := m.sizes.Offsetsof(fields)
+		for i := n - 1; i >= 0; i-- {
+			if ptr := m.Ptr(fields[i].Type()); ptr > 0 {
+				return offsets[i] + ptr
+			}
+		}
+	}
+	return 0
+}
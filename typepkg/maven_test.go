@@ -37,6 +37,7 @@ func TestNewMavenGoTypes(t *testing.T) {
 			caches:   []int64{2, 4, 8, 16, 32},
 			maven: MavenGoTypes{
 				sizes: types.SizesFor("gc", "amd64"),
+				arch:  "amd64",
 				caches: map[uint]int64{
 					1: 2,
 					2: 4,
@@ -70,12 +71,14 @@ func TestMavenGoTypesCurator(t *testing.T) {
 	}
 	table := map[string]struct {
 		maven  gopium.Maven
+		arch   string
 		word   int64
 		align  int64
 		caches []int64
 	}{
 		"gc/amd64 maven should return expected results": {
 			maven:  maven,
+			arch:   "amd64",
 			word:   8,
 			align:  8,
 			caches: []int64{64, 2, 4, 8, 16, 32, 64, 64, 64},
@@ -84,6 +87,7 @@ func TestMavenGoTypesCurator(t *testing.T) {
 	for name, tcase := range table {
 		t.Run(name, func(t *testing.T) {
 			// exec
+			arch := maven.SysArch()
 			word := maven.SysWord()
 			align := maven.SysAlign()
 			caches := make([]int64, len(tcase.caches))
@@ -91,6 +95,9 @@ func TestMavenGoTypesCurator(t *testing.T) {
 				caches[i] = maven.SysCache(uint(i))
 			}
 			// check
+			if !reflect.DeepEqual(arch, tcase.arch) {
+				t.Errorf("actual %v doesn't equal to %v", arch, tcase.arch)
+			}
 			if !reflect.DeepEqual(word, tcase.word) {
 				t.Errorf("actual %v doesn't equal to %v", word, tcase.word)
 			}
@@ -115,30 +122,49 @@ func TestMavenGoTypesExposer(t *testing.T) {
 		name  string
 		size  int64
 		align int64
+		ptr   int64
 	}{
 		"int64 type should return expected resultss": {
 			tp:    types.Typ[types.Int64],
 			name:  "int64",
 			size:  8,
 			align: 8,
+			ptr:   0,
 		},
 		"string type should return expected results": {
 			tp:    types.Typ[types.String],
 			name:  "string",
 			size:  16,
 			align: 8,
+			ptr:   8,
 		},
 		"string slice type should return expected results": {
 			tp:    types.NewSlice(types.Typ[types.String]),
 			name:  "[]string",
 			size:  24,
 			align: 8,
+			ptr:   8,
 		},
 		"float32 arr type should return expected results": {
 			tp:    types.NewArray(types.Typ[types.Float32], 8),
 			name:  "[8]float32",
 			size:  32,
 			align: 4,
+			ptr:   0,
+		},
+		"string arr type should return expected results": {
+			tp:    types.NewArray(types.Typ[types.String], 2),
+			name:  "[2]string",
+			size:  32,
+			align: 8,
+			ptr:   24,
+		},
+		"interface type should return expected results": {
+			tp:    types.NewInterfaceType(nil, nil),
+			name:  "interface{}",
+			size:  16,
+			align: 8,
+			ptr:   16,
 		},
 		"struct type should return expected results": {
 			tp: types.NewStruct(
@@ -153,6 +179,7 @@ func TestMavenGoTypesExposer(t *testing.T) {
 			name:  "struct{a int64; b []int64; c complex128; d [16]uint8}",
 			size:  64,
 			align: 8,
+			ptr:   16,
 		},
 	}
 	for name, tcase := range table {
@@ -161,6 +188,7 @@ func TestMavenGoTypesExposer(t *testing.T) {
 			name := maven.Name(tcase.tp)
 			size := maven.Size(tcase.tp)
 			align := maven.Align(tcase.tp)
+			ptr := maven.Ptr(tcase.tp)
 			// check
 			if !reflect.DeepEqual(name, tcase.name) {
 				t.Errorf("actual %v doesn't equal to %v", name, tcase.name)
@@ -171,6 +199,9 @@ func TestMavenGoTypesExposer(t *testing.T) {
 			if !reflect.DeepEqual(align, tcase.align) {
 				t.Errorf("actual %v doesn't equal to %v", align, tcase.align)
 			}
+			if !reflect.DeepEqual(ptr, tcase.ptr) {
+				t.Errorf("actual %v doesn't equal to %v", ptr, tcase.ptr)
+			}
 		})
 	}
 
@@ -9,6 +9,7 @@ import (
 	"go/types"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/1pkg/gopium/gopium"
@@ -16,6 +17,25 @@ import (
 	"golang.org/x/tools/go/packages"
 )
 
+// TestVariant defines package test variant
+// that selects which package variants
+// are loaded and analyzed by parser
+type TestVariant string
+
+// list of supported package test variants
+const (
+	// plain package without any test files
+	TestNone TestVariant = "none"
+	// package augmented with internal test files,
+	// zero test variant is treated as internal
+	TestInternal TestVariant = "internal"
+	// plain package and external test package
+	TestExternal TestVariant = "external"
+	// package augmented with internal test files
+	// and external test package
+	TestAll TestVariant = "all"
+)
+
 // ParserXToolPackagesAst defines
 // gopium parser default implementation
 // that uses "golang.org/x/tools/go/packages"
@@ -30,9 +50,9 @@ type ParserXToolPackagesAst struct {
 	Pattern    string            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	Path       string            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	Root       string            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Tests      TestVariant       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	ModeTypes  packages.LoadMode `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	ModeAst    parser.Mode       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_          [16]byte          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 } // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
 
 // ParseTypes ParserXToolPackagesAst implementation
@@ -55,7 +75,7 @@ func (p *ParserXToolPackagesAst) ParseTypes(ctx context.Context, _ ...byte) (*ty
 		Mode:       p.ModeTypes,
 		Env:        p.BuildEnv,
 		BuildFlags: p.BuildFlags,
-		Tests:      true,
+		Tests:      p.Tests != TestNone,
 	}
 	// use packages.Load
 	pkgs, err := packages.Load(cfg, "")
@@ -72,22 +92,18 @@ func (p *ParserXToolPackagesAst) ParseTypes(ctx context.Context, _ ...byte) (*ty
 		// fix package name for windows
 		path = strings.ReplaceAll(path, string(os.PathSeparator), "/")
 	}
-	// check parse results
-	// it should be equal to
-	// package pattern or
-	// relative path
-	// note: len of pkgs should be equal to
-	// - either 1 (pkg contains no tests)
-	// - or 3 (pkg contains tests)
-	// see go packages config test description
-	if plen := len(pkgs); plen >= 1 &&
-		(pkgs[0].String() == p.Pattern || strings.HasPrefix(pkgs[0].String(), path)) {
-		switch plen {
-		case 1:
-			return pkgs[0].Types, NewLocator(fset), nil
-		default:
-			return pkgs[1].Types, NewLocator(fset), nil
+	// select package variants
+	// accordingly to test variant
+	// and check that first variant
+	// is equal to package pattern
+	// or relative path
+	if vpkgs := variants(pkgs, p.Tests); len(vpkgs) >= 1 &&
+		(vpkgs[0].PkgPath == p.Pattern || strings.HasPrefix(vpkgs[0].PkgPath, path)) {
+		loc, err := NewLocator(fset).Package(vpkgs[0].PkgPath).Scan(vpkgs[0].Syntax...)
+		if err != nil {
+			return nil, nil, err
 		}
+		return vpkgs[0].Types, loc.Info(vpkgs[0].TypesInfo), nil
 	}
 	return nil, nil, fmt.Errorf("package %q wasn't found at %q", p.Pattern, dir)
 }
@@ -118,12 +134,16 @@ func (p *ParserXToolPackagesAst) ParseAst(ctx context.Context, src ...byte) (*as
 		if err != nil {
 			return nil, nil, err
 		}
+		loc, err := NewLocator(fset).Scan(file)
+		if err != nil {
+			return nil, nil, err
+		}
 		return &ast.Package{
 			Name: "pkg",
 			Files: map[string]*ast.File{
 				"file": file,
 			},
-		}, NewLocator(fset), err
+		}, loc, nil
 	}
 	// otherwise use parser parse dir
 	dir := filepath.Join(p.Root, p.Path)
@@ -143,11 +163,214 @@ func (p *ParserXToolPackagesAst) ParseAst(ctx context.Context, src ...byte) (*as
 	// last component of path
 	// note: len of pkgs should aways be equal to 1
 	if pkg, ok := pkgs[p.Pattern]; len(pkgs) >= 1 && ok {
-		return pkg, NewLocator(fset), nil
+		return scan(NewLocator(fset), pkg)
 	}
 	pkg := filepath.Base(p.Path)
 	if pkg, ok := pkgs[pkg]; len(pkgs) >= 1 && ok {
-		return pkg, NewLocator(fset), nil
+		return scan(NewLocator(fset), pkg)
 	}
 	return nil, nil, fmt.Errorf("package %q wasn't found at %q", p.Pattern, dir)
 }
+
+// Locate helps to locate package the same way
+// go command does it, by finding enclosing go.mod
+// or go.work from parser root and path dir and
+// honoring build envs and flags like -mod=vendor,
+// package pattern could be either import path
+// or relative dir, it returns package import path and dir
+func (p *ParserXToolPackagesAst) Locate(ctx context.Context) (string, string, error) {
+	// manage context actions
+	// in case of cancelation
+	// stop parse and return error back
+	select {
+	case <-ctx.Done():
+		return "", "", ctx.Err()
+	default:
+	}
+	// create packages.Config obj
+	// that only lists package files
+	dir := filepath.Join(p.Root, p.Path)
+	cfg := &packages.Config{
+		Context:    ctx,
+		Dir:        dir,
+		Mode:       packages.NeedName | packages.NeedFiles,
+		Env:        p.BuildEnv,
+		BuildFlags: p.BuildFlags,
+	}
+	// use packages.Load
+	pkgs, err := packages.Load(cfg, p.Pattern)
+	// on any error just propagate it
+	if err != nil {
+		return "", "", err
+	}
+	// take dir of first package file
+	// or return first package error
+	for _, pkg := range pkgs {
+		if len(pkg.GoFiles) > 0 {
+			return pkg.PkgPath, filepath.Dir(pkg.GoFiles[0]), nil
+		}
+		if len(pkg.Errors) > 0 {
+			return "", "", pkg.Errors[0]
+		}
+	}
+	return "", "", fmt.Errorf("package %q wasn't found at %q", p.Pattern, dir)
+}
+
+// Packages helps to load all packages matched by
+// provided patterns in single packages.Load call
+// and split them to single package parsers
+// per each selected package test variant
+// sorted by packages paths, packages are loaded
+// relatively to parser root and path dir
+func (p *ParserXToolPackagesAst) Packages(ctx context.Context, patterns ...string) ([]*ParserXToolPackage, error) {
+	// manage context actions
+	// in case of cancelation
+	// stop parse and return error back
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	// create packages.Config obj
+	fset := token.NewFileSet()
+	dir := filepath.Join(p.Root, p.Path)
+	cfg := &packages.Config{
+		Fset:       fset,
+		Context:    ctx,
+		Dir:        dir,
+		Mode:       p.ModeTypes,
+		Env:        p.BuildEnv,
+		BuildFlags: p.BuildFlags,
+		Tests:      p.Tests != TestNone,
+	}
+	// use packages.Load
+	pkgs, err := packages.Load(cfg, patterns...)
+	// on any error just propagate it
+	if err != nil {
+		return nil, err
+	}
+	// select package variants
+	// accordingly to test variant
+	pkgs = variants(pkgs, p.Tests)
+	parsers := make([]*ParserXToolPackage, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		// skip packages without go files
+		// which are produced by
+		// patterns that match nothing
+		if len(pkg.GoFiles) == 0 {
+			continue
+		}
+		// set up ast parser
+		// on package dir
+		ap := *p
+		ap.Pattern = pkg.Name
+		ap.Root = ""
+		ap.Path = filepath.Dir(pkg.GoFiles[0])
+		parsers = append(parsers, &ParserXToolPackage{Package: pkg, Ast: &ap})
+	}
+	if len(parsers) == 0 {
+		return nil, fmt.Errorf("packages %q weren't found at %q", strings.Join(patterns, ","), dir)
+	}
+	sort.SliceStable(parsers, func(i, j int) bool {
+		return parsers[i].Package.PkgPath < parsers[j].Package.PkgPath
+	})
+	return parsers, nil
+}
+
+// variants helps to select package variants
+// from packages loaded with tests accordingly
+// to test variant, plain packages are replaced
+// with their internal test variants if any,
+// so each struct is presented only in single variant
+func variants(pkgs []*packages.Package, tests TestVariant) []*packages.Package {
+	// collect internal test variants
+	// by their packages paths
+	internals := make(map[string]*packages.Package)
+	for _, pkg := range pkgs {
+		if pkg.ID != pkg.PkgPath && !strings.HasSuffix(pkg.PkgPath, "_test") {
+			internals[pkg.PkgPath] = pkg
+		}
+	}
+	vpkgs := make([]*packages.Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		switch {
+		case strings.HasSuffix(pkg.PkgPath, ".test"):
+			// skip generated test main packages
+		case strings.HasSuffix(pkg.PkgPath, "_test"):
+			// select external test packages
+			// only for external and all variants
+			if tests == TestExternal || tests == TestAll {
+				vpkgs = append(vpkgs, pkg)
+			}
+		case pkg.ID == pkg.PkgPath:
+			// replace plain packages
+			// with internal test variants
+			// unless tests are excluded
+			if ipkg, ok := internals[pkg.PkgPath]; ok && tests != TestNone && tests != TestExternal {
+				pkg = ipkg
+			}
+			vpkgs = append(vpkgs, pkg)
+		}
+	}
+	return vpkgs
+}
+
+// ParserXToolPackage defines
+// gopium parser implementation
+// for single package preloaded by
+// ParserXToolPackagesAst Packages,
+// that reuses loaded package types
+// and delegates ast parsing to ast parser,
+// type decls of provided imported packages
+// are located as well with their identities
+type ParserXToolPackage struct {
+	Imports []*packages.Package     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Package *packages.Package       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Ast     *ParserXToolPackagesAst `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [24]byte                `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// ParseTypes ParserXToolPackage implementation
+func (p ParserXToolPackage) ParseTypes(ctx context.Context, _ ...byte) (*types.Package, gopium.Locator, error) {
+	// manage context actions
+	// in case of cancelation
+	// stop parse and return error back
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	default:
+	}
+	// scan imported packages first
+	// and then package itself
+	loc := NewLocator(p.Package.Fset)
+	for _, imp := range p.Imports {
+		if _, err := loc.Package(imp.PkgPath).Scan(imp.Syntax...); err != nil {
+			return nil, nil, err
+		}
+	}
+	if _, err := loc.Package(p.Package.PkgPath).Scan(p.Package.Syntax...); err != nil {
+		return nil, nil, err
+	}
+	return p.Package.Types, loc.Info(p.Package.TypesInfo), nil
+}
+
+// ParseAst ParserXToolPackage implementation
+func (p ParserXToolPackage) ParseAst(ctx context.Context, src ...byte) (*ast.Package, gopium.Locator, error) {
+	return p.Ast.ParseAst(ctx, src...)
+}
+
+// scan helps to scan all ast package files
+// with locator and returns package back,
+// ast package doesn't hold package path
+// so identities are scoped only
+// by scope path and type name
+func scan(loc *Locator, pkg *ast.Package) (*ast.Package, gopium.Locator, error) {
+	files := make([]*ast.File, 0, len(pkg.Files))
+	for _, file := range pkg.Files {
+		files = append(files, file)
+	}
+	if _, err := loc.Scan(files...); err != nil {
+		return nil, nil, err
+	}
+	return pkg, loc, nil
+}
@@ -9,6 +9,8 @@ import (
 	"go/scanner"
 	"go/token"
 	"go/types"
+	"io/ioutil"
+	"os"
 	"path"
 	"path/filepath"
 	"reflect"
@@ -379,3 +381,252 @@ type Single struct {
 		})
 	}
 }
+
+func TestParserXToolPackagesAstLocate(t *testing.T) {
+	// prepare
+	root, err := ioutil.TempDir("", "gopium")
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	defer os.RemoveAll(root)
+	// resolve tmp dir symlinks
+	// as go command does
+	root, err = filepath.EvalSymlinks(root)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	write := func(name string, content string) {
+		fpath := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(fpath), 0755); err != nil {
+			t.Fatalf("actual %v doesn't equal to %v", err, nil)
+		}
+		if err := ioutil.WriteFile(fpath, []byte(content), 0644); err != nil {
+			t.Fatalf("actual %v doesn't equal to %v", err, nil)
+		}
+	}
+	write("mod/go.mod", "module example.com/mod\n\ngo 1.14\n")
+	write("mod/sub/file.go", "package sub")
+	write("vendor/go.mod", "module example.com/vendor\n\ngo 1.14\n\nrequire example.com/dep v1.0.0\n")
+	write("vendor/vendor/modules.txt", "# example.com/dep v1.0.0\n## explicit\nexample.com/dep\n")
+	write("vendor/vendor/example.com/dep/file.go", "package dep")
+	write("work/go.work", "go 1.18\n\nuse ./app\n")
+	write("work/app/go.mod", "module example.com/app\n\ngo 1.18\n")
+	write("work/app/file.go", "package app")
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		p   ParserXToolPackagesAst
+		ctx context.Context
+		pkg string
+		dir string
+		err bool
+	}{
+		"module import path should be located inside module": {
+			p: ParserXToolPackagesAst{
+				Pattern: "example.com/mod/sub",
+				Path:    filepath.Join(root, "mod"),
+			},
+			ctx: context.Background(),
+			pkg: "example.com/mod/sub",
+			dir: filepath.Join(root, "mod", "sub"),
+		},
+		"module relative dir should be located inside module": {
+			p: ParserXToolPackagesAst{
+				Pattern: "./sub",
+				Path:    filepath.Join(root, "mod"),
+			},
+			ctx: context.Background(),
+			pkg: "example.com/mod/sub",
+			dir: filepath.Join(root, "mod", "sub"),
+		},
+		"module dependency import path should be located inside vendor": {
+			p: ParserXToolPackagesAst{
+				Pattern:    "example.com/dep",
+				Path:       filepath.Join(root, "vendor"),
+				BuildFlags: []string{"-mod=vendor"},
+			},
+			ctx: context.Background(),
+			pkg: "example.com/dep",
+			dir: filepath.Join(root, "vendor", "vendor", "example.com", "dep"),
+		},
+		"workspace import path should be located inside workspace module": {
+			p: ParserXToolPackagesAst{
+				Pattern: "example.com/app",
+				Path:    filepath.Join(root, "work"),
+			},
+			ctx: context.Background(),
+			pkg: "example.com/app",
+			dir: filepath.Join(root, "work", "app"),
+		},
+		"unknown import path should return locate error": {
+			p: ParserXToolPackagesAst{
+				Pattern: "example.com/mod/unknown",
+				Path:    filepath.Join(root, "mod"),
+			},
+			ctx: context.Background(),
+			err: true,
+		},
+		"canceled context should return context error": {
+			p: ParserXToolPackagesAst{
+				Pattern: "example.com/mod/sub",
+				Path:    filepath.Join(root, "mod"),
+			},
+			ctx: cctx,
+			err: true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			pkg, dir, err := tcase.p.Locate(tcase.ctx)
+			// check
+			// go command errors contain tmp paths
+			// so just check errors presence
+			if !reflect.DeepEqual(err != nil, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			if !reflect.DeepEqual(pkg, tcase.pkg) {
+				t.Errorf("actual %v doesn't equal to expected %v", pkg, tcase.pkg)
+			}
+			if !reflect.DeepEqual(dir, tcase.dir) {
+				t.Errorf("actual %v doesn't equal to expected %v", dir, tcase.dir)
+			}
+		})
+	}
+}
+
+func TestParserXToolPackagesAstPackages(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		p        ParserXToolPackagesAst
+		ctx      context.Context
+		patterns []string
+		pkgs     []string
+		names    []string
+		err      error
+	}{
+		"valid patterns should return expected sorted packages parsers": {
+			p: ParserXToolPackagesAst{
+				Path:       tests.Gopium,
+				ModeTypes:  packages.LoadAllSyntax,
+				ModeAst:    parser.ParseComments | parser.AllErrors,
+				BuildFlags: []string{"-tags=tests_data"},
+			},
+			ctx: context.Background(),
+			patterns: []string{
+				"github.com/1pkg/gopium/tests/data/single",
+				"github.com/1pkg/gopium/tests/data/flat",
+			},
+			pkgs: []string{
+				"github.com/1pkg/gopium/tests/data/flat",
+				"github.com/1pkg/gopium/tests/data/single",
+			},
+			names: []string{"flat", "single"},
+		},
+		"valid wildcard pattern should return expected sorted packages parsers": {
+			p: ParserXToolPackagesAst{
+				Path:       filepath.Join(tests.Gopium, "tests", "data", "nested"),
+				ModeTypes:  packages.LoadAllSyntax,
+				ModeAst:    parser.ParseComments | parser.AllErrors,
+				BuildFlags: []string{"-tags=tests_data"},
+			},
+			ctx:      context.Background(),
+			patterns: []string{"./..."},
+			pkgs:     []string{"github.com/1pkg/gopium/tests/data/nested"},
+			names:    []string{"nested"},
+		},
+		"patterns without go files should return parser error": {
+			p: ParserXToolPackagesAst{
+				Path:      tests.Gopium,
+				ModeTypes: packages.LoadAllSyntax,
+			},
+			ctx:      context.Background(),
+			patterns: []string{"./tests/data/empty"},
+			err:      fmt.Errorf("packages %q weren't found at %q", "./tests/data/empty", tests.Gopium),
+		},
+		"canceled context should return context error": {
+			p: ParserXToolPackagesAst{
+				Path:      tests.Gopium,
+				ModeTypes: packages.LoadAllSyntax,
+			},
+			ctx:      cctx,
+			patterns: []string{"./..."},
+			err:      context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			pps, err := tcase.p.Packages(tcase.ctx, tcase.patterns...)
+			// check
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Fatalf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			var pkgs, names []string
+			for _, pp := range pps {
+				pkg, _, err := pp.ParseTypes(tcase.ctx)
+				if !reflect.DeepEqual(err, nil) {
+					t.Fatalf("actual %v doesn't equal to %v", err, nil)
+				}
+				apkg, _, err := pp.ParseAst(tcase.ctx)
+				if !reflect.DeepEqual(err, nil) {
+					t.Fatalf("actual %v doesn't equal to %v", err, nil)
+				}
+				pkgs = append(pkgs, pkg.Path())
+				names = append(names, apkg.Name)
+			}
+			if !reflect.DeepEqual(pkgs, tcase.pkgs) {
+				t.Errorf("actual %v doesn't equal to expected %v", pkgs, tcase.pkgs)
+			}
+			if !reflect.DeepEqual(names, tcase.names) {
+				t.Errorf("actual %v doesn't equal to expected %v", names, tcase.names)
+			}
+		})
+	}
+}
+
+func TestVariants(t *testing.T) {
+	// prepare
+	plain := &packages.Package{ID: "pkg", PkgPath: "pkg"}
+	internal := &packages.Package{ID: "pkg [pkg.test]", PkgPath: "pkg"}
+	external := &packages.Package{ID: "pkg_test [pkg.test]", PkgPath: "pkg_test"}
+	tmain := &packages.Package{ID: "pkg.test", PkgPath: "pkg.test"}
+	other := &packages.Package{ID: "other", PkgPath: "other"}
+	pkgs := []*packages.Package{plain, internal, external, tmain, other}
+	table := map[string]struct {
+		tests TestVariant
+		pkgs  []*packages.Package
+	}{
+		"none variant should select only plain packages": {
+			tests: TestNone,
+			pkgs:  []*packages.Package{plain, other},
+		},
+		"internal variant should select internal test packages": {
+			tests: TestInternal,
+			pkgs:  []*packages.Package{internal, other},
+		},
+		"zero variant should select internal test packages": {
+			pkgs: []*packages.Package{internal, other},
+		},
+		"external variant should select plain and external test packages": {
+			tests: TestExternal,
+			pkgs:  []*packages.Package{plain, external, other},
+		},
+		"all variant should select internal and external test packages": {
+			tests: TestAll,
+			pkgs:  []*packages.Package{internal, external, other},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			vpkgs := variants(pkgs, tcase.tests)
+			// check
+			if !reflect.DeepEqual(vpkgs, tcase.pkgs) {
+				t.Errorf("actual %v doesn't equal to expected %v", vpkgs, tcase.pkgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package typepkg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// ParserGitRevision defines
+// gopium types parser implementation
+// that reads package files from provided git revision
+// via `git show` and then type checks them in memory,
+// it is useful to obtain baseline of package types
+// without touching the working tree
+type ParserGitRevision struct {
+	Rev     string `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Pattern string `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Path    string `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Root    string `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// ParseTypes ParserGitRevision implementation
+func (p ParserGitRevision) ParseTypes(ctx context.Context, _ ...byte) (*types.Package, gopium.Locator, error) {
+	// manage context actions
+	// in case of cancelation
+	// stop parse and return error back
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	default:
+	}
+	// package dir might not exist in working tree
+	// so run git inside closest existing parent dir
+	// and list all package files on revision
+	dir := filepath.Join(p.Root, p.Path)
+	wdir := dir
+	for {
+		if _, err := os.Stat(wdir); err == nil || filepath.Dir(wdir) == wdir {
+			break
+		}
+		wdir = filepath.Dir(wdir)
+	}
+	rel, err := filepath.Rel(wdir, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	rel = filepath.ToSlash(rel)
+	out, err := p.git(ctx, wdir, "ls-tree", "--name-only", p.Rev, fmt.Sprintf("./%s/", rel))
+	if err != nil {
+		return nil, nil, err
+	}
+	// prepare build context that reads
+	// files content from git revision
+	srcs := make(map[string][]byte)
+	bctx := build.Default
+	bctx.OpenFile = func(fpath string) (io.ReadCloser, error) {
+		if src, ok := srcs[fpath]; ok {
+			return ioutil.NopCloser(bytes.NewReader(src)), nil
+		}
+		return nil, fmt.Errorf("file %q wasn't found on revision %q", fpath, p.Rev)
+	}
+	// go through all revision files
+	// and parse only go non test files
+	// that match build constraints
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0)
+	for _, rname := range strings.Fields(string(out)) {
+		name := path.Base(rname)
+		if filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		// grab file content from revision
+		src, err := p.git(ctx, wdir, "show", fmt.Sprintf("%s:./%s", p.Rev, rname))
+		if err != nil {
+			return nil, nil, err
+		}
+		fpath := filepath.Join(dir, name)
+		srcs[fpath] = src
+		// check file build constraints
+		if ok, err := bctx.MatchFile(dir, name); err != nil || !ok {
+			continue
+		}
+		// parse file in memory
+		// on any error just propagate it
+		file, err := parser.ParseFile(fset, fpath, src, parser.ParseComments)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, nil, fmt.Errorf("package %q wasn't found at %q on revision %q", p.Pattern, dir, p.Rev)
+	}
+	// type check parsed files
+	// on any error just propagate it
+	cfg := &types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Sizes:    types.SizesFor("gc", build.Default.GOARCH),
+	}
+	pkg, err := cfg.Check(p.Pattern, fset, files, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	loc, err := NewLocator(fset).Package(pkg.Path()).Scan(files...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pkg, loc, nil
+}
+
+// git runs git command inside provided dir
+// and returns its output or error
+func (p ParserGitRevision) git(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	// prepare git command
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Stderr = &stderr
+	// run it and wrap any error
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s failed %v %s", strings.Join(args, " "), err, stderr.String())
+	}
+	return out, nil
+}
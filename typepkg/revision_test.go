@@ -0,0 +1,129 @@
+package typepkg
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParserGitRevision(t *testing.T) {
+	// prepare
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git executable wasn't found")
+	}
+	root, err := ioutil.TempDir("", "gopium")
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	defer os.RemoveAll(root)
+	// prepare git repository with
+	// single commit that contains package
+	// and then remove package from working tree
+	files := map[string]string{
+		filepath.Join("pkg", "file.go"):      "package pkg\n\ntype A struct {\n\ta bool\n\tb int64\n\tc bool\n}\n",
+		filepath.Join("pkg", "file_test.go"): "package pkg\n\ntype B struct{}\n",
+		filepath.Join("empty", "file.txt"):   "empty\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); !reflect.DeepEqual(err, nil) {
+			t.Fatalf("actual %v doesn't equal to %v", err, nil)
+		}
+		if err := ioutil.WriteFile(path, []byte(src), 0644); !reflect.DeepEqual(err, nil) {
+			t.Fatalf("actual %v doesn't equal to %v", err, nil)
+		}
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@test", "commit", "-q", "-m", "test"},
+		{"rm", "-q", "-r", "pkg"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); !reflect.DeepEqual(err, nil) {
+			t.Fatalf("actual %v %s doesn't equal to %v", err, out, nil)
+		}
+	}
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		p     ParserGitRevision
+		ctx   context.Context
+		names []string
+		err   error
+		gerr  bool
+	}{
+		"valid revision should return expected package": {
+			p: ParserGitRevision{
+				Rev:     "HEAD",
+				Pattern: "pkg",
+				Path:    "pkg",
+				Root:    root,
+			},
+			ctx:   context.Background(),
+			names: []string{"A"},
+		},
+		"empty package should return parser error": {
+			p: ParserGitRevision{
+				Rev:     "HEAD",
+				Pattern: "empty",
+				Path:    "empty",
+				Root:    root,
+			},
+			ctx: context.Background(),
+			err: fmt.Errorf(`package "empty" wasn't found at %q on revision "HEAD"`, filepath.Join(root, "empty")),
+		},
+		"invalid revision should return git error": {
+			p: ParserGitRevision{
+				Rev:     "test",
+				Pattern: "pkg",
+				Path:    "pkg",
+				Root:    root,
+			},
+			ctx:  context.Background(),
+			gerr: true,
+		},
+		"valid revision should return error on canceled context": {
+			p: ParserGitRevision{
+				Rev:     "HEAD",
+				Pattern: "pkg",
+				Path:    "pkg",
+				Root:    root,
+			},
+			ctx: cctx,
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			pkg, loc, err := tcase.p.ParseTypes(tcase.ctx)
+			// check
+			// git errors contain git output
+			// so just check their presence
+			if tcase.gerr {
+				if reflect.DeepEqual(err, nil) {
+					t.Errorf("actual %v doesn't equal to expected not %v", err, nil)
+				}
+				return
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			if tcase.err == nil {
+				if !reflect.DeepEqual(pkg.Scope().Names(), tcase.names) {
+					t.Errorf("actual %v doesn't equal to expected %v", pkg.Scope().Names(), tcase.names)
+				}
+				if reflect.DeepEqual(loc, nil) {
+					t.Errorf("actual %v doesn't equal to expected not %v", loc, nil)
+				}
+			}
+		})
+	}
+}
@@ -21,18 +21,26 @@ const (
 	// wdiff walkers
 	SizeAlignFileMdt gopium.WalkerName = "size_align_file_md_table"
 	FieldsFileHtmlt  gopium.WalkerName = "fields_file_html_table"
+	// wsnap walkers
+	Snapshot gopium.WalkerName = "snapshot"
+	// wreg walkers
+	Regress gopium.WalkerName = "regress"
+	// wdeps walkers
+	DependenciesFileMdt gopium.WalkerName = "dependencies_file_md_table"
 )
 
 // Builder defines types gopium.WalkerBuilder implementation
 // that uses parser and exposer to pass it to related walkers
 type Builder struct {
-	Parser  gopium.Parser  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	Exposer gopium.Exposer `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	Printer gopium.Printer `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	Deep    bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	Bref    bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_       [14]byte       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+	Matrix   []Target          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Parser   gopium.Parser     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Baseline gopium.TypeParser `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Exposer  gopium.Exposer    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Printer  gopium.Printer    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Deep     bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Bref     bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_        [38]byte          `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
 
 // Build Builder implementation
 func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
@@ -43,6 +51,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 			b.Parser,
 			b.Exposer,
 			b.Printer,
+			b.Matrix,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -51,6 +60,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 			b.Parser,
 			b.Exposer,
 			b.Printer,
+			b.Matrix,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -59,6 +69,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 			b.Parser,
 			b.Exposer,
 			b.Printer,
+			b.Matrix,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -67,6 +78,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 			b.Parser,
 			b.Exposer,
 			b.Printer,
+			b.Matrix,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -75,6 +87,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 		return filejson.With(
 			b.Parser,
 			b.Exposer,
+			b.Matrix,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -82,6 +95,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 		return filexml.With(
 			b.Parser,
 			b.Exposer,
+			b.Matrix,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -89,6 +103,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 		return filecsv.With(
 			b.Parser,
 			b.Exposer,
+			b.Matrix,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -96,6 +111,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 		return filemdt.With(
 			b.Parser,
 			b.Exposer,
+			b.Matrix,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -104,11 +120,38 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 		return safilemdt.With(
 			b.Parser,
 			b.Exposer,
+			b.Matrix,
 			b.Deep,
 			b.Bref,
 		), nil
 	case FieldsFileHtmlt:
 		return ffilehtml.With(
+			b.Parser,
+			b.Exposer,
+			b.Matrix,
+			b.Deep,
+			b.Bref,
+		), nil
+	// wsnap walkers
+	case Snapshot:
+		return snapshot.With(
+			b.Parser,
+			b.Exposer,
+			b.Deep,
+			b.Bref,
+		), nil
+	// wreg walkers
+	case Regress:
+		return regress.With(
+			b.Parser,
+			b.Baseline,
+			b.Exposer,
+			b.Deep,
+			b.Bref,
+		), nil
+	// wdeps walkers
+	case DependenciesFileMdt:
+		return depsfilemdt.With(
 			b.Parser,
 			b.Exposer,
 			b.Deep,
@@ -14,6 +14,7 @@ func TestBuilder(t *testing.T) {
 	b := Builder{
 		Parser:  mocks.Parser{},
 		Exposer: mocks.Maven{},
+		Matrix:  []Target{{Name: "linux/amd64"}},
 		Deep:    true,
 		Bref:    true,
 	}
@@ -29,6 +30,7 @@ func TestBuilder(t *testing.T) {
 				b.Parser,
 				b.Exposer,
 				b.Printer,
+				b.Matrix,
 				b.Deep,
 				b.Bref,
 			),
@@ -39,6 +41,7 @@ func TestBuilder(t *testing.T) {
 				b.Parser,
 				b.Exposer,
 				b.Printer,
+				b.Matrix,
 				b.Deep,
 				b.Bref,
 			),
@@ -49,6 +52,7 @@ func TestBuilder(t *testing.T) {
 				b.Parser,
 				b.Exposer,
 				b.Printer,
+				b.Matrix,
 				b.Deep,
 				b.Bref,
 			),
@@ -59,6 +63,7 @@ func TestBuilder(t *testing.T) {
 				b.Parser,
 				b.Exposer,
 				b.Printer,
+				b.Matrix,
 				b.Deep,
 				b.Bref,
 			),
@@ -69,6 +74,7 @@ func TestBuilder(t *testing.T) {
 			w: filejson.With(
 				b.Parser,
 				b.Exposer,
+				b.Matrix,
 				b.Deep,
 				b.Bref,
 			),
@@ -78,6 +84,7 @@ func TestBuilder(t *testing.T) {
 			w: filexml.With(
 				b.Parser,
 				b.Exposer,
+				b.Matrix,
 				b.Deep,
 				b.Bref,
 			),
@@ -87,6 +94,7 @@ func TestBuilder(t *testing.T) {
 			w: filecsv.With(
 				b.Parser,
 				b.Exposer,
+				b.Matrix,
 				b.Deep,
 				b.Bref,
 			),
@@ -96,6 +104,7 @@ func TestBuilder(t *testing.T) {
 			w: filemdt.With(
 				b.Parser,
 				b.Exposer,
+				b.Matrix,
 				b.Deep,
 				b.Bref,
 			),
@@ -106,6 +115,7 @@ func TestBuilder(t *testing.T) {
 			w: safilemdt.With(
 				b.Parser,
 				b.Exposer,
+				b.Matrix,
 				b.Deep,
 				b.Bref,
 			),
@@ -113,6 +123,38 @@ func TestBuilder(t *testing.T) {
 		"`fields_file_html_table` name should return expected walker": {
 			name: FieldsFileHtmlt,
 			w: ffilehtml.With(
+				b.Parser,
+				b.Exposer,
+				b.Matrix,
+				b.Deep,
+				b.Bref,
+			),
+		},
+		// wsnap walkers
+		"`snapshot` name should return expected walker": {
+			name: Snapshot,
+			w: snapshot.With(
+				b.Parser,
+				b.Exposer,
+				b.Deep,
+				b.Bref,
+			),
+		},
+		// wreg walkers
+		"`regress` name should return expected walker": {
+			name: Regress,
+			w: regress.With(
+				b.Parser,
+				b.Baseline,
+				b.Exposer,
+				b.Deep,
+				b.Bref,
+			),
+		},
+		// wdeps walkers
+		"`dependencies_file_md_table` name should return expected walker": {
+			name: DependenciesFileMdt,
+			w: depsfilemdt.With(
 				b.Parser,
 				b.Exposer,
 				b.Deep,
@@ -0,0 +1,79 @@
+package walkers
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// diagsKey defines context key
+// for strategies diagnostics flag
+type diagsKey struct{}
+
+// withDiags attaches strategies diagnostics
+// flag to visiting context, so report
+// and check walkers collect non fatal
+// strategies diagnostics like exceeded
+// struct budgets instead of failing
+func withDiags(ctx context.Context) context.Context {
+	return context.WithValue(ctx, diagsKey{}, true)
+}
+
+// diags returns strategies diagnostics
+// flag from visiting context if any
+func diags(ctx context.Context) bool {
+	diags, _ := ctx.Value(diagsKey{}).(bool)
+	return diags
+}
+
+// diagnoser helps to attach new structure
+// strategies diagnoser to visiting context
+// in case of diagnostics and returns it back,
+// returned diagnostics func collects
+// reported diagnostics
+func diagnoser(ctx context.Context, id string, loc string) (context.Context, func() collections.Diagnostics) {
+	if !diags(ctx) {
+		return ctx, func() collections.Diagnostics { return nil }
+	}
+	d := collections.NewDiagnoser(collections.Local(id), loc)
+	return gopium.WithDiagnoser(ctx, d), d.Diagnostics
+}
+
+// wdiags helps to write structures
+// strategies diagnostics to diagnostics writer
+// next to other walker results
+func wdiags(ds collections.Diagnostics, writer gopium.Writer) error {
+	// skip empty writes
+	if len(ds) == 0 || writer == nil {
+		return nil
+	}
+	// use diagnostics locations
+	// to find results root category
+	h := collections.NewHierarchic("")
+	for _, d := range ds {
+		h.Push(d.Struct, d.Loc, gopium.Struct{})
+	}
+	loc := filepath.Join(h.Rcat(), gopium.NAME)
+	// apply formatter
+	buf, err := fmtio.DiagnosticsMdt(ds)
+	// in case any error happened
+	// in formatter return error back
+	if err != nil {
+		return kind(gopium.ErrFormat, err, loc)
+	}
+	// generate writer
+	wc, err := writer.Generate(loc)
+	if err != nil {
+		return kind(gopium.ErrWrite, err, loc)
+	}
+	// write results and close writer
+	// in case any error happened
+	// in writer return error
+	if _, err := wc.Write(buf); err != nil {
+		return kind(gopium.ErrWrite, err, loc)
+	}
+	return kind(gopium.ErrWrite, wc.Close(), loc)
+}
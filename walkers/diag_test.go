@@ -0,0 +1,189 @@
+package walkers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestDiagnostics(t *testing.T) {
+	// prepare
+	src := `
+package pkg
+
+//gopium:budget size=8
+type A struct {
+	a bool
+	b int64
+	c bool
+}
+
+//gopium:budget size=8
+type b struct {
+	x int64
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "pkg/file.go", src, parser.ParseComments)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	files := []*ast.File{file}
+	pkg, err := (&types.Config{Sizes: types.SizesFor("gc", "amd64")}).Check("pkg", fset, files, nil)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	p := typepkg.ParserXToolPackage{Package: &packages.Package{PkgPath: "pkg", Types: pkg, Fset: fset, Syntax: files}}
+	m, err := typepkg.NewMavenGoTypes("gc", "amd64", 64, 64, 64)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	table := map[string]struct {
+		diags bool
+		ds    collections.Diagnostics
+		errs  []string
+	}{
+		"visiting without diagnostics should fail on exceeded budget": {
+			errs: []string{"A"},
+		},
+		"visiting with diagnostics should collect exceeded budget": {
+			diags: true,
+			ds: collections.Diagnostics{
+				{
+					Struct:  "A",
+					Loc:     "pkg/file.go",
+					Message: `struct "A" aligned size 16 bytes exceeds budget size 8 bytes`,
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			stg, err := strategies.Builder{}.Build(strategies.Pack, strategies.Budget)
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to %v", err, nil)
+			}
+			ctx := context.Background()
+			if tcase.diags {
+				ctx = withDiags(ctx)
+			}
+			_, loc, err := p.ParseTypes(ctx)
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to %v", err, nil)
+			}
+			ch := make(appliedCh)
+			// exec
+			go with(m, loc, false).visit(regexp.MustCompile(`.*`), stg, ch, false)(ctx, pkg.Scope())
+			var ds collections.Diagnostics
+			var errs []string
+			for applied := range ch {
+				if applied.Err != nil {
+					errs = append(errs, applied.O.Name)
+				}
+				ds = append(ds, applied.Diags...)
+			}
+			// check
+			if !reflect.DeepEqual(ds, tcase.ds) {
+				t.Errorf("actual %v doesn't equal to expected %v", ds, tcase.ds)
+			}
+			if !reflect.DeepEqual(errs, tcase.errs) {
+				t.Errorf("actual %v doesn't equal to expected %v", errs, tcase.errs)
+			}
+		})
+	}
+}
+
+func TestWdiags(t *testing.T) {
+	// prepare
+	ds := collections.Diagnostics{
+		{
+			Struct:  "B",
+			Loc:     "pkg/file.go:10",
+			Message: `struct "B" align 8 bytes exceeds budget align 4 bytes`,
+		},
+		{
+			Struct:  "A",
+			Loc:     "pkg/file.go:4",
+			Message: `struct "A" aligned size 24 bytes exceeds budget size 16 bytes`,
+		},
+	}
+	table := map[string]struct {
+		ds  collections.Diagnostics
+		w   *mocks.Writer
+		dgs map[string][]byte
+		err error
+	}{
+		"empty diagnostics should write nothing": {
+			w:   &mocks.Writer{},
+			dgs: map[string][]byte{},
+		},
+		"diagnostics should be written next to results": {
+			ds: ds,
+			w:  &mocks.Writer{},
+			dgs: map[string][]byte{
+				"pkg/gopium": []byte(`
+| Struct Key | Struct Location | Diagnostic |
+| :---: | :---: | :---: |
+| A | pkg/file.go:4 | struct "A" aligned size 24 bytes exceeds budget size 16 bytes |
+| B | pkg/file.go:10 | struct "B" align 8 bytes exceeds budget align 4 bytes |
+`),
+			},
+		},
+		"diagnostics should write nothing on writer error": {
+			ds:  ds,
+			w:   &mocks.Writer{Gerr: errors.New("test-1")},
+			dgs: map[string][]byte{},
+			err: gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-1"), Pos: "pkg/gopium"},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			err := wdiags(tcase.ds, tcase.w)
+			// check
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// process checks only on success
+			if tcase.err == nil {
+				for id, rwc := range tcase.w.RWCs {
+					dg, ok := tcase.dgs[id]
+					if !ok {
+						t.Errorf("actual %v doesn't equal to expected %v", id, "")
+						continue
+					}
+					var buf bytes.Buffer
+					if _, err := buf.ReadFrom(rwc); !reflect.DeepEqual(err, nil) {
+						t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+					}
+					actual := strings.Trim(buf.String(), "\n")
+					expected := strings.Trim(string(dg), "\n")
+					if !reflect.DeepEqual(actual, expected) {
+						t.Errorf("id %v actual %v doesn't equal to expected %v", id, actual, expected)
+					}
+					delete(tcase.dgs, id)
+				}
+				if !reflect.DeepEqual(tcase.dgs, map[string][]byte{}) {
+					t.Errorf("actual %v doesn't equal to expected %v", tcase.dgs, map[string][]byte{})
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package walkers
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// failures defines visiting failures collector
+// that on keep going visiting collects structures
+// failures instead of aborting visiting
+type failures struct {
+	errs gopium.Errors `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	keep bool          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_    [7]byte       `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
+
+// fails creates failures collector
+// with keep going flag from visiting context
+func fails(ctx context.Context) *failures {
+	return &failures{keep: gopium.KeepGoing(ctx)}
+}
+
+// fail collects structure failure
+// on keep going visiting and returns nil,
+// otherwise or if error isn't related
+// to any structure it returns error back
+func (fs *failures) fail(err error) error {
+	var gerr gopium.Error
+	if fs.keep && errors.As(err, &gerr) && gerr.ID != "" {
+		fs.errs = append(fs.errs, err)
+		return nil
+	}
+	return err
+}
+
+// failed checks if structure
+// failure was collected for id
+func (fs *failures) failed(id string) bool {
+	for _, err := range fs.errs {
+		var gerr gopium.Error
+		if errors.As(err, &gerr) && gerr.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// err returns all collected
+// failures as single error if any,
+// failures are sorted by structures
+// identities and positions as they
+// are collected from concurrent visits
+func (fs *failures) err() error {
+	if len(fs.errs) == 0 {
+		return nil
+	}
+	errs := make(gopium.Errors, len(fs.errs))
+	copy(errs, fs.errs)
+	sort.SliceStable(errs, func(i, j int) bool {
+		var ierr, jerr gopium.Error
+		errors.As(errs[i], &ierr)
+		errors.As(errs[j], &jerr)
+		if ierr.ID != jerr.ID {
+			return ierr.ID < jerr.ID
+		}
+		if ierr.Pos != jerr.Pos {
+			return ierr.Pos < jerr.Pos
+		}
+		return errs[i].Error() < errs[j].Error()
+	})
+	return errs
+}
+
+// kind helps to wrap error with error kind
+// and position it happened at if any,
+// context errors are returned as is
+func kind(k error, err error, pos string) error {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return gopium.Error{Kind: k, Err: err, Pos: pos}
+}
@@ -0,0 +1,125 @@
+package walkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// anonymous helps to strip failed struct
+// identity and position from struct error,
+// as failed struct of multi structs package
+// depends on concurrent visiting order
+func anonymous(err error) error {
+	if gerr, ok := err.(gopium.Error); ok && gerr.ID != "" {
+		gerr.ID, gerr.Pos = "", ""
+		return gerr
+	}
+	return err
+}
+
+func TestFailures(t *testing.T) {
+	// prepare
+	serr := gopium.Error{Kind: gopium.ErrStrategy, Err: errors.New("test-1"), ID: "pkg:A", Pos: "pkg/file.go:3:6"}
+	werr := gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-2"), Pos: "pkg/gopium"}
+	berr := gopium.Error{Kind: gopium.ErrStrategy, Err: errors.New("test-4"), ID: "pkg:B", Pos: "pkg/file.go:7:6"}
+	aerr := gopium.Error{Kind: gopium.ErrFormat, Err: errors.New("test-5"), ID: "pkg:A", Pos: "pkg/file.go:3:6"}
+	table := map[string]struct {
+		ctx    context.Context
+		errs   []error
+		fails  []error
+		failed map[string]bool
+		err    error
+	}{
+		"failures should collect nothing without errors": {
+			ctx:    gopium.WithKeepGoing(context.Background(), true),
+			failed: map[string]bool{"pkg:A": false},
+		},
+		"failures should return errors back without keep going": {
+			ctx:    context.Background(),
+			errs:   []error{serr, werr},
+			fails:  []error{serr, werr},
+			failed: map[string]bool{"pkg:A": false},
+		},
+		"failures should collect only structs errors on keep going": {
+			ctx:    gopium.WithKeepGoing(context.Background(), true),
+			errs:   []error{serr, werr, errors.New("test-3")},
+			fails:  []error{nil, werr, errors.New("test-3")},
+			failed: map[string]bool{"pkg:A": true, "pkg:B": false},
+			err:    gopium.Errors{serr},
+		},
+		"failures should collect structs errors sorted by identities on keep going": {
+			ctx:    gopium.WithKeepGoing(context.Background(), true),
+			errs:   []error{berr, serr, aerr},
+			fails:  []error{nil, nil, nil},
+			failed: map[string]bool{"pkg:A": true, "pkg:B": true},
+			err:    gopium.Errors{aerr, serr, berr},
+		},
+		"failures should collect wrapped structs errors on keep going": {
+			ctx:    gopium.WithKeepGoing(context.Background(), true),
+			errs:   []error{fmt.Errorf("target %q %w", "linux/386", serr)},
+			fails:  []error{nil},
+			failed: map[string]bool{"pkg:A": true},
+			err:    gopium.Errors{fmt.Errorf("target %q %w", "linux/386", serr)},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			fs := fails(tcase.ctx)
+			fails := make([]error, 0, len(tcase.errs))
+			for _, err := range tcase.errs {
+				fails = append(fails, fs.fail(err))
+			}
+			err := fs.err()
+			// check
+			if len(tcase.errs) > 0 && !reflect.DeepEqual(fails, tcase.fails) {
+				t.Errorf("actual %v doesn't equal to expected %v", fails, tcase.fails)
+			}
+			for id, failed := range tcase.failed {
+				if !reflect.DeepEqual(fs.failed(id), failed) {
+					t.Errorf("id %v actual %v doesn't equal to expected %v", id, fs.failed(id), failed)
+				}
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestKind(t *testing.T) {
+	// prepare
+	cause := errors.New("test-1")
+	err := kind(gopium.ErrWrite, cause, "pkg/gopium")
+	// check
+	if !reflect.DeepEqual(kind(gopium.ErrWrite, nil, "pkg/gopium"), nil) {
+		t.Errorf("actual %v doesn't equal to expected %v", kind(gopium.ErrWrite, nil, "pkg/gopium"), nil)
+	}
+	if !reflect.DeepEqual(kind(gopium.ErrParse, context.Canceled, ""), context.Canceled) {
+		t.Errorf("actual %v doesn't equal to expected %v", kind(gopium.ErrParse, context.Canceled, ""), context.Canceled)
+	}
+	if !reflect.DeepEqual(err.Error(), "write error at pkg/gopium: test-1") {
+		t.Errorf("actual %v doesn't equal to expected %v", err.Error(), "write error at pkg/gopium: test-1")
+	}
+	if !errors.Is(err, gopium.ErrWrite) || errors.Is(err, gopium.ErrParse) || !errors.Is(err, cause) {
+		t.Errorf("actual %v doesn't equal to expected %v", err, gopium.ErrWrite)
+	}
+	serr := gopium.Error{Kind: gopium.ErrStrategy, Err: cause, ID: "pkg:A", Pos: "pkg/file.go:3"}
+	if !reflect.DeepEqual(serr.Error(), `strategy error on struct "pkg:A" at pkg/file.go:3: test-1`) {
+		t.Errorf("actual %v doesn't equal to expected %v", serr.Error(), `strategy error on struct "pkg:A" at pkg/file.go:3: test-1`)
+	}
+	errs := gopium.Errors{errors.New("test-2"), fmt.Errorf("package %q %w", "pkg", err)}
+	var gerr gopium.Error
+	if !errors.Is(errs, gopium.ErrWrite) || !errors.As(errs, &gerr) || !reflect.DeepEqual(gerr, err) {
+		t.Errorf("actual %v doesn't equal to expected %v", gerr, err)
+	}
+	expected := "2 errors happened\ntest-2\npackage \"pkg\" write error at pkg/gopium: test-1"
+	if !reflect.DeepEqual(errs.Error(), expected) {
+		t.Errorf("actual %v doesn't equal to expected %v", errs.Error(), expected)
+	}
+}
@@ -0,0 +1,196 @@
+package walkers
+
+import (
+	"context"
+	"fmt"
+	"go/types"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// Filters defines structures selection filters
+// that are evaluated on visited scopes
+// in addition to walker regexp, structure is skipped
+// by the first filter it doesn't pass,
+// zero values are treated as not set
+type Filters struct {
+	Include    []*regexp.Regexp `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Exclude    []*regexp.Regexp `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Files      []string         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Directives []string         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	MinSize    int64            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	MaxSize    int64            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	MinWaste   int64            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Exported   bool             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_          [7]byte          `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
+
+// skip checks structure against all selection filters
+// and returns description of the first filter
+// that structure doesn't pass or empty string otherwise,
+// size filters are evaluated only for non generic structures
+// on their original layouts
+func (f Filters) skip(m *maven, tn *types.TypeName, st *types.Struct, loc string) string {
+	name := tn.Name()
+	// check name include and exclude regexps
+	if len(f.Include) > 0 && !rmatch(f.Include, name) {
+		rs := make([]string, 0, len(f.Include))
+		for _, r := range f.Include {
+			rs = append(rs, r.String())
+		}
+		return fmt.Sprintf("include %s", quote(rs))
+	}
+	for _, r := range f.Exclude {
+		if r.MatchString(name) {
+			return fmt.Sprintf("exclude %q", r.String())
+		}
+	}
+	// check file globs against location
+	// and location base name,
+	// globs were already validated
+	if len(f.Files) > 0 && !fmatch(f.Files, loc) {
+		return fmt.Sprintf("file %s", quote(f.Files))
+	}
+	// check exported only flag
+	if f.Exported && !tn.Exported() {
+		return "exported"
+	}
+	// check structure directives names
+	if len(f.Directives) > 0 && !dmatch(f.Directives, m.loc.Directives(tn.Pos())) {
+		return fmt.Sprintf("directive %s", quote(f.Directives))
+	}
+	// check structure size and padding waste
+	if f.MinSize <= 0 && f.MaxSize <= 0 && f.MinWaste <= 0 {
+		return ""
+	}
+	if _, ok := generic(tn); ok {
+		return ""
+	}
+	o := m.orig(name, st)
+	size, _ := collections.SizeAlign(o)
+	if f.MinSize > 0 && size < f.MinSize {
+		return fmt.Sprintf("min_size %d", f.MinSize)
+	}
+	if f.MaxSize > 0 && size > f.MaxSize {
+		return fmt.Sprintf("max_size %d", f.MaxSize)
+	}
+	// padding waste is aligned size
+	// without all non pad fields sizes
+	waste := size
+	for _, field := range o.Fields {
+		if !collections.IsPad(field) {
+			waste -= field.Size
+		}
+	}
+	if f.MinWaste > 0 && waste < f.MinWaste {
+		return fmt.Sprintf("min_waste %d", f.MinWaste)
+	}
+	return ""
+}
+
+// rmatch checks if name
+// matches any of regexps
+func rmatch(rs []*regexp.Regexp, name string) bool {
+	for _, r := range rs {
+		if r.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// fmatch checks if location or
+// its base name matches any of globs
+func fmatch(globs []string, loc string) bool {
+	for _, glob := range globs {
+		if ok, _ := filepath.Match(glob, loc); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(glob, filepath.Base(loc)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// dmatch checks if any of directives
+// has any of provided names
+func dmatch(names []string, dirs []string) bool {
+	for _, dir := range dirs {
+		for _, name := range names {
+			if body := strings.TrimPrefix(dir, name); body != dir &&
+				(body == "" || strings.HasPrefix(body, " ")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// quote helps to format list of
+// filter values as quoted list
+func quote(vals []string) string {
+	qs := make([]string, 0, len(vals))
+	for _, v := range vals {
+		qs = append(qs, fmt.Sprintf("%q", v))
+	}
+	return strings.Join(qs, ", ")
+}
+
+// filtersKey defines context key
+// for structures selection filters
+type filtersKey struct{}
+
+// WithFilters attaches structures selection
+// filters to visiting context
+func WithFilters(ctx context.Context, f Filters) context.Context {
+	return context.WithValue(ctx, filtersKey{}, f)
+}
+
+// filters returns structures selection
+// filters from visiting context if any
+func filters(ctx context.Context) Filters {
+	f, _ := ctx.Value(filtersKey{}).(Filters)
+	return f
+}
+
+// wskips helps to write structures skipped
+// by selection filters to skips writer
+// next to other walker results
+func wskips(ss collections.Skips, writer gopium.Writer) error {
+	// skip empty writes
+	if len(ss) == 0 || writer == nil {
+		return nil
+	}
+	// use skips locations
+	// to find results root category
+	h := collections.NewHierarchic("")
+	for _, s := range ss {
+		h.Push(s.Struct, s.Loc, gopium.Struct{})
+	}
+	loc := filepath.Join(h.Rcat(), gopium.NAME)
+	// apply formatter
+	buf, err := fmtio.SkipsMdt(ss)
+	// in case any error happened
+	// in formatter return error back
+	if err != nil {
+		return kind(gopium.ErrFormat, err, loc)
+	}
+	// generate writer
+	wc, err := writer.Generate(loc)
+	if err != nil {
+		return kind(gopium.ErrWrite, err, loc)
+	}
+	// write results and close writer
+	// in case any error happened
+	// in writer return error
+	if _, err := wc.Write(buf); err != nil {
+		return kind(gopium.ErrWrite, err, loc)
+	}
+	return kind(gopium.ErrWrite, wc.Close(), loc)
+}
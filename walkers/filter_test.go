@@ -0,0 +1,236 @@
+package walkers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestFilters(t *testing.T) {
+	// prepare
+	src := `
+package pkg
+
+type A struct {
+	a bool
+	b int64
+	c bool
+}
+
+type b struct {
+	x int64
+}
+
+type E[T any] struct {
+	t T
+}
+`
+	gsrc := `
+package pkg
+
+//gopium:budget size=16
+type C struct {
+	a bool
+	b int64
+}
+
+type TestD struct {
+	a A
+	_ [8]byte ` + "`gopium:\"pad\"`" + `
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "pkg/file.go", src, parser.ParseComments)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	gfile, err := parser.ParseFile(fset, "pkg/gen_file.go", gsrc, parser.ParseComments)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	files := []*ast.File{file, gfile}
+	pkg, err := (&types.Config{Sizes: types.SizesFor("gc", "amd64")}).Check("pkg", fset, files, nil)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	p := typepkg.ParserXToolPackage{Package: &packages.Package{PkgPath: "pkg", Types: pkg, Fset: fset, Syntax: files}}
+	m, err := typepkg.NewMavenGoTypes("gc", "amd64", 64, 64, 64)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	pck, err := strategies.Builder{}.Build(strategies.Pack)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	table := map[string]struct {
+		f     Filters
+		skips map[string]string
+	}{
+		"empty filters should skip nothing": {
+			skips: map[string]string{"A": "", "b": "", "E": "", "C": "", "TestD": ""},
+		},
+		"include and exclude filters should skip structs by names": {
+			f: Filters{
+				Include: []*regexp.Regexp{regexp.MustCompile(`^[A-C]`), regexp.MustCompile(`^Test`)},
+				Exclude: []*regexp.Regexp{regexp.MustCompile(`^B`), regexp.MustCompile(`D$`)},
+			},
+			skips: map[string]string{
+				"A":     "",
+				"b":     `include "^[A-C]", "^Test"`,
+				"E":     `include "^[A-C]", "^Test"`,
+				"C":     "",
+				"TestD": `exclude "D$"`,
+			},
+		},
+		"file filters should skip structs by file globs": {
+			f: Filters{Files: []string{"gen_*.go", "other/*.go"}},
+			skips: map[string]string{
+				"A":     `file "gen_*.go", "other/*.go"`,
+				"b":     `file "gen_*.go", "other/*.go"`,
+				"E":     `file "gen_*.go", "other/*.go"`,
+				"C":     "",
+				"TestD": "",
+			},
+		},
+		"exported and directive filters should skip structs by exported names and directives": {
+			f: Filters{Exported: true, Directives: []string{"budget"}},
+			skips: map[string]string{
+				"A":     `directive "budget"`,
+				"b":     "exported",
+				"E":     `directive "budget"`,
+				"C":     "",
+				"TestD": `directive "budget"`,
+			},
+		},
+		"size filters should skip non generic structs by sizes": {
+			f: Filters{MinSize: 16, MaxSize: 24},
+			skips: map[string]string{
+				"A":     "",
+				"b":     "min_size 16",
+				"E":     "",
+				"C":     "",
+				"TestD": "max_size 24",
+			},
+		},
+		"waste filters should skip non generic structs by padding waste": {
+			f: Filters{MinWaste: 8},
+			skips: map[string]string{
+				"A":     "",
+				"b":     "min_waste 8",
+				"E":     "",
+				"C":     "min_waste 8",
+				"TestD": "",
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			ctx := WithFilters(context.Background(), tcase.f)
+			_, loc, err := p.ParseTypes(ctx)
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to %v", err, nil)
+			}
+			ch := make(appliedCh)
+			// exec
+			go with(m, loc, false).visit(regexp.MustCompile(`.*`), pck, ch, false)(ctx, pkg.Scope())
+			skips := make(map[string]string)
+			for applied := range ch {
+				if !reflect.DeepEqual(applied.Err, nil) {
+					t.Fatalf("actual %v doesn't equal to %v", applied.Err, nil)
+				}
+				skips[collections.Local(applied.ID)] = applied.Skip
+			}
+			// check
+			if !reflect.DeepEqual(skips, tcase.skips) {
+				t.Errorf("actual %v doesn't equal to expected %v", skips, tcase.skips)
+			}
+		})
+	}
+}
+
+func TestWskips(t *testing.T) {
+	// prepare
+	ss := collections.Skips{
+		{Struct: "B", Loc: "pkg/file.go", Filter: "exported"},
+		{Struct: "A", Loc: "pkg/file.go", Filter: "min_size 8"},
+	}
+	table := map[string]struct {
+		ss  collections.Skips
+		w   *mocks.Writer
+		sts map[string][]byte
+		err error
+	}{
+		"empty skips should write nothing": {
+			w:   &mocks.Writer{},
+			sts: map[string][]byte{},
+		},
+		"skips should be written next to results": {
+			ss: ss,
+			w:  &mocks.Writer{},
+			sts: map[string][]byte{
+				"pkg/gopium": []byte(`
+| Struct Key | Struct Location | Skipped By Filter |
+| :---: | :---: | :---: |
+| A | pkg/file.go | min_size 8 |
+| B | pkg/file.go | exported |
+`),
+			},
+		},
+		"skips should write nothing on writer error": {
+			ss:  ss,
+			w:   &mocks.Writer{Gerr: errors.New("test-1")},
+			sts: map[string][]byte{},
+			err: gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-1"), Pos: "pkg/gopium"},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			err := wskips(tcase.ss, tcase.w)
+			// check
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// process checks only on success
+			if tcase.err == nil {
+				for id, rwc := range tcase.w.RWCs {
+					st, ok := tcase.sts[id]
+					if !ok {
+						t.Errorf("actual %v doesn't equal to expected %v", id, "")
+						continue
+					}
+					var buf bytes.Buffer
+					if _, err := buf.ReadFrom(rwc); !reflect.DeepEqual(err, nil) {
+						t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+					}
+					actual := strings.Trim(buf.String(), "\n")
+					expected := strings.Trim(string(st), "\n")
+					if !reflect.DeepEqual(actual, expected) {
+						t.Errorf("id %v actual %v doesn't equal to expected %v", id, actual, expected)
+					}
+					delete(tcase.sts, id)
+				}
+				if !reflect.DeepEqual(tcase.sts, map[string][]byte{}) {
+					t.Errorf("actual %v doesn't equal to expected %v", tcase.sts, map[string][]byte{})
+				}
+			}
+		})
+	}
+}
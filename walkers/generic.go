@@ -0,0 +1,444 @@
+package walkers
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// worst defines worst case type arguments
+// that are considered for unconstrained
+// type parameters, as the smallest
+// and the largest alignment types
+var worst = []types.Type{
+	types.Typ[types.Uint8],
+	types.Typ[types.Complex128],
+}
+
+// wlimit defines max number of worst case
+// instantiations considered for single struct
+const wlimit = 16
+
+// generic checks if type name is
+// generic struct declaration `type A[T any] struct{}`
+// and returns its origin named type
+func generic(tn *types.TypeName) (*types.Named, bool) {
+	named, ok := tn.Type().(*types.Named)
+	return named, ok && named.TypeParams().Len() > 0
+}
+
+// vgeneric helps to apply strategy
+// to generic struct declaration,
+// strategy is applied to each considered instantiation
+// and the first result which reordering is beneficial
+// or neutral for all considered instantiations is picked,
+// otherwise original struct is kept as is,
+// per instantiation layouts of the picked result
+// are attached to applied instantiations
+func (m *maven) vgeneric(ctx context.Context, stg gopium.Strategy, tn *types.TypeName, origin *types.Named, id, loc string) applied {
+	// collect considered instantiations
+	insts, err := m.considered(tn, origin)
+	if err != nil {
+		return applied{Err: gopium.Error{Kind: gopium.ErrParse, Err: err, ID: id, Pos: m.pos(tn, loc)}}
+	}
+	// apply strategy to each instantiation
+	dirs := m.loc.Directives(tn.Pos())
+	sts := make([]gopium.Struct, 0, len(insts))
+	rts := make([]gopium.Struct, 0, len(insts))
+	sss := make([]collections.Stages, 0, len(insts))
+	dss := make([]collections.Diagnostics, 0, len(insts))
+	for _, inst := range insts {
+		o := m.enum(iname(tn, inst), inst.Underlying().(*types.Struct))
+		o.Directives = dirs
+		tctx, stages := tracer(gopium.WithLoc(ctx, loc), iid(id, tn, o), loc)
+		dctx, diags := diagnoser(tctx, iid(id, tn, o), loc)
+		r, err := nest(dctx, stg, o)
+		if err != nil {
+			return applied{Err: gopium.Error{Kind: gopium.ErrStrategy, Err: err, ID: id, Pos: m.pos(tn, loc)}}
+		}
+		sts = append(sts, o)
+		rts = append(rts, r)
+		sss = append(sss, stages())
+		dss = append(dss, diags())
+	}
+	// pick the first result which reordering
+	// doesn't grow any instantiation size
+	picked := -1
+	for c := range rts {
+		beneficial := true
+		for j := range sts {
+			psize, _ := collections.SizeAlign(project(rts[c], sts[j], false))
+			osize, _ := collections.SizeAlign(sts[j])
+			beneficial = beneficial && psize <= osize
+		}
+		if beneficial {
+			picked = c
+			break
+		}
+	}
+	// in case nothing was picked
+	// keep the first instantiation as is
+	a := applied{O: sts[0], R: collections.CopyStruct(sts[0])}
+	if picked >= 0 {
+		a.O, a.R = sts[picked], rts[picked]
+	}
+	a.O.Name, a.R.Name = tn.Name(), tn.Name()
+	// attach per instantiation layouts,
+	// traced stages and diagnostics
+	for i, o := range sts {
+		r := collections.CopyStruct(o)
+		if picked >= 0 {
+			r = project(rts[picked], o, true)
+		}
+		a.Insts = append(a.Insts, applied{
+			ID:     iid(id, tn, o),
+			Loc:    loc,
+			O:      o,
+			R:      r,
+			Stages: sss[i],
+			Diags:  dss[i],
+		})
+	}
+	return a
+}
+
+// iid helps to build generic struct
+// instantiation identity from struct identity
+// and instantiation struct name `id[int8]`
+func iid(id string, tn *types.TypeName, o gopium.Struct) string {
+	return fmt.Sprintf("%s%s", id, strings.TrimPrefix(o.Name, tn.Name()))
+}
+
+// considered helps to collect instantiations
+// of generic struct that should be considered
+// - user specified by `//gopium:instance int64, string` directives
+// - or all concrete instantiations used inside the package
+// - or worst case instantiations built from type parameters constraints
+func (m *maven) considered(tn *types.TypeName, origin *types.Named) ([]*types.Named, error) {
+	// collect user specified instantiations
+	insts := make([]*types.Named, 0, 1)
+	for _, dir := range m.loc.Directives(tn.Pos()) {
+		body := strings.TrimPrefix(dir, "instance")
+		if body == dir || (body != "" && !strings.HasPrefix(body, " ")) {
+			continue
+		}
+		args, err := targs(tn.Pkg(), body)
+		if err != nil {
+			return nil, fmt.Errorf("directive %q of structure %q can't be parsed %w", dir, tn.Name(), err)
+		}
+		inst, err := types.Instantiate(nil, origin, args, true)
+		if err != nil {
+			return nil, fmt.Errorf("directive %q of structure %q can't be instantiated %w", dir, tn.Name(), err)
+		}
+		insts = append(insts, inst.(*types.Named))
+	}
+	if len(insts) > 0 {
+		return insts, nil
+	}
+	// use package instantiations if any
+	if insts := m.instances(origin); len(insts) > 0 {
+		return insts, nil
+	}
+	// otherwise build worst case instantiations
+	// from cartesian product of type parameters candidates
+	tparams := origin.TypeParams()
+	combs := [][]types.Type{{}}
+	for i := 0; i < tparams.Len(); i++ {
+		cands := candidates(tparams.At(i))
+		ncombs := make([][]types.Type, 0, len(combs)*len(cands))
+		for _, comb := range combs {
+			for _, cand := range cands {
+				if len(ncombs) < wlimit {
+					ncomb := append(append([]types.Type{}, comb...), cand)
+					ncombs = append(ncombs, ncomb)
+				}
+			}
+		}
+		combs = ncombs
+	}
+	for _, comb := range combs {
+		// worst case instantiations
+		// aren't validated against constraints
+		// as only their layouts matter
+		inst, err := types.Instantiate(nil, origin, comb, false)
+		if err != nil {
+			return nil, fmt.Errorf("structure %q can't be instantiated %w", tn.Name(), err)
+		}
+		insts = append(insts, inst.(*types.Named))
+	}
+	return insts, nil
+}
+
+// instances helps to get all concrete instantiations
+// of generic named type used inside its package,
+// package instantiations are collected once
+// for all package scopes declarations
+// and type checker recorded instantiations
+func (m *maven) instances(origin *types.Named) []*types.Named {
+	m.once.Do(func() {
+		m.insts = collect(origin.Obj().Pkg(), m.loc.Instances())
+	})
+	return m.insts[origin.Obj()]
+}
+
+// collect helps to collect all concrete
+// generic instantiations used inside the package
+// scope and its children scopes declarations
+// and recorded by type checker instantiations
+// of package own generic structs including
+// expression only ones like `f(T[int]{})`
+// grouped by their generic type names
+func collect(pkg *types.Package, recorded []*types.Named) map[*types.TypeName][]*types.Named {
+	insts := make(map[*types.TypeName][]*types.Named)
+	seen := make(map[string]struct{})
+	// inspect defines recursive type
+	// inspecting helper that finds
+	// all generic instantiations inside type
+	var inspect func(t types.Type)
+	inspect = func(t types.Type) {
+		switch tp := t.(type) {
+		case *types.Named:
+			// only instantiations are inspected
+			// as all other named types are
+			// inspected by their declarations
+			if tp.TypeArgs().Len() == 0 {
+				return
+			}
+			key := tp.String()
+			if _, ok := seen[key]; ok {
+				return
+			}
+			seen[key] = struct{}{}
+			args := tp.TypeArgs()
+			for i := 0; i < args.Len(); i++ {
+				inspect(args.At(i))
+			}
+			if concrete(tp) {
+				obj := tp.Origin().Obj()
+				insts[obj] = append(insts[obj], tp)
+			}
+			inspect(tp.Underlying())
+		case *types.Pointer:
+			inspect(tp.Elem())
+		case *types.Slice:
+			inspect(tp.Elem())
+		case *types.Array:
+			inspect(tp.Elem())
+		case *types.Chan:
+			inspect(tp.Elem())
+		case *types.Map:
+			inspect(tp.Key())
+			inspect(tp.Elem())
+		case *types.Signature:
+			inspect(tp.Params())
+			inspect(tp.Results())
+		case *types.Tuple:
+			for i := 0; i < tp.Len(); i++ {
+				inspect(tp.At(i).Type())
+			}
+		case *types.Struct:
+			for i := 0; i < tp.NumFields(); i++ {
+				inspect(tp.Field(i).Type())
+			}
+		case *types.Interface:
+			for i := 0; i < tp.NumExplicitMethods(); i++ {
+				inspect(tp.ExplicitMethod(i).Type())
+			}
+		}
+	}
+	// walk defines recursive scope
+	// walking helper that inspects
+	// all scope objects types
+	var walk func(s *types.Scope)
+	walk = func(s *types.Scope) {
+		for _, name := range s.Names() {
+			obj := s.Lookup(name)
+			inspect(obj.Type())
+			// inspect type declarations
+			// underlying types and methods
+			if tn, ok := obj.(*types.TypeName); ok && !tn.IsAlias() {
+				inspect(tn.Type().Underlying())
+				if named, ok := tn.Type().(*types.Named); ok {
+					for i := 0; i < named.NumMethods(); i++ {
+						inspect(named.Method(i).Type())
+					}
+				}
+			}
+		}
+		for i := 0; i < s.NumChildren(); i++ {
+			walk(s.Child(i))
+		}
+	}
+	walk(pkg.Scope())
+	// inspect recorded instantiations
+	// of package own generic structs
+	for _, named := range recorded {
+		obj := named.Origin().Obj()
+		if _, ok := named.Underlying().(*types.Struct); ok && obj.Pkg() == pkg {
+			inspect(named)
+		}
+	}
+	// sort instantiations by their names
+	for _, named := range insts {
+		sort.SliceStable(named, func(i, j int) bool {
+			return named[i].String() < named[j].String()
+		})
+	}
+	return insts
+}
+
+// concrete checks that type doesn't
+// depend on any type parameters
+func concrete(t types.Type) bool {
+	switch tp := t.(type) {
+	case *types.TypeParam:
+		return false
+	case *types.Named:
+		args := tp.TypeArgs()
+		for i := 0; i < args.Len(); i++ {
+			if !concrete(args.At(i)) {
+				return false
+			}
+		}
+	case *types.Pointer:
+		return concrete(tp.Elem())
+	case *types.Slice:
+		return concrete(tp.Elem())
+	case *types.Array:
+		return concrete(tp.Elem())
+	case *types.Chan:
+		return concrete(tp.Elem())
+	case *types.Map:
+		return concrete(tp.Key()) && concrete(tp.Elem())
+	case *types.Signature:
+		return concrete(tp.Params()) && concrete(tp.Results())
+	case *types.Tuple:
+		for i := 0; i < tp.Len(); i++ {
+			if !concrete(tp.At(i).Type()) {
+				return false
+			}
+		}
+	case *types.Struct:
+		for i := 0; i < tp.NumFields(); i++ {
+			if !concrete(tp.Field(i).Type()) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// candidates helps to get worst case
+// type arguments candidates for type parameter,
+// union constraint terms are used if any
+// `~int8 | ~int64` otherwise worst case types
+func candidates(tp *types.TypeParam) []types.Type {
+	if iface, ok := tp.Constraint().Underlying().(*types.Interface); ok {
+		for i := 0; i < iface.NumEmbeddeds(); i++ {
+			if union, ok := iface.EmbeddedType(i).(*types.Union); ok {
+				cands := make([]types.Type, 0, union.Len())
+				for j := 0; j < union.Len(); j++ {
+					cands = append(cands, union.Term(j).Type())
+				}
+				return cands
+			}
+		}
+	}
+	return worst
+}
+
+// targs helps to parse comma separated
+// type arguments list `int64, map[string]int`
+// inside package scope
+func targs(pkg *types.Package, body string) ([]types.Type, error) {
+	// split body by top level commas
+	parts := make([]string, 0, 1)
+	depth, last := 0, 0
+	for i, r := range body {
+		switch r {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, body[last:i])
+				last = i + 1
+			}
+		}
+	}
+	parts = append(parts, body[last:])
+	// evaluate each part as type
+	args := make([]types.Type, 0, len(parts))
+	for _, part := range parts {
+		tv, err := types.Eval(token.NewFileSet(), pkg, token.NoPos, strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		if !tv.IsType() {
+			return nil, fmt.Errorf("%q is not a type", strings.TrimSpace(part))
+		}
+		args = append(args, tv.Type)
+	}
+	return args, nil
+}
+
+// iname helps to build instantiation
+// name from type name and type arguments
+// `A[int64, string]`
+func iname(tn *types.TypeName, inst *types.Named) string {
+	args := inst.TypeArgs()
+	names := make([]string, 0, args.Len())
+	for i := 0; i < args.Len(); i++ {
+		names = append(names, types.TypeString(args.At(i), types.RelativeTo(tn.Pkg())))
+	}
+	return fmt.Sprintf("%s[%s]", tn.Name(), strings.Join(names, ", "))
+}
+
+// project helps to project structure result
+// fields order on other structure,
+// fields are matched by their names
+// and keep other structure types and sizes,
+// result pads are optionally kept as is
+func project(r gopium.Struct, o gopium.Struct, pads bool) gopium.Struct {
+	// collect other structure fields
+	fields := make(map[string]gopium.Field, len(o.Fields))
+	blanks := 0
+	for _, f := range o.Fields {
+		name := f.Name
+		if name == "_" {
+			name = fmt.Sprintf("_#%d", blanks)
+			blanks++
+		}
+		fields[name] = f
+	}
+	// project result fields on them
+	p := collections.CopyStruct(r)
+	p.Name = o.Name
+	p.Fields = make([]gopium.Field, 0, len(r.Fields))
+	blanks = 0
+	for _, f := range collections.CopyStruct(r).Fields {
+		if collections.IsPad(f) {
+			if pads {
+				p.Fields = append(p.Fields, f)
+			}
+			continue
+		}
+		name := f.Name
+		if name == "_" {
+			name = fmt.Sprintf("_#%d", blanks)
+			blanks++
+		}
+		if of, ok := fields[name]; ok {
+			f.Type, f.Size, f.Align, f.Ptr, f.Nested = of.Type, of.Size, of.Align, of.Ptr, of.Nested
+			p.Fields = append(p.Fields, f)
+		}
+	}
+	return p
+}
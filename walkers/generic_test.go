@@ -0,0 +1,308 @@
+package walkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
+)
+
+func TestVgeneric(t *testing.T) {
+	// prepare
+	src := `
+package generic
+
+type G[T any] struct {
+	a bool
+	t T
+	b bool
+}
+
+type H[T any] struct {
+	a T
+	b int16
+	c int8
+}
+
+type W[T ~int8 | ~int64] struct {
+	a bool
+	t T
+}
+
+type X[T any] struct {
+	t T
+}
+
+var g G[int64]
+var h1 H[int8]
+var h2 []H[int64]
+
+func f(*G[string]) {}
+
+type E[T any] struct {
+	a bool
+	t T
+	b bool
+}
+
+func use(any) {}
+
+var _ = E[uint16]{}
+
+func e() {
+	use(E[int32]{})
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "generic.go", src, 0)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	info := &types.Info{Instances: make(map[*ast.Ident]types.Instance)}
+	pkg, err := (&types.Config{}).Check("generic", fset, []*ast.File{file}, info)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	exp, err := typepkg.NewMavenGoTypes("gc", "amd64")
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	// prepare expected wrapped instantiation errors
+	_, perr := targs(pkg, " unknown")
+	w := pkg.Scope().Lookup("W").Type().(*types.Named)
+	_, ierr := types.Instantiate(nil, w, []types.Type{types.Typ[types.String]}, true)
+	fields := func(names ...string) []gopium.Field {
+		fields := make([]gopium.Field, 0, len(names))
+		for _, name := range names {
+			fields = append(fields, gopium.Field{Name: name})
+		}
+		return fields
+	}
+	table := map[string]struct {
+		name  string
+		dirs  []string
+		stg   gopium.Strategy
+		r     []string
+		insts map[string][]string
+		err   error
+		terr  bool
+	}{
+		"generic struct should be applied to all package instantiations": {
+			name: "G",
+			stg:  &mocks.Strategy{R: gopium.Struct{Fields: fields("t", "a", "b")}},
+			r:    []string{"t", "a", "b"},
+			insts: map[string][]string{
+				"id[int64]":  {"t", "a", "b"},
+				"id[string]": {"t", "a", "b"},
+			},
+		},
+		"generic struct should be applied to expressions only instantiations": {
+			name: "E",
+			stg:  &mocks.Strategy{R: gopium.Struct{Fields: fields("t", "a", "b")}},
+			r:    []string{"t", "a", "b"},
+			insts: map[string][]string{
+				"id[int32]":  {"t", "a", "b"},
+				"id[uint16]": {"t", "a", "b"},
+			},
+		},
+		"generic struct should be kept as is on harmful reordering": {
+			name: "H",
+			stg:  &mocks.Strategy{R: gopium.Struct{Fields: fields("c", "a", "b")}},
+			r:    []string{"a", "b", "c"},
+			insts: map[string][]string{
+				"id[int64]": {"a", "b", "c"},
+				"id[int8]":  {"a", "b", "c"},
+			},
+		},
+		"generic struct should be applied to user specified instantiations": {
+			name: "H",
+			dirs: []string{"instance int8", "instance int16"},
+			stg:  &mocks.Strategy{R: gopium.Struct{Fields: fields("c", "a", "b")}},
+			r:    []string{"c", "a", "b"},
+			insts: map[string][]string{
+				"id[int16]": {"c", "a", "b"},
+				"id[int8]":  {"c", "a", "b"},
+			},
+		},
+		"generic struct should be applied to constraint worst case instantiations": {
+			name: "W",
+			stg:  &mocks.Strategy{R: gopium.Struct{Fields: fields("t", "a")}},
+			r:    []string{"t", "a"},
+			insts: map[string][]string{
+				"id[int64]": {"t", "a"},
+				"id[int8]":  {"t", "a"},
+			},
+		},
+		"generic struct should be applied to default worst case instantiations": {
+			name: "X",
+			stg:  &mocks.Strategy{R: gopium.Struct{Fields: fields("t")}},
+			r:    []string{"t"},
+			insts: map[string][]string{
+				"id[complex128]": {"t"},
+				"id[uint8]":      {"t"},
+			},
+		},
+		"generic struct should return error on invalid user specified instantiations": {
+			name: "W",
+			dirs: []string{"instance string"},
+			stg:  &mocks.Strategy{},
+			err:  gopium.Error{Kind: gopium.ErrParse, Err: fmt.Errorf("directive %q of structure %q can't be instantiated %w", "instance string", "W", ierr), ID: "id", Pos: "loc"},
+		},
+		"generic struct should return error on unknown user specified instantiations": {
+			name: "X",
+			dirs: []string{"instance unknown"},
+			stg:  &mocks.Strategy{},
+			err:  gopium.Error{Kind: gopium.ErrParse, Err: fmt.Errorf("directive %q of structure %q can't be parsed %w", "instance unknown", "X", perr), ID: "id", Pos: "loc"},
+			terr: true,
+		},
+		"generic struct should return error on strategy error": {
+			name: "G",
+			stg:  &mocks.Strategy{Err: errors.New("test-1")},
+			err:  gopium.Error{Kind: gopium.ErrStrategy, Err: errors.New("test-1"), ID: "id", Pos: "loc"},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			tn := pkg.Scope().Lookup(tcase.name).(*types.TypeName)
+			m := &maven{
+				exp: exp,
+				loc: mocks.Locator{
+					Insts: typepkg.NewLocator(fset).Info(info).Instances(),
+					Poses: map[token.Pos]mocks.Pos{tn.Pos(): {Directives: tcase.dirs}},
+				},
+				ref: collections.NewReference(false),
+			}
+			origin, ok := generic(tn)
+			if !reflect.DeepEqual(ok, true) {
+				t.Fatalf("actual %v doesn't equal to %v", ok, true)
+			}
+			// exec
+			a := m.vgeneric(context.Background(), tcase.stg, tn, origin, "id", "loc")
+			// check
+			// type checker errors carry their own file sets
+			// so they are compared by messages and types
+			var terr types.Error
+			switch {
+			case tcase.terr && !errors.As(a.Err, &terr):
+				t.Errorf("actual %v doesn't equal to expected %v", a.Err, tcase.err)
+			case tcase.terr && !reflect.DeepEqual(a.Err.Error(), tcase.err.Error()):
+				t.Errorf("actual %v doesn't equal to expected %v", a.Err, tcase.err)
+			case !tcase.terr && !reflect.DeepEqual(a.Err, tcase.err):
+				t.Errorf("actual %v doesn't equal to expected %v", a.Err, tcase.err)
+			}
+			if tcase.err != nil {
+				return
+			}
+			if !reflect.DeepEqual(a.R.Name, tcase.name) {
+				t.Errorf("actual %v doesn't equal to expected %v", a.R.Name, tcase.name)
+			}
+			if r := names(a.R); !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			insts := make(map[string][]string, len(a.Insts))
+			for _, inst := range a.Insts {
+				insts[inst.ID] = names(inst.R)
+			}
+			if !reflect.DeepEqual(insts, tcase.insts) {
+				t.Errorf("actual %v doesn't equal to expected %v", insts, tcase.insts)
+			}
+		})
+	}
+}
+
+func TestProject(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		r    gopium.Struct
+		o    gopium.Struct
+		pads bool
+		p    gopium.Struct
+	}{
+		"empty structs should be projected to empty struct": {
+			p: gopium.Struct{Fields: []gopium.Field{}},
+		},
+		"result should be projected on other struct without pads": {
+			r: gopium.Struct{
+				Name: "A[int8]",
+				Fields: []gopium.Field{
+					{Name: "b", Type: "int8", Size: 1, Align: 1},
+					collections.PadField(7),
+					{Name: "a", Type: "int8", Size: 1, Align: 1, Tag: "tag"},
+				},
+			},
+			o: gopium.Struct{
+				Name: "A[int64]",
+				Fields: []gopium.Field{
+					{Name: "a", Type: "int64", Size: 8, Align: 8},
+					{Name: "b", Type: "int8", Size: 1, Align: 1},
+				},
+			},
+			p: gopium.Struct{
+				Name: "A[int64]",
+				Fields: []gopium.Field{
+					{Name: "b", Type: "int8", Size: 1, Align: 1},
+					{Name: "a", Type: "int64", Size: 8, Align: 8, Tag: "tag"},
+				},
+			},
+		},
+		"result should be projected on other struct with pads": {
+			r: gopium.Struct{
+				Name: "A[int8]",
+				Fields: []gopium.Field{
+					{Name: "_", Type: "noCopy"},
+					{Name: "b", Type: "int8", Size: 1, Align: 1},
+					collections.PadField(7),
+					{Name: "a", Type: "int8", Size: 1, Align: 1},
+				},
+			},
+			o: gopium.Struct{
+				Name: "A[int64]",
+				Fields: []gopium.Field{
+					{Name: "_", Type: "noCopy"},
+					{Name: "a", Type: "int64", Size: 8, Align: 8},
+					{Name: "b", Type: "int8", Size: 1, Align: 1},
+				},
+			},
+			pads: true,
+			p: gopium.Struct{
+				Name: "A[int64]",
+				Fields: []gopium.Field{
+					{Name: "_", Type: "noCopy"},
+					{Name: "b", Type: "int8", Size: 1, Align: 1},
+					collections.PadField(7),
+					{Name: "a", Type: "int64", Size: 8, Align: 8},
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			p := project(tcase.r, tcase.o, tcase.pads)
+			// check
+			if !reflect.DeepEqual(p, tcase.p) {
+				t.Errorf("actual %v doesn't equal to expected %v", p, tcase.p)
+			}
+		})
+	}
+}
+
+// names helps to collect struct fields names
+func names(st gopium.Struct) []string {
+	names := make([]string, 0, len(st.Fields))
+	for _, f := range st.Fields {
+		names = append(names, f.Name)
+	}
+	return names
+}
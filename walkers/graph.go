@@ -0,0 +1,118 @@
+package walkers
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// graph defines structures type dependency graph
+// of single scope that is built before visiting,
+// it keeps ids of structures that each structure
+// depends on by value through its fields
+type graph struct {
+	nodes []string            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	deps  map[string][]string `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
+
+// add adds structure node with its
+// dependencies ids to the graph
+func (g *graph) add(id string, deps []string) {
+	if g.deps == nil {
+		g.deps = make(map[string][]string)
+	}
+	if _, ok := g.deps[id]; !ok {
+		g.nodes = append(g.nodes, id)
+	}
+	g.deps[id] = deps
+}
+
+// resolved returns dependencies ids of structure node
+// that are resolved inside the graph,
+// unresolved dependencies like structures
+// filtered out by regex or declared on other scopes
+// are skipped as their layouts are either
+// already known or original layouts are used
+func (g graph) resolved(id string) []string {
+	deps := make([]string, 0, len(g.deps[id]))
+	for _, dep := range g.deps[id] {
+		if _, ok := g.deps[dep]; ok {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// sort sorts graph structures nodes topologically
+// so each structure is placed after all structures
+// it depends on, in case graph has any cyclic references
+// error with all structures inside cycles is returned
+func (g graph) sort() ([]string, error) {
+	// calculate number of resolved
+	// dependencies for each node
+	// and reversed dependants edges
+	indeg := make(map[string]int, len(g.nodes))
+	dependants := make(map[string][]string, len(g.nodes))
+	for _, id := range g.nodes {
+		deps := g.resolved(id)
+		indeg[id] = len(deps)
+		for _, dep := range deps {
+			dependants[dep] = append(dependants[dep], id)
+		}
+	}
+	// start from nodes without dependencies
+	// in graph nodes order and apply
+	// kahn's algorithm on the rest
+	order := make([]string, 0, len(g.nodes))
+	for _, id := range g.nodes {
+		if indeg[id] == 0 {
+			order = append(order, id)
+		}
+	}
+	for i := 0; i < len(order); i++ {
+		for _, id := range dependants[order[i]] {
+			indeg[id]--
+			if indeg[id] == 0 {
+				order = append(order, id)
+			}
+		}
+	}
+	// all nodes that are left unsorted
+	// are either inside cycles or depend on them
+	if len(order) < len(g.nodes) {
+		cycle := make([]string, 0, len(g.nodes)-len(order))
+		for _, id := range g.nodes {
+			if indeg[id] > 0 {
+				cycle = append(cycle, id)
+			}
+		}
+		sort.Strings(cycle)
+		return nil, fmt.Errorf("structures have cyclic references %s", strings.Join(cycle, ", "))
+	}
+	return order, nil
+}
+
+// named helps to collect all named
+// non generic structures that provided type
+// depends on by value through arrays
+// and anonymous structs fields
+func named(t types.Type) []*types.Named {
+	switch tp := t.(type) {
+	case *types.Array:
+		return named(tp.Elem())
+	case *types.Struct:
+		var ns []*types.Named
+		for i := 0; i < tp.NumFields(); i++ {
+			ns = append(ns, named(tp.Field(i).Type())...)
+		}
+		return ns
+	case *types.Named:
+		// generic instantiations don't share layouts
+		// so they aren't structures dependencies
+		if _, ok := tp.Underlying().(*types.Struct); ok && tp.TypeArgs().Len() == 0 {
+			return []*types.Named{tp}
+		}
+	}
+	return nil
+}
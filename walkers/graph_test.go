@@ -0,0 +1,131 @@
+package walkers
+
+import (
+	"errors"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+func TestGraphSort(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		nodes map[string][]string
+		ids   []string
+		order []string
+		err   error
+	}{
+		"empty graph should return empty order": {
+			order: []string{},
+		},
+		"graph without dependencies should return nodes order": {
+			nodes: map[string][]string{"A": nil, "B": nil, "C": nil},
+			ids:   []string{"C", "A", "B"},
+			order: []string{"C", "A", "B"},
+		},
+		"graph with dependencies should return topological order": {
+			nodes: map[string][]string{
+				"A": {"B", "C"},
+				"B": {"C", "C"},
+				"C": nil,
+				"D": {"A"},
+			},
+			ids:   []string{"D", "A", "B", "C"},
+			order: []string{"C", "B", "A", "D"},
+		},
+		"graph with unresolved dependencies should skip them": {
+			nodes: map[string][]string{
+				"A": {"X"},
+				"B": {"A", "Y"},
+			},
+			ids:   []string{"B", "A"},
+			order: []string{"A", "B"},
+		},
+		"graph with cyclic dependencies should return error": {
+			nodes: map[string][]string{
+				"A": {"B"},
+				"B": {"C"},
+				"C": {"A"},
+				"D": {"C"},
+				"E": nil,
+			},
+			ids: []string{"A", "B", "C", "D", "E"},
+			err: errors.New("structures have cyclic references A, B, C, D"),
+		},
+		"graph with self dependency should return error": {
+			nodes: map[string][]string{
+				"A": {"A"},
+			},
+			ids: []string{"A"},
+			err: errors.New("structures have cyclic references A"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			var g graph
+			for _, id := range tcase.ids {
+				g.add(id, tcase.nodes[id])
+			}
+			// exec
+			order, err := g.sort()
+			// check
+			if !reflect.DeepEqual(order, tcase.order) {
+				t.Errorf("actual %v doesn't equal to expected %v", order, tcase.order)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestNamed(t *testing.T) {
+	// prepare
+	st := types.NewStruct([]*types.Var{types.NewVar(token.NoPos, nil, "a", types.Typ[types.Int64])}, nil)
+	a := types.NewNamed(types.NewTypeName(token.NoPos, nil, "A", nil), st, nil)
+	b := types.NewNamed(types.NewTypeName(token.NoPos, nil, "B", nil), st, nil)
+	i := types.NewNamed(types.NewTypeName(token.NoPos, nil, "I", nil), types.Typ[types.Int64], nil)
+	table := map[string]struct {
+		t  types.Type
+		ns []*types.Named
+	}{
+		"basic type should return no dependencies": {
+			t: types.Typ[types.String],
+		},
+		"named non struct type should return no dependencies": {
+			t: i,
+		},
+		"pointer to named struct should return no dependencies": {
+			t: types.NewPointer(a),
+		},
+		"named struct type should return itself": {
+			t:  a,
+			ns: []*types.Named{a},
+		},
+		"array of named struct type should return its element": {
+			t:  types.NewArray(types.NewArray(a, 2), 2),
+			ns: []*types.Named{a},
+		},
+		"anonymous struct should return its fields dependencies": {
+			t: types.NewStruct([]*types.Var{
+				types.NewVar(token.NoPos, nil, "a", a),
+				types.NewVar(token.NoPos, nil, "i", i),
+				types.NewVar(token.NoPos, nil, "s", types.NewSlice(b)),
+				types.NewVar(token.NoPos, nil, "b", types.NewArray(b, 4)),
+			}, nil),
+			ns: []*types.Named{a, b},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			ns := named(tcase.t)
+			// check
+			if !reflect.DeepEqual(ns, tcase.ns) {
+				t.Errorf("actual %v doesn't equal to expected %v", ns, tcase.ns)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package walkers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// Target defines single matrix target configuration
+// that consists of type parser that loads package
+// under target GOOS/GOARCH/tags combination,
+// exposer and optional strategy synced with the same target,
+// visiting strategy is used if target strategy is nil
+type Target struct {
+	Parser   gopium.TypeParser `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Exposer  gopium.Exposer    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Strategy gopium.Strategy   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Name     string            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// matrix defines list of matrix targets
+// that should be visited together
+type matrix []Target
+
+// visit helps to start structures visiting
+// in separate goroutine either on types parser package
+// or in case of any matrix targets on all targets packages,
+// in later case results are unified by structures identity
+func (mx matrix) visit(
+	ctx context.Context,
+	p gopium.TypeParser,
+	exp gopium.Exposer,
+	regex *regexp.Regexp,
+	stg gopium.Strategy,
+	ch appliedCh,
+	deep,
+	bref bool,
+) error {
+	// in case of no matrix targets
+	// just visit parser package
+	if len(mx) == 0 {
+		// use parser to parse types pkg data
+		// we don't care about fset
+		pkg, loc, err := p.ParseTypes(ctx)
+		if err != nil {
+			return kind(gopium.ErrParse, err, "")
+		}
+		// create govisit func
+		// using visit helper
+		// and run it on pkg scope
+		gvisit := with(exp, loc, bref).visit(regex, stg, ch, deep)
+		go gvisit(ctx, pkg.Scope())
+		return nil
+	}
+	// otherwise parse all targets packages first,
+	// targets layouts differ from each other
+	// so they never share module layouts
+	ctx = WithModule(ctx, nil)
+	gvisits := make([]func() appliedCh, 0, len(mx))
+	for _, t := range mx {
+		pkg, loc, err := t.Parser.ParseTypes(ctx)
+		if err != nil {
+			return fmt.Errorf("target %q %w", t.Name, kind(gopium.ErrParse, err, ""))
+		}
+		tstg := stg
+		if t.Strategy != nil {
+			tstg = t.Strategy
+		}
+		prep := with(t.Exposer, loc, bref)
+		gvisits = append(gvisits, func() appliedCh {
+			tch := make(appliedCh)
+			go prep.visit(regex, tstg, tch, deep)(ctx, pkg.Scope())
+			return tch
+		})
+	}
+	// then visit targets packages one by one
+	// and unify collected results by identity
+	go func() {
+		// close the channel
+		// after all results are pushed
+		defer close(ch)
+		ids, sids := make([]string, 0), make([]string, 0)
+		mapplied := make(map[string][]applied)
+		mskipped := make(map[string]applied)
+		fs := fails(ctx)
+		var err error
+		for i, gvisit := range gvisits {
+			// drain target results
+			// even after an error
+			// to finish its visiting,
+			// structs failures are collected
+			// on keep going visiting
+			for a := range gvisit() {
+				if a.Err != nil {
+					if ferr := fs.fail(a.Err); ferr != nil && err == nil {
+						err = ferr
+					}
+					continue
+				}
+				// keep the first target skip
+				// of structure by selection filters
+				if a.Skip != "" {
+					if _, ok := mskipped[a.ID]; !ok {
+						sids = append(sids, a.ID)
+						mskipped[a.ID] = a
+					}
+					continue
+				}
+				if _, ok := mapplied[a.ID]; !ok {
+					ids = append(ids, a.ID)
+					mapplied[a.ID] = make([]applied, len(gvisits))
+				}
+				mapplied[a.ID][i] = a
+			}
+		}
+		if err != nil {
+			ch <- applied{Err: err}
+			return
+		}
+		// structures failed under any
+		// target are never unified
+		// and their failures are pushed instead
+		for _, id := range ids {
+			if !fs.failed(id) {
+				ch <- unify(mapplied[id])
+			}
+		}
+		for _, ferr := range fs.errs {
+			ch <- applied{Err: ferr}
+		}
+		// structures skipped by all
+		// including targets stay skipped
+		for _, id := range sids {
+			if _, ok := mapplied[id]; !ok {
+				ch <- mskipped[id]
+			}
+		}
+	}()
+	return nil
+}
+
+// unify helps to unify structure results
+// from all matrix targets that include it,
+// the first result which is valid under
+// every including target is picked, result
+// is valid under target if its projection
+// with paddings on target original structure
+// isn't bigger than target own result,
+// otherwise original structure is kept as is
+func unify(as []applied) applied {
+	// collect only including targets results,
+	// missing results have empty ids
+	incl := make([]applied, 0, len(as))
+	for _, a := range as {
+		if a.ID != "" {
+			incl = append(incl, a)
+		}
+	}
+	for _, c := range incl {
+		valid := true
+		for _, t := range incl {
+			psize, _ := collections.SizeAlign(project(c.R, t.O, true))
+			rsize, _ := collections.SizeAlign(t.R)
+			valid = valid && psize <= rsize
+		}
+		if valid {
+			return c
+		}
+	}
+	// in case nothing was picked
+	// keep the first result origin as is
+	a := incl[0]
+	a.R = collections.CopyStruct(a.O)
+	a.Insts = append([]applied{}, a.Insts...)
+	for i := range a.Insts {
+		a.Insts[i].R = collections.CopyStruct(a.Insts[i].O)
+	}
+	return a
+}
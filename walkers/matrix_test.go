@@ -0,0 +1,210 @@
+package walkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestMatrixVisit(t *testing.T) {
+	// prepare
+	srcs := map[string]string{
+		"pkg/common.go": `
+package pkg
+
+type A struct {
+	a bool
+	b int64
+}
+`,
+		"pkg/amd64.go": `
+package pkg
+
+type B struct {
+	a bool
+	b int32
+}
+`,
+		"pkg/386.go": `
+package pkg
+
+type C struct {
+	a bool
+	b uintptr
+}
+`,
+	}
+	// target defines helper that type checks
+	// target files and builds matrix target
+	target := func(arch string, salign int64, names ...string) Target {
+		fset := token.NewFileSet()
+		files := make([]*ast.File, 0, len(names))
+		for _, name := range names {
+			file, err := parser.ParseFile(fset, name, srcs[name], parser.ParseComments)
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to %v", err, nil)
+			}
+			files = append(files, file)
+		}
+		pkg, err := (&types.Config{Sizes: types.SizesFor("gc", arch)}).Check("pkg", fset, files, nil)
+		if !reflect.DeepEqual(err, nil) {
+			t.Fatalf("actual %v doesn't equal to %v", err, nil)
+		}
+		m, err := typepkg.NewMavenGoTypes("gc", arch, 64, 64, 64)
+		if !reflect.DeepEqual(err, nil) {
+			t.Fatalf("actual %v doesn't equal to %v", err, nil)
+		}
+		stg, err := strategies.Builder{Curator: mocks.Maven{SAlign: salign}}.Build(strategies.PadSys)
+		if !reflect.DeepEqual(err, nil) {
+			t.Fatalf("actual %v doesn't equal to %v", err, nil)
+		}
+		return Target{
+			Parser:   typepkg.ParserXToolPackage{Package: &packages.Package{Types: pkg, Fset: fset, Syntax: files}},
+			Exposer:  m,
+			Strategy: stg,
+			Name:     "linux/" + arch,
+		}
+	}
+	amd64 := target("amd64", 8, "pkg/common.go", "pkg/amd64.go")
+	i386 := target("386", 4, "pkg/common.go", "pkg/386.go")
+	table := map[string]struct {
+		mx  matrix
+		r   map[string][]string
+		err error
+	}{
+		"matrix should unify structs by identity across targets": {
+			mx: matrix{amd64, i386},
+			r: map[string][]string{
+				"pkg/common.go:A": {"a bool", "_ [3]byte", "b int64"},
+				"pkg/amd64.go:B":  {"a bool", "_ [7]byte", "b int32", "_ [4]byte"},
+				"pkg/386.go:C":    {"a bool", "_ [3]byte", "b uintptr"},
+			},
+		},
+		"matrix should return error on target parser error": {
+			mx:  matrix{amd64, {Parser: mocks.Parser{Typeserr: errors.New("test-1")}, Name: "linux/arm"}},
+			err: fmt.Errorf(`target "linux/arm" %w`, gopium.Error{Kind: gopium.ErrParse, Err: errors.New("test-1")}),
+		},
+		"matrix should return error on target strategy error": {
+			mx: matrix{
+				amd64,
+				{
+					Parser:   i386.Parser,
+					Exposer:  i386.Exposer,
+					Strategy: &mocks.Strategy{Err: errors.New("test-2")},
+					Name:     i386.Name,
+				},
+			},
+			r:   map[string][]string{},
+			err: gopium.Error{Kind: gopium.ErrStrategy, Err: errors.New("test-2")},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			ch := make(appliedCh)
+			err := tcase.mx.visit(context.Background(), nil, nil, regexp.MustCompile(`.*`), nil, ch, false, false)
+			// check
+			if err != nil {
+				if !reflect.DeepEqual(err, tcase.err) {
+					t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+				}
+				return
+			}
+			r := make(map[string][]string)
+			for a := range ch {
+				if a.Err != nil {
+					err = anonymous(a.Err)
+					continue
+				}
+				fields := make([]string, 0, len(a.R.Fields))
+				for _, f := range a.R.Fields {
+					fields = append(fields, f.Name+" "+f.Type)
+				}
+				r[a.Loc+":"+a.R.Name] = fields
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+		})
+	}
+}
+
+func TestUnify(t *testing.T) {
+	// prepare
+	st := func(name string, fields ...gopium.Field) gopium.Struct {
+		return gopium.Struct{Name: name, Fields: fields}
+	}
+	a64 := gopium.Field{Name: "a", Type: "bool", Size: 1, Align: 1}
+	b64 := gopium.Field{Name: "b", Type: "int64", Size: 8, Align: 8}
+	a32 := gopium.Field{Name: "a", Type: "bool", Size: 1, Align: 1}
+	b32 := gopium.Field{Name: "b", Type: "int64", Size: 8, Align: 4}
+	x8 := gopium.Field{Name: "x", Type: "T", Size: 8, Align: 8}
+	x1 := gopium.Field{Name: "x", Type: "T", Size: 1, Align: 1}
+	y8 := gopium.Field{Name: "y", Type: "P", Size: 8, Align: 8}
+	y1 := gopium.Field{Name: "y", Type: "P", Size: 1, Align: 1}
+	z1 := gopium.Field{Name: "z", Type: "bool", Size: 1, Align: 1}
+	table := map[string]struct {
+		as []applied
+		a  applied
+	}{
+		"single target result should be picked as is": {
+			as: []applied{
+				{},
+				{ID: "id", O: st("A", a64, b64), R: st("A", b64, a64)},
+			},
+			a: applied{ID: "id", O: st("A", a64, b64), R: st("A", b64, a64)},
+		},
+		"first result valid under all targets should be picked": {
+			as: []applied{
+				{ID: "id", O: st("A", a64, b64), R: st("A", a64, collections.PadField(7), b64)},
+				{ID: "id", O: st("A", a32, b32), R: st("A", a32, collections.PadField(3), b32)},
+			},
+			a: applied{ID: "id", O: st("A", a32, b32), R: st("A", a32, collections.PadField(3), b32)},
+		},
+		"original struct should be kept if no result is valid under all targets": {
+			as: []applied{
+				{
+					ID:    "id",
+					O:     st("A", y1, z1, x8),
+					R:     st("A", x8, y1, z1),
+					Insts: []applied{{ID: "id[int]", O: st("A[int]", y1, x8, z1), R: st("A[int]", x8, y1, z1)}},
+				},
+				{ID: "id", O: st("A", x1, y8, z1), R: st("A", y8, x1, z1)},
+			},
+			a: applied{
+				ID:    "id",
+				O:     st("A", y1, z1, x8),
+				R:     st("A", y1, z1, x8),
+				Insts: []applied{{ID: "id[int]", O: st("A[int]", y1, x8, z1), R: st("A[int]", y1, x8, z1)}},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			a := unify(tcase.as)
+			// check
+			if !reflect.DeepEqual(a, tcase.a) {
+				t.Errorf("actual %v doesn't equal to expected %v", a, tcase.a)
+			}
+		})
+	}
+}
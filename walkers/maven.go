@@ -1,6 +1,7 @@
 package walkers
 
 import (
+	"fmt"
 	"go/types"
 	"sync"
 
@@ -9,22 +10,30 @@ import (
 )
 
 // sizealign defines data transfer
-// object that holds type pair
-// of size and align vals
+// object that holds type triplet
+// of size, align and ptr vals
+// and flag that shows whether vals
+// came from optimized struct layout
 type sizealign struct {
-	size  int64 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	align int64 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; - 🌺 gopium @1pkg
+	size  int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	align int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	ptr   int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	opt   bool    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_     [7]byte `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
 
 // maven defines visiting helper
 // that aggregates some useful
 // operations on underlying facilities
 type maven struct {
-	store sync.Map               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	exp   gopium.Exposer         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	loc   gopium.Locator         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	ref   *collections.Reference `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_     [48]byte               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	store sync.Map                           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	exp   gopium.Exposer                     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	loc   gopium.Locator                     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	ref   *collections.Reference             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	mod   *Module                            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	insts map[*types.TypeName][]*types.Named `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	once  sync.Once                          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_     [12]byte                           `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 } // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
 
 // has defines struct store id helper
@@ -46,11 +55,23 @@ func (m *maven) has(tn *types.TypeName) (id string, loc string, ok bool) {
 	return id, loc, false
 }
 
+// pos helps to format structure position
+// as file, line and column falling back
+// to structure location if position is unknown
+func (m *maven) pos(tn *types.TypeName, loc string) string {
+	if p := m.loc.Root().Position(tn.Pos()); p.IsValid() {
+		return p.String()
+	}
+	return loc
+}
+
 // enum defines struct enumerating converting helper
 // that goes through all structure fields
 // and uses exposer to expose field DTO
 // for each field and puts them back
-// to resulted struct object
+// to resulted struct object,
+// anonymous struct fields are enumerated
+// recursively to their nested structs
 func (m *maven) enum(name string, st *types.Struct) gopium.Struct {
 	// set structure name
 	r := gopium.Struct{}
@@ -65,19 +86,54 @@ func (m *maven) enum(name string, st *types.Struct) gopium.Struct {
 		// get size and align for field
 		sa := m.refsa(f.Type())
 		// fill field structure
-		r.Fields = append(r.Fields, gopium.Field{
+		field := gopium.Field{
 			Name:     f.Name(),
 			Type:     m.exp.Name(f.Type()),
 			Size:     sa.size,
 			Align:    sa.align,
+			Ptr:      sa.ptr,
 			Tag:      st.Tag(i),
 			Exported: f.Exported(),
 			Embedded: f.Embedded(),
-		})
+		}
+		// enumerate anonymous nested struct
+		// including arrays, slices and pointers elements
+		if nst, ok := nstunwrap(f.Type()); ok {
+			nested := m.enum(fmt.Sprintf("%s.%s", name, f.Name()), nst)
+			field.Nested = &nested
+		}
+		r.Fields = append(r.Fields, field)
 	}
 	return r
 }
 
+// nstunwrap helps to unwrap anonymous struct
+// from arrays, slices and pointers element types
+func nstunwrap(t types.Type) (*types.Struct, bool) {
+	for {
+		switch tp := t.(type) {
+		case *types.Struct:
+			return tp, true
+		case *types.Array:
+			t = tp.Elem()
+		case *types.Slice:
+			t = tp.Elem()
+		case *types.Pointer:
+			t = tp.Elem()
+		default:
+			return nil, false
+		}
+	}
+}
+
+// orig helps to enumerate structure
+// with original layouts of all nested
+// named structures without reference
+func (m *maven) orig(name string, st *types.Struct) gopium.Struct {
+	om := maven{exp: m.exp, loc: m.loc}
+	return om.enum(name, st)
+}
+
 // refsa defines size and align getter
 // with reference helper that uses reference
 // if it has been provided
@@ -89,6 +145,7 @@ func (m *maven) refsa(t types.Type) sizealign {
 		return sizealign{
 			size:  m.exp.Size(t),
 			align: m.exp.Align(t),
+			ptr:   m.exp.Ptr(t),
 		}
 	}
 	// for refsize only named structures
@@ -103,17 +160,25 @@ func (m *maven) refsa(t types.Type) sizealign {
 		}
 		// n > 0
 		sa := m.refsa(tp.Elem())
-		sa.size = collections.Align(sa.size, sa.align)*(n-1) + sa.size
+		stride := collections.Align(sa.size, sa.align)
+		if sa.ptr > 0 {
+			sa.ptr = stride*(n-1) + sa.ptr
+		}
+		sa.size = stride*(n-1) + sa.size
 		return sa
 	case *types.Named:
-		// in case it's not a struct skip it
-		if _, ok := tp.Underlying().(*types.Struct); !ok {
+		// in case it's not a struct
+		// or it's generic instantiation skip it
+		// as instantiations don't share layouts
+		if _, ok := tp.Underlying().(*types.Struct); !ok || tp.TypeArgs().Len() > 0 {
 			break
 		}
 		// get id for named structures
 		id := m.loc.ID(tp.Obj().Pos())
 		// get size of the structure from ref
+		// and mark it as optimized
 		if sa, ok := m.ref.Get(id).(sizealign); ok {
+			sa.opt = true
 			return sa
 		}
 	}
@@ -121,6 +186,7 @@ func (m *maven) refsa(t types.Type) sizealign {
 	return sizealign{
 		size:  m.exp.Size(t),
 		align: m.exp.Align(t),
+		ptr:   m.exp.Ptr(t),
 	}
 }
 
@@ -133,9 +199,60 @@ func (m *maven) refst(name string) func(gopium.Struct) {
 	m.ref.Alloc(name)
 	// return the pushing closure
 	return func(st gopium.Struct) {
-		// calculate structure align and aligned size
+		// calculate structure align, aligned size and ptr
 		stsize, stalign := collections.SizeAlign(st)
-		// set ref key size and align
-		m.ref.Set(name, sizealign{size: stsize, align: stalign})
+		stptr := collections.PtrData(st)
+		// set ref key size, align and ptr
+		m.ref.Set(name, sizealign{size: stsize, align: stalign, ptr: stptr})
+	}
+}
+
+// refids helps to collect ids
+// of all named structures that
+// provided structure depends on by value,
+// in case we don't have a reference
+// no dependencies are collected
+// as original sizes are used anyway
+func (m *maven) refids(st *types.Struct) []string {
+	if m.ref == nil {
+		return nil
+	}
+	ids := make([]string, 0)
+	for _, n := range named(st) {
+		if id := m.loc.ID(n.Obj().Pos()); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// deps helps to collect dependencies
+// of all structure fields on named structures
+// with their sizes and aligns and flag
+// whether they came from optimized layouts,
+// anonymous struct fields are collected
+// recursively with prefixed fields names
+func (m *maven) deps(prefix string, st *types.Struct) []collections.Dependency {
+	var ds []collections.Dependency
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		name := prefix + f.Name()
+		// collect anonymous nested struct
+		// dependencies recursively
+		if nst, ok := f.Type().(*types.Struct); ok {
+			ds = append(ds, m.deps(name+".", nst)...)
+			continue
+		}
+		for _, n := range named(f.Type()) {
+			sa := m.refsa(n)
+			ds = append(ds, collections.Dependency{
+				Field:     name,
+				Type:      m.exp.Name(n),
+				Size:      sa.size,
+				Align:     sa.align,
+				Optimized: sa.opt,
+			})
+		}
 	}
+	return ds
 }
@@ -99,6 +99,26 @@ func TestMavenEnum(t *testing.T) {
 					Size:  240,
 					Align: 20,
 				},
+				"struct{a string; b string; c string}": {
+					Name:  "struct{a string; b string; c string}",
+					Size:  48,
+					Align: 8,
+				},
+				"[2]struct{a string; b string; c string}": {
+					Name:  "[2]struct{a string; b string; c string}",
+					Size:  96,
+					Align: 8,
+				},
+				"[]struct{a string; b string; c string}": {
+					Name:  "[]struct{a string; b string; c string}",
+					Size:  24,
+					Align: 8,
+				},
+				"*[]struct{a string; b string; c string}": {
+					Name:  "*[]struct{a string; b string; c string}",
+					Size:  8,
+					Align: 8,
+				},
 			},
 		},
 		loc: mocks.Locator{
@@ -167,6 +187,99 @@ func TestMavenEnum(t *testing.T) {
 				},
 			},
 		},
+		"anonymous struct type should return expected struct with nested struct": {
+			name: "test-st",
+			tst:  types.NewStruct([]*types.Var{types.NewVar(token.Pos(0), nil, "n", sti)}, nil),
+			st: gopium.Struct{
+				Name: "test-st",
+				Fields: []gopium.Field{
+					{
+						Name:  "n",
+						Type:  "struct{a string; b string; c string}",
+						Size:  48,
+						Align: 8,
+						Nested: &gopium.Struct{
+							Name: "test-st.n",
+							Fields: []gopium.Field{
+								{
+									Name:  "a",
+									Type:  "string",
+									Size:  16,
+									Align: 8,
+								},
+								{
+									Name:  "b",
+									Type:  "string",
+									Size:  16,
+									Align: 8,
+								},
+								{
+									Name:  "c",
+									Type:  "string",
+									Size:  16,
+									Align: 8,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"wrapped anonymous struct types should return expected struct with nested structs": {
+			name: "test-st",
+			tst: types.NewStruct([]*types.Var{
+				types.NewVar(token.Pos(0), nil, "arr", types.NewArray(sti, 2)),
+				types.NewVar(token.Pos(0), nil, "sl", types.NewSlice(sti)),
+				types.NewVar(token.Pos(0), nil, "ptr", types.NewPointer(types.NewSlice(sti))),
+			}, nil),
+			st: gopium.Struct{
+				Name: "test-st",
+				Fields: []gopium.Field{
+					{
+						Name:  "arr",
+						Type:  "[2]struct{a string; b string; c string}",
+						Size:  96,
+						Align: 8,
+						Nested: &gopium.Struct{
+							Name: "test-st.arr",
+							Fields: []gopium.Field{
+								{Name: "a", Type: "string", Size: 16, Align: 8},
+								{Name: "b", Type: "string", Size: 16, Align: 8},
+								{Name: "c", Type: "string", Size: 16, Align: 8},
+							},
+						},
+					},
+					{
+						Name:  "sl",
+						Type:  "[]struct{a string; b string; c string}",
+						Size:  24,
+						Align: 8,
+						Nested: &gopium.Struct{
+							Name: "test-st.sl",
+							Fields: []gopium.Field{
+								{Name: "a", Type: "string", Size: 16, Align: 8},
+								{Name: "b", Type: "string", Size: 16, Align: 8},
+								{Name: "c", Type: "string", Size: 16, Align: 8},
+							},
+						},
+					},
+					{
+						Name:  "ptr",
+						Type:  "*[]struct{a string; b string; c string}",
+						Size:  8,
+						Align: 8,
+						Nested: &gopium.Struct{
+							Name: "test-st.ptr",
+							Fields: []gopium.Field{
+								{Name: "a", Type: "string", Size: 16, Align: 8},
+								{Name: "b", Type: "string", Size: 16, Align: 8},
+								{Name: "c", Type: "string", Size: 16, Align: 8},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for name, tcase := range table {
 		t.Run(name, func(t *testing.T) {
@@ -240,12 +353,12 @@ func TestMavenRefsa(t *testing.T) {
 		},
 		"custom type should return expected size and align with backref": {
 			t:   tp,
-			sa:  sizealign{size: 32, align: 32},
+			sa:  sizealign{size: 32, align: 32, opt: true},
 			ref: ref,
 		},
 		"custom arr type should return expected size and align with backref": {
 			t:   types.NewArray(tp, 10),
-			sa:  sizealign{size: 320, align: 32},
+			sa:  sizealign{size: 320, align: 32, opt: true},
 			ref: ref,
 		},
 		"custom non struct type should return expected size and align with backref": {
@@ -0,0 +1,82 @@
+package walkers
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// Module defines cross packages visiting state
+// shared by module packages visits in import order,
+// it keeps optimized layouts of visited structs
+// by their identities, so dependent packages
+// use optimized layouts of imported structs,
+// and packages structs sizes savings
+type Module struct {
+	ref     *collections.Reference `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	savings collections.Savings    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	mutex   sync.Mutex             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [8]byte                `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; - 🌺 gopium @1pkg
+
+// NewModule creates cross packages
+// visiting state instance
+func NewModule() *Module {
+	return &Module{
+		ref:     collections.NewReference(true),
+		savings: make(collections.Savings),
+	}
+}
+
+// Savings returns copy of collected
+// packages structs sizes savings
+func (mod *Module) Savings() collections.Savings {
+	defer mod.mutex.Unlock()
+	mod.mutex.Lock()
+	savings := make(collections.Savings, len(mod.savings))
+	for pkg, s := range mod.savings {
+		savings[pkg] = s
+	}
+	return savings
+}
+
+// save helps to add struct sizes saving
+// to its package savings, original struct
+// should use original layouts of nested structs
+func (mod *Module) save(id string, o gopium.Struct, r gopium.Struct) {
+	// package path is the first part of id
+	pkg := id
+	if i := strings.Index(id, ":"); i >= 0 {
+		pkg = id[:i]
+	}
+	osize, _ := collections.SizeAlign(o)
+	rsize, _ := collections.SizeAlign(r)
+	defer mod.mutex.Unlock()
+	mod.mutex.Lock()
+	s := mod.savings[pkg]
+	s.Structs++
+	s.Original += osize
+	s.Current += rsize
+	mod.savings[pkg] = s
+}
+
+// modKey defines context key
+// for cross packages visiting state
+type modKey struct{}
+
+// WithModule attaches cross packages visiting state
+// to visiting context, so walkers backref
+// shares structs layouts between packages
+func WithModule(ctx context.Context, mod *Module) context.Context {
+	return context.WithValue(ctx, modKey{}, mod)
+}
+
+// module returns cross packages visiting state
+// from visiting context if any
+func module(ctx context.Context) *Module {
+	mod, _ := ctx.Value(modKey{}).(*Module)
+	return mod
+}
@@ -0,0 +1,171 @@
+package walkers
+
+import (
+	"bytes"
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// importer defines types importer helper
+// that imports only preloaded packages
+type importer map[string]*types.Package
+
+// Import types importer implementation
+func (imp importer) Import(path string) (*types.Package, error) {
+	return imp[path], nil
+}
+
+func TestModuleSave(t *testing.T) {
+	// prepare
+	mod := NewModule()
+	o := gopium.Struct{Fields: []gopium.Field{{Size: 1, Align: 1}, {Size: 8, Align: 8}, {Size: 1, Align: 1}}}
+	r := gopium.Struct{Fields: []gopium.Field{{Size: 8, Align: 8}, {Size: 1, Align: 1}, {Size: 1, Align: 1}}}
+	// exec
+	mod.save("a:A", o, r)
+	mod.save("a:f.0.B", r, r)
+	mod.save("b:A", o, o)
+	mod.save("C", o, r)
+	savings := mod.Savings()
+	// check
+	expected := collections.Savings{
+		"a": {Structs: 2, Original: 40, Current: 32},
+		"b": {Structs: 1, Original: 24, Current: 24},
+		"C": {Structs: 1, Original: 24, Current: 16},
+	}
+	if !reflect.DeepEqual(savings, expected) {
+		t.Errorf("actual %v doesn't equal to expected %v", savings, expected)
+	}
+	if !reflect.DeepEqual(module(WithModule(context.Background(), mod)), mod) {
+		t.Errorf("actual %v doesn't equal to expected %v", module(WithModule(context.Background(), mod)), mod)
+	}
+	if !reflect.DeepEqual(module(context.Background()), (*Module)(nil)) {
+		t.Errorf("actual %v doesn't equal to expected %v", module(context.Background()), nil)
+	}
+}
+
+func TestModuleVisit(t *testing.T) {
+	// prepare
+	bsrc := `
+package b
+
+type Header struct {
+	a bool
+	b int64
+	c bool
+}
+`
+	asrc := `
+package a
+
+import "b"
+
+type A struct {
+	h b.Header
+	x bool
+}
+`
+	fset := token.NewFileSet()
+	bfile, err := parser.ParseFile(fset, "b/file.go", bsrc, parser.ParseComments)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	afile, err := parser.ParseFile(fset, "a/file.go", asrc, parser.ParseComments)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	sizes := types.SizesFor("gc", "amd64")
+	bpkg, err := (&types.Config{Sizes: sizes}).Check("b", fset, []*ast.File{bfile}, nil)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	apkg, err := (&types.Config{Sizes: sizes, Importer: importer{"b": bpkg}}).Check("a", fset, []*ast.File{afile}, nil)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	bp := &packages.Package{PkgPath: "b", Types: bpkg, Fset: fset, Syntax: []*ast.File{bfile}}
+	ap := &packages.Package{PkgPath: "a", Types: apkg, Fset: fset, Syntax: []*ast.File{afile}}
+	m, err := typepkg.NewMavenGoTypes("gc", "amd64", 64, 64, 64)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	pck, err := strategies.Builder{}.Build(strategies.Pack)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	table := map[string]struct {
+		mod     *Module
+		imports []*packages.Package
+		deps    string
+		savings collections.Savings
+	}{
+		"dependent package should use imported package optimized layouts": {
+			mod:     NewModule(),
+			imports: []*packages.Package{bp},
+			deps:    "| A | h | b.Header | 16 bytes | 8 bytes | optimized |",
+			savings: collections.Savings{
+				"a": {Structs: 1, Original: 32, Current: 24},
+				"b": {Structs: 1, Original: 24, Current: 16},
+			},
+		},
+		"dependent package without located imports should use imported package original layouts": {
+			mod:  NewModule(),
+			deps: "| A | h | b.Header | 24 bytes | 8 bytes | original |",
+			savings: collections.Savings{
+				"a": {Structs: 1, Original: 32, Current: 32},
+				"b": {Structs: 1, Original: 24, Current: 16},
+			},
+		},
+		"dependent package without module should use imported package original layouts": {
+			imports: []*packages.Package{bp},
+			deps:    "| A | h | b.Header | 24 bytes | 8 bytes | original |",
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			ctx := context.Background()
+			if tcase.mod != nil {
+				ctx = WithModule(ctx, tcase.mod)
+			}
+			w := &mocks.Writer{}
+			wdeps := wdeps{fmt: fmtio.DependenciesMdt, writer: w}
+			// exec
+			for _, p := range []typepkg.ParserXToolPackage{
+				{Package: bp},
+				{Package: ap, Imports: tcase.imports},
+			} {
+				err := wdeps.With(p, m, false, true).Visit(ctx, regexp.MustCompile(`.*`), pck)
+				if !reflect.DeepEqual(err, nil) {
+					t.Fatalf("actual %v doesn't equal to %v", err, nil)
+				}
+			}
+			// check
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(w.RWCs["a/gopium"]); !reflect.DeepEqual(err, nil) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			if !strings.Contains(buf.String(), tcase.deps) {
+				t.Errorf("actual %v doesn't equal to expected %v", buf.String(), tcase.deps)
+			}
+			if tcase.mod != nil && !reflect.DeepEqual(tcase.mod.Savings(), tcase.savings) {
+				t.Errorf("actual %v doesn't equal to expected %v", tcase.mod.Savings(), tcase.savings)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package walkers
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// traceKey defines context key
+// for strategies pipeline tracing flag
+type traceKey struct{}
+
+// WithTrace attaches strategies pipeline
+// tracing flag to visiting context,
+// so each structure strategies pipeline stages
+// are traced and written next to other walker results
+func WithTrace(ctx context.Context, trace bool) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+// trace returns strategies pipeline
+// tracing flag from visiting context if any
+func trace(ctx context.Context) bool {
+	trace, _ := ctx.Value(traceKey{}).(bool)
+	return trace
+}
+
+// tracer helps to attach new structure
+// strategies pipeline tracer to visiting context
+// in case of tracing and returns it back,
+// returned stages func collects traced stages
+func tracer(ctx context.Context, id string, loc string) (context.Context, func() collections.Stages) {
+	if !trace(ctx) {
+		return ctx, func() collections.Stages { return nil }
+	}
+	t := collections.NewTracer(collections.Local(id), loc)
+	return gopium.WithTracer(ctx, t), t.Stages
+}
+
+// wtrace helps to write structures strategies
+// pipeline stages with trace formatter to trace writer
+// next to other walker results
+func wtrace(ss collections.Stages, fmt func(collections.Stages) ([]byte, error), writer gopium.Writer) error {
+	// skip empty writes
+	if len(ss) == 0 || fmt == nil || writer == nil {
+		return nil
+	}
+	// use stages locations
+	// to find results root category
+	h := collections.NewHierarchic("")
+	for _, s := range ss {
+		h.Push(s.Struct, s.Loc, gopium.Struct{})
+	}
+	loc := filepath.Join(h.Rcat(), gopium.NAME)
+	// apply formatter
+	buf, err := fmt(ss)
+	// in case any error happened
+	// in formatter return error back
+	if err != nil {
+		return kind(gopium.ErrFormat, err, loc)
+	}
+	// generate writer
+	wc, err := writer.Generate(loc)
+	if err != nil {
+		return kind(gopium.ErrWrite, err, loc)
+	}
+	// write results and close writer
+	// in case any error happened
+	// in writer return error
+	if _, err := wc.Write(buf); err != nil {
+		return kind(gopium.ErrWrite, err, loc)
+	}
+	return kind(gopium.ErrWrite, wc.Close(), loc)
+}
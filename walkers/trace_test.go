@@ -0,0 +1,238 @@
+package walkers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestTrace(t *testing.T) {
+	// prepare
+	src := `
+package pkg
+
+type A struct {
+	a bool
+	b int64
+	c bool
+}
+
+type b struct {
+	x int64
+}
+
+type E[T any] struct {
+	a bool
+	t T
+	b bool
+}
+
+type F struct {
+	e E[int64]
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "pkg/file.go", src, parser.ParseComments)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	files := []*ast.File{file}
+	pkg, err := (&types.Config{Sizes: types.SizesFor("gc", "amd64")}).Check("pkg", fset, files, nil)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	p := typepkg.ParserXToolPackage{Package: &packages.Package{PkgPath: "pkg", Types: pkg, Fset: fset, Syntax: files}}
+	m, err := typepkg.NewMavenGoTypes("gc", "amd64", 64, 64, 64)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	table := map[string]struct {
+		trace  bool
+		stages map[string][]string
+	}{
+		"visiting without trace should trace nothing": {
+			stages: map[string][]string{},
+		},
+		"visiting with trace should trace each structure stage": {
+			trace: true,
+			stages: map[string][]string{
+				"A": {
+					"filter_pads 24 +0 a,b,c",
+					"memory_pack 16 -8 b,a,c",
+				},
+				"b": {
+					"filter_pads 8 +0 x",
+					"memory_pack 8 +0 x",
+				},
+				"E[int64]": {
+					"filter_pads 24 +0 a,t,b",
+					"memory_pack 16 -8 t,a,b",
+				},
+				"F": {
+					"filter_pads 24 +0 e",
+					"memory_pack 24 +0 e",
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			stg, err := strategies.Builder{Trace: tcase.trace}.Build(strategies.FPad, strategies.Pack)
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to %v", err, nil)
+			}
+			ctx := WithTrace(context.Background(), tcase.trace)
+			_, loc, err := p.ParseTypes(ctx)
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to %v", err, nil)
+			}
+			ch := make(appliedCh)
+			// exec
+			go with(m, loc, false).visit(regexp.MustCompile(`.*`), stg, ch, false)(ctx, pkg.Scope())
+			stages := make(map[string][]string)
+			for applied := range ch {
+				if !reflect.DeepEqual(applied.Err, nil) {
+					t.Fatalf("actual %v doesn't equal to %v", applied.Err, nil)
+				}
+				ss := applied.Stages
+				for _, inst := range applied.Insts {
+					ss = append(ss, inst.Stages...)
+				}
+				for _, s := range ss {
+					fields := make([]string, 0, len(s.Result.Fields))
+					for _, f := range s.Result.Fields {
+						fields = append(fields, f.Name)
+					}
+					stages[s.Struct] = append(
+						stages[s.Struct],
+						fmt.Sprintf("%s %d %+d %s", s.Strategy, s.Size, s.Delta, strings.Join(fields, ",")),
+					)
+				}
+			}
+			// check
+			if !reflect.DeepEqual(stages, tcase.stages) {
+				t.Errorf("actual %v doesn't equal to expected %v", stages, tcase.stages)
+			}
+		})
+	}
+}
+
+func TestWtrace(t *testing.T) {
+	// prepare
+	ss := collections.Stages{
+		{
+			Struct:   "B",
+			Loc:      "pkg/file.go:10",
+			Strategy: "memory_pack",
+			Result:   gopium.Struct{Name: "B", Fields: []gopium.Field{{Name: "b"}}},
+			Duration: time.Millisecond,
+			Size:     8,
+		},
+		{
+			Struct:   "A",
+			Loc:      "pkg/file.go:4",
+			Strategy: "memory_pack",
+			Result:   gopium.Struct{Name: "A", Fields: []gopium.Field{{Name: "b"}, {Name: "a"}}},
+			Duration: time.Millisecond,
+			Size:     16,
+			Delta:    -8,
+		},
+	}
+	table := map[string]struct {
+		ss  collections.Stages
+		fmt func(collections.Stages) ([]byte, error)
+		w   *mocks.Writer
+		sts map[string][]byte
+		err error
+	}{
+		"empty stages should write nothing": {
+			w:   &mocks.Writer{},
+			sts: map[string][]byte{},
+		},
+		"stages should be written next to results": {
+			ss: ss,
+			w:  &mocks.Writer{},
+			sts: map[string][]byte{
+				"pkg/gopium": []byte(`
+| Struct Key | Stage | Strategy | Duration | Size with Pad | Size Delta | Fields Order |
+| :---: | :---: | :---: | :---: | :---: | :---: | :---: |
+| A | 1 | memory_pack | 1ms | 16 bytes | -8 bytes | b, a |
+| B | 1 | memory_pack | 1ms | 8 bytes | +0 bytes | b |
+`),
+			},
+		},
+		"stages should write nothing on formatter error": {
+			ss: ss,
+			fmt: func(collections.Stages) ([]byte, error) {
+				return nil, errors.New("test-1")
+			},
+			w:   &mocks.Writer{},
+			sts: map[string][]byte{},
+			err: gopium.Error{Kind: gopium.ErrFormat, Err: errors.New("test-1"), Pos: "pkg/gopium"},
+		},
+		"stages should write nothing on writer error": {
+			ss:  ss,
+			w:   &mocks.Writer{Gerr: errors.New("test-2")},
+			sts: map[string][]byte{},
+			err: gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-2"), Pos: "pkg/gopium"},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			tfmt := tcase.fmt
+			if tfmt == nil {
+				tfmt = fmtio.TraceMdt
+			}
+			// exec
+			err := wtrace(tcase.ss, tfmt, tcase.w)
+			// check
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// process checks only on success
+			if tcase.err == nil {
+				for id, rwc := range tcase.w.RWCs {
+					st, ok := tcase.sts[id]
+					if !ok {
+						t.Errorf("actual %v doesn't equal to expected %v", id, "")
+						continue
+					}
+					var buf bytes.Buffer
+					if _, err := buf.ReadFrom(rwc); !reflect.DeepEqual(err, nil) {
+						t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+					}
+					actual := strings.Trim(buf.String(), "\n")
+					expected := strings.Trim(string(st), "\n")
+					if !reflect.DeepEqual(actual, expected) {
+						t.Errorf("id %v actual %v doesn't equal to expected %v", id, actual, expected)
+					}
+					delete(tcase.sts, id)
+				}
+				if !reflect.DeepEqual(tcase.sts, map[string][]byte{}) {
+					t.Errorf("actual %v doesn't equal to expected %v", tcase.sts, map[string][]byte{})
+				}
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package walkers
 
 import (
 	"context"
+	"fmt"
 	"go/types"
 	"regexp"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/1pkg/gopium/collections"
@@ -11,15 +14,24 @@ import (
 )
 
 // applied encapsulates visited by strategy
-// structs results: id, loc, origin, result structs and error
+// structs results: id, loc, origin, result structs, error,
+// generic struct per instantiation results,
+// struct fields dependencies on named structs,
+// traced strategies pipeline stages,
+// strategies diagnostics
+// and selection filter that skipped struct if any
 type applied struct {
-	O   gopium.Struct `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	R   gopium.Struct `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	ID  string        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	Loc string        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	Err error         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_   [32]byte      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 256 bytes; struct align: 8 bytes; struct aligned size: 256 bytes; - 🌺 gopium @1pkg
+	O      gopium.Struct            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	R      gopium.Struct            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Insts  []applied                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Deps   []collections.Dependency `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Stages collections.Stages       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Diags  collections.Diagnostics  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	ID     string                   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Loc    string                   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Skip   string                   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Err    error                    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 384 bytes; struct align: 8 bytes; struct aligned size: 384 bytes; - 🌺 gopium @1pkg
 
 // appliedCh defines abstraction that helps
 // keep applied stream results
@@ -31,13 +43,19 @@ type govisit func(context.Context, *types.Scope)
 
 // prepare defines abstraction that helps
 // setup visiting maven for future visit action
-type prepare func() (*maven, context.CancelFunc)
+type prepare func(context.Context) (*maven, context.CancelFunc)
 
 // with helps to create prepare func
 // with exposer, locator and backref
 func with(exp gopium.Exposer, loc gopium.Locator, bref bool) prepare {
-	return func() (*maven, context.CancelFunc) {
-		// create visiting maven with reference
+	return func(ctx context.Context) (*maven, context.CancelFunc) {
+		// in case of cross packages visiting
+		// create visiting maven with module
+		// reference shared between packages
+		if mod := module(ctx); mod != nil && bref {
+			return &maven{exp: exp, loc: loc, ref: mod.ref, mod: mod}, mod.ref.Prune
+		}
+		// otherwise create visiting maven with reference
 		// and return it back,
 		// with ref prune cancelation func
 		ref := collections.NewReference(bref)
@@ -60,7 +78,7 @@ func (p prepare) visit(regex *regexp.Regexp, stg gopium.Strategy, ch appliedCh,
 	// and applies strategy to them
 	return func(ctx context.Context, s *types.Scope) {
 		// prepare visiting maven
-		m, cancel := p()
+		m, cancel := p(ctx)
 		defer cancel()
 		// determinate which function
 		// should be applied for visiting
@@ -147,21 +165,28 @@ func vdeep(ctx context.Context, s *types.Scope, r *regexp.Regexp, stg gopium.Str
 
 // vscope defines visiting helper
 // that goes through structures on the single scope concurently,
-// if their names match regex then applies strategy to them
+// if their names match regex and they pass selection filters
+// then applies strategy to them, otherwise structures
+// skipped by selection filters are pushed with filter description
 func vscope(ctx context.Context, s *types.Scope, r *regexp.Regexp, stg gopium.Strategy, m *maven, ch appliedCh) {
 	// wait until all visits finished
 	// and then close the channel
 	defer close(ch)
+	// grab context selection filters
+	f := filters(ctx)
 	// wait group visits counter
 	var wg sync.WaitGroup
 	// go through all names inside the package scope
-	// and collect all visiting closure
+	// and collect all visiting closures along with
+	// structures type dependency graph
 	//
 	// we can't call them directly in order to backref
 	// work correctly, as we need in first iteration to
 	// enumerate all structs on the scope first
+	// and then visit them in topological order
 	names := s.Names()
-	vclos := make([]func(), 0, len(names))
+	var g graph
+	vclos := make(map[string]func(func(gopium.Struct)), len(names))
 	for _, name := range names {
 		// manage context actions
 		// in case of cancelation
@@ -190,33 +215,88 @@ func vscope(ctx context.Context, s *types.Scope, r *regexp.Regexp, stg gopium.St
 				if id, loc, ok = m.has(tn); ok {
 					continue
 				}
-				// create struct ref notifier
-				notif := m.refst(id)
+				// in case structure doesn't pass
+				// selection filters skip it
+				if skip := f.skip(m, tn, st, loc); skip != "" {
+					ch <- applied{ID: id, Loc: loc, Skip: skip}
+					continue
+				}
+				// add structure to dependency graph
+				g.add(id, m.refids(st))
 				// collect the structure's visiting
 				// closure that applies strategy to it
-				vclos = append(vclos, func() {
-					// decrement visits wait group
-					defer wg.Done()
+				// and notifies ref with result structure
+				vclos[id] = func(notif func(gopium.Struct)) {
+					// collect structure's fields dependencies
+					// after all its dependencies are visited
+					ds := m.deps("", st)
+					for i := range ds {
+						ds[i].Struct = collections.Local(id)
+					}
+					// generic structs are applied
+					// per considered instantiation
+					if origin, ok := generic(tn); ok {
+						a := m.vgeneric(ctx, stg, tn, origin, id, loc)
+						a.ID, a.Loc, a.Deps = id, loc, ds
+						notif(a.R)
+						ch <- a
+						return
+					}
 					// convert original struct
 					// to inner gopium format
 					o := m.enum(name, st)
+					// attach structure's directives
+					o.Directives = m.loc.Directives(tn.Pos())
 					// apply provided strategy
-					r, err := stg.Apply(ctx, o)
+					// with struct location, tracer and diagnoser
+					// attached to struct and its nested structs
+					// strategy error is wrapped with
+					// structure identity and position
+					tctx, stages := tracer(gopium.WithLoc(ctx, loc), id, loc)
+					dctx, diags := diagnoser(tctx, id, loc)
+					r, err := nest(dctx, stg, o)
+					if err != nil {
+						err = gopium.Error{Kind: gopium.ErrStrategy, Err: err, ID: id, Pos: m.pos(tn, loc)}
+					}
 					// notify ref with result structure
+					// and save module sizes saving
+					// against original nested layouts
 					notif(r)
+					if m.mod != nil && err == nil {
+						m.mod.save(id, m.orig(name, st), r)
+					}
 					// and push results to the chan
 					ch <- applied{
-						ID:  id,
-						Loc: loc,
-						O:   o,
-						R:   r,
-						Err: err,
+						ID:     id,
+						Loc:    loc,
+						O:      o,
+						R:      r,
+						Deps:   ds,
+						Stages: stages(),
+						Diags:  diags(),
+						Err:    err,
 					}
-				})
+				}
 			}
 		}
 	}
-	for _, vclos := range vclos {
+	// sort structures topologically
+	// in case of any cyclic references
+	// push error to the chan
+	order, err := g.sort()
+	if err != nil {
+		ch <- applied{Err: err}
+		return
+	}
+	// prepare structures ref notifiers
+	// and visits done signals
+	notifs := make(map[string]func(gopium.Struct), len(order))
+	dones := make(map[string]chan struct{}, len(order))
+	for _, id := range order {
+		notifs[id] = m.refst(id)
+		dones[id] = make(chan struct{})
+	}
+	for _, id := range order {
 		// manage context actions
 		// in case of cancelation
 		// stop the execution
@@ -230,10 +310,116 @@ func vscope(ctx context.Context, s *types.Scope, r *regexp.Regexp, stg gopium.St
 		}
 		// increment visits wait group
 		// and run collected visits
-		// closures concurently
+		// closures concurently, each closure
+		// waits only for visits of structures
+		// it depends on inside the graph
 		wg.Add(1)
-		go vclos()
+		go func(id string) {
+			// decrement visits wait group
+			// and signal visit is done
+			defer wg.Done()
+			defer close(dones[id])
+			for _, dep := range g.resolved(id) {
+				<-dones[dep]
+			}
+			vclos[id](notifs[id])
+		}(id)
 	}
 	// wait until all visits are finished
 	wg.Wait()
 }
+
+// nest helps to apply strategy to structure
+// and all its anonymous nested structs bottom-up,
+// nested structs inherit structure directives
+// and their results sizes and types are propagated
+// to their fields before structure itself is applied,
+// only structure itself is traced by context tracer
+func nest(ctx context.Context, stg gopium.Strategy, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// go through all nested structs fields
+	for i, f := range r.Fields {
+		if f.Nested == nil {
+			continue
+		}
+		// apply strategy to nested struct first
+		nested := *f.Nested
+		nested.Directives = r.Directives
+		nr, err := nest(gopium.WithTracer(ctx, nil), stg, nested)
+		if err != nil {
+			return o, err
+		}
+		// and propagate nested result to field
+		nr.Directives = f.Nested.Directives
+		r.Fields[i] = wrap(r.Fields[i], nr)
+	}
+	return stg.Apply(ctx, r)
+}
+
+// wrap helps to propagate nested struct result
+// to its field through field type wrappers
+// like `[N]`, `[]` or `*` of anonymous struct,
+// slices and pointers keep field size intact
+func wrap(f gopium.Field, nr gopium.Struct) gopium.Field {
+	// split field type to wrappers prefix
+	var prefix string
+	if index := strings.Index(f.Type, "struct{"); index > 0 {
+		prefix = f.Type[:index]
+	}
+	f.Type = prefix + stype(nr)
+	f.Nested = &nr
+	// in case of any indirection
+	// field layout is kept
+	if strings.Contains(prefix, "*") || strings.Contains(prefix, "[]") {
+		return f
+	}
+	// otherwise calculate arrays layout
+	// from the innermost array
+	// note: copied from `go/types/sizes.go`
+	size, align := collections.SizeAlign(nr)
+	ptr := collections.PtrData(nr)
+	arrs := wrappers.FindAllStringSubmatch(prefix, -1)
+	for i := len(arrs) - 1; i >= 0; i-- {
+		n, _ := strconv.ParseInt(arrs[i][1], 10, 64)
+		if n <= 0 {
+			size, ptr = 0, 0
+			continue
+		}
+		stride := collections.Align(size, align)
+		if ptr > 0 {
+			ptr = stride*(n-1) + ptr
+		}
+		size = stride*(n-1) + size
+	}
+	f.Size, f.Align, f.Ptr = size, align, ptr
+	return f
+}
+
+// wrappers defines anonymous struct arrays wrappers regex
+var wrappers = regexp.MustCompile(`\[(\d+)\]`)
+
+// stype helps to format structure
+// as anonymous struct type
+// `struct{a int; b string "tag"}`
+func stype(st gopium.Struct) string {
+	fields := make([]string, 0, len(st.Fields))
+	for _, f := range st.Fields {
+		field := f.Type
+		if !f.Embedded {
+			field = fmt.Sprintf("%s %s", f.Name, f.Type)
+		}
+		if f.Tag != "" {
+			field = fmt.Sprintf("%s %s", field, strconv.Quote(f.Tag))
+		}
+		fields = append(fields, field)
+	}
+	return fmt.Sprintf("struct{%s}", strings.Join(fields, "; "))
+}
+
+// tested checks if structure loc
+// is go test file, so structure
+// is declared only for tests
+func tested(loc string) bool {
+	return strings.HasSuffix(loc, "_test.go")
+}
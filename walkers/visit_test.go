@@ -129,7 +129,7 @@ func TestVscope(t *testing.T) {
 			p:   data.NewParser("single"),
 			stg: np,
 			sts: map[string]gopium.Struct{
-				"tests_data_single_file.go:5": {
+				"github.com/1pkg/gopium/tests/data/single:Single": {
 					Name: "Single",
 					Fields: []gopium.Field{
 						{
@@ -137,6 +137,7 @@ func TestVscope(t *testing.T) {
 							Type:     "string",
 							Size:     16,
 							Align:    8,
+							Ptr:      8,
 							Exported: true,
 						},
 						{
@@ -144,6 +145,7 @@ func TestVscope(t *testing.T) {
 							Type:     "string",
 							Size:     16,
 							Align:    8,
+							Ptr:      8,
 							Exported: true,
 						},
 						{
@@ -151,6 +153,7 @@ func TestVscope(t *testing.T) {
 							Type:     "string",
 							Size:     16,
 							Align:    8,
+							Ptr:      8,
 							Exported: true,
 						},
 					},
@@ -182,7 +185,7 @@ func TestVscope(t *testing.T) {
 			p:   data.NewParser("flat"),
 			stg: np,
 			sts: map[string]gopium.Struct{
-				"tests_data_flat_file.go:10": {
+				"github.com/1pkg/gopium/tests/data/flat:A": {
 					Name: "A",
 					Fields: []gopium.Field{
 						{
@@ -193,7 +196,7 @@ func TestVscope(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_flat_file.go:16": {
+				"github.com/1pkg/gopium/tests/data/flat:b": {
 					Name: "b",
 					Fields: []gopium.Field{
 						{
@@ -212,7 +215,7 @@ func TestVscope(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_flat_file.go:21": {
+				"github.com/1pkg/gopium/tests/data/flat:C": {
 					Name: "C",
 					Fields: []gopium.Field{
 						{
@@ -220,6 +223,7 @@ func TestVscope(t *testing.T) {
 							Type:  "[]string",
 							Size:  24,
 							Align: 8,
+							Ptr:   8,
 						},
 						{
 							Name:     "A",
@@ -227,10 +231,27 @@ func TestVscope(t *testing.T) {
 							Size:     24,
 							Align:    8,
 							Exported: true,
+							Nested: &gopium.Struct{
+								Name: "C.A",
+								Fields: []gopium.Field{
+									{
+										Name:  "b",
+										Type:  "github.com/1pkg/gopium/tests/data/flat.b",
+										Size:  16,
+										Align: 8,
+									},
+									{
+										Name:  "z",
+										Type:  "github.com/1pkg/gopium/tests/data/flat.A",
+										Size:  8,
+										Align: 8,
+									},
+								},
+							},
 						},
 					},
 				},
-				"tests_data_flat_file.go:29": {
+				"github.com/1pkg/gopium/tests/data/flat:c1": {
 					Name: "c1",
 					Fields: []gopium.Field{
 						{
@@ -238,6 +259,7 @@ func TestVscope(t *testing.T) {
 							Type:  "[]string",
 							Size:  24,
 							Align: 8,
+							Ptr:   8,
 						},
 						{
 							Name:     "A",
@@ -245,10 +267,27 @@ func TestVscope(t *testing.T) {
 							Size:     24,
 							Align:    8,
 							Exported: true,
+							Nested: &gopium.Struct{
+								Name: "c1.A",
+								Fields: []gopium.Field{
+									{
+										Name:  "b",
+										Type:  "github.com/1pkg/gopium/tests/data/flat.b",
+										Size:  16,
+										Align: 8,
+									},
+									{
+										Name:  "z",
+										Type:  "github.com/1pkg/gopium/tests/data/flat.A",
+										Size:  8,
+										Align: 8,
+									},
+								},
+							},
 						},
 					},
 				},
-				"tests_data_flat_file.go:32": {
+				"github.com/1pkg/gopium/tests/data/flat:D": {
 					Name: "D",
 					Fields: []gopium.Field{
 						{
@@ -271,7 +310,7 @@ func TestVscope(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_flat_file.go:41": {
+				"github.com/1pkg/gopium/tests/data/flat:AZ": {
 					Name: "AZ",
 					Fields: []gopium.Field{
 						{
@@ -313,7 +352,7 @@ func TestVscope(t *testing.T) {
 			p:   data.NewParser("flat"),
 			stg: np,
 			sts: map[string]gopium.Struct{
-				"tests_data_flat_file.go:10": {
+				"github.com/1pkg/gopium/tests/data/flat:A": {
 					Name: "A",
 					Fields: []gopium.Field{
 						{
@@ -324,7 +363,7 @@ func TestVscope(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_flat_file.go:41": {
+				"github.com/1pkg/gopium/tests/data/flat:AZ": {
 					Name: "AZ",
 					Fields: []gopium.Field{
 						{
@@ -357,7 +396,7 @@ func TestVscope(t *testing.T) {
 			p:   data.NewParser("nested"),
 			stg: np,
 			sts: map[string]gopium.Struct{
-				"tests_data_nested_file.go:7": {
+				"github.com/1pkg/gopium/tests/data/nested:A": {
 					Name: "A",
 					Fields: []gopium.Field{
 						{
@@ -368,7 +407,7 @@ func TestVscope(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_nested_file.go:11": {
+				"github.com/1pkg/gopium/tests/data/nested:b": {
 					Name: "b",
 					Fields: []gopium.Field{
 						{
@@ -387,7 +426,7 @@ func TestVscope(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_nested_file.go:16": {
+				"github.com/1pkg/gopium/tests/data/nested:C": {
 					Name: "C",
 					Fields: []gopium.Field{
 						{
@@ -395,6 +434,7 @@ func TestVscope(t *testing.T) {
 							Type:  "[]string",
 							Size:  24,
 							Align: 8,
+							Ptr:   8,
 						},
 						{
 							Name:     "A",
@@ -402,10 +442,27 @@ func TestVscope(t *testing.T) {
 							Size:     24,
 							Align:    8,
 							Exported: true,
+							Nested: &gopium.Struct{
+								Name: "C.A",
+								Fields: []gopium.Field{
+									{
+										Name:  "b",
+										Type:  "github.com/1pkg/gopium/tests/data/nested.b",
+										Size:  16,
+										Align: 8,
+									},
+									{
+										Name:  "z",
+										Type:  "github.com/1pkg/gopium/tests/data/nested.A",
+										Size:  8,
+										Align: 8,
+									},
+								},
+							},
 						},
 					},
 				},
-				"tests_data_nested_file.go:63": {
+				"github.com/1pkg/gopium/tests/data/nested:Z": {
 					Name: "Z",
 					Fields: []gopium.Field{
 						{
@@ -419,6 +476,7 @@ func TestVscope(t *testing.T) {
 							Type:     "github.com/1pkg/gopium/tests/data/nested.C",
 							Size:     48,
 							Align:    8,
+							Ptr:      8,
 							Exported: true,
 						},
 						{
@@ -438,7 +496,7 @@ func TestVscope(t *testing.T) {
 			p:   data.NewParser("multi"),
 			stg: pck,
 			sts: map[string]gopium.Struct{
-				"tests_data_multi_file-1.go:9": {
+				"github.com/1pkg/gopium/tests/data/multi:A": {
 					Name: "A",
 					Fields: []gopium.Field{
 						{
@@ -449,7 +507,7 @@ func TestVscope(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_multi_file-3.go:17": {
+				"github.com/1pkg/gopium/tests/data/multi:AZ": {
 					Name: "AZ",
 					Fields: []gopium.Field{
 						{
@@ -473,7 +531,7 @@ func TestVscope(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_multi_file-3.go:27": {
+				"github.com/1pkg/gopium/tests/data/multi:Zeze": {
 					Name: "Zeze",
 					Fields: []gopium.Field{
 						{
@@ -504,6 +562,7 @@ func TestVscope(t *testing.T) {
 							Type:     "github.com/1pkg/gopium/tests/data/multi.ze",
 							Size:     16,
 							Align:    8,
+							Ptr:      16,
 							Embedded: true,
 						},
 					},
@@ -597,7 +656,7 @@ func TestVdeep(t *testing.T) {
 			p:   data.NewParser("single"),
 			stg: np,
 			sts: map[string]gopium.Struct{
-				"tests_data_single_file.go:5": {
+				"github.com/1pkg/gopium/tests/data/single:Single": {
 					Name: "Single",
 					Fields: []gopium.Field{
 						{
@@ -605,6 +664,7 @@ func TestVdeep(t *testing.T) {
 							Type:     "string",
 							Size:     16,
 							Align:    8,
+							Ptr:      8,
 							Exported: true,
 						},
 						{
@@ -612,6 +672,7 @@ func TestVdeep(t *testing.T) {
 							Type:     "string",
 							Size:     16,
 							Align:    8,
+							Ptr:      8,
 							Exported: true,
 						},
 						{
@@ -619,6 +680,7 @@ func TestVdeep(t *testing.T) {
 							Type:     "string",
 							Size:     16,
 							Align:    8,
+							Ptr:      8,
 							Exported: true,
 						},
 					},
@@ -650,7 +712,7 @@ func TestVdeep(t *testing.T) {
 			p:   data.NewParser("flat"),
 			stg: np,
 			sts: map[string]gopium.Struct{
-				"tests_data_flat_file.go:10": {
+				"github.com/1pkg/gopium/tests/data/flat:A": {
 					Name: "A",
 					Fields: []gopium.Field{
 						{
@@ -661,7 +723,7 @@ func TestVdeep(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_flat_file.go:16": {
+				"github.com/1pkg/gopium/tests/data/flat:b": {
 					Name: "b",
 					Fields: []gopium.Field{
 						{
@@ -680,7 +742,7 @@ func TestVdeep(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_flat_file.go:21": {
+				"github.com/1pkg/gopium/tests/data/flat:C": {
 					Name: "C",
 					Fields: []gopium.Field{
 						{
@@ -688,6 +750,7 @@ func TestVdeep(t *testing.T) {
 							Type:  "[]string",
 							Size:  24,
 							Align: 8,
+							Ptr:   8,
 						},
 						{
 							Name:     "A",
@@ -695,10 +758,27 @@ func TestVdeep(t *testing.T) {
 							Size:     24,
 							Align:    8,
 							Exported: true,
+							Nested: &gopium.Struct{
+								Name: "C.A",
+								Fields: []gopium.Field{
+									{
+										Name:  "b",
+										Type:  "github.com/1pkg/gopium/tests/data/flat.b",
+										Size:  16,
+										Align: 8,
+									},
+									{
+										Name:  "z",
+										Type:  "github.com/1pkg/gopium/tests/data/flat.A",
+										Size:  8,
+										Align: 8,
+									},
+								},
+							},
 						},
 					},
 				},
-				"tests_data_flat_file.go:29": {
+				"github.com/1pkg/gopium/tests/data/flat:c1": {
 					Name: "c1",
 					Fields: []gopium.Field{
 						{
@@ -706,6 +786,7 @@ func TestVdeep(t *testing.T) {
 							Type:  "[]string",
 							Size:  24,
 							Align: 8,
+							Ptr:   8,
 						},
 						{
 							Name:     "A",
@@ -713,10 +794,27 @@ func TestVdeep(t *testing.T) {
 							Size:     24,
 							Align:    8,
 							Exported: true,
+							Nested: &gopium.Struct{
+								Name: "c1.A",
+								Fields: []gopium.Field{
+									{
+										Name:  "b",
+										Type:  "github.com/1pkg/gopium/tests/data/flat.b",
+										Size:  16,
+										Align: 8,
+									},
+									{
+										Name:  "z",
+										Type:  "github.com/1pkg/gopium/tests/data/flat.A",
+										Size:  8,
+										Align: 8,
+									},
+								},
+							},
 						},
 					},
 				},
-				"tests_data_flat_file.go:32": {
+				"github.com/1pkg/gopium/tests/data/flat:D": {
 					Name: "D",
 					Fields: []gopium.Field{
 						{
@@ -739,7 +837,7 @@ func TestVdeep(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_flat_file.go:41": {
+				"github.com/1pkg/gopium/tests/data/flat:AZ": {
 					Name: "AZ",
 					Fields: []gopium.Field{
 						{
@@ -781,7 +879,7 @@ func TestVdeep(t *testing.T) {
 			p:   data.NewParser("flat"),
 			stg: np,
 			sts: map[string]gopium.Struct{
-				"tests_data_flat_file.go:10": {
+				"github.com/1pkg/gopium/tests/data/flat:A": {
 					Name: "A",
 					Fields: []gopium.Field{
 						{
@@ -792,7 +890,7 @@ func TestVdeep(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_flat_file.go:41": {
+				"github.com/1pkg/gopium/tests/data/flat:AZ": {
 					Name: "AZ",
 					Fields: []gopium.Field{
 						{
@@ -825,7 +923,7 @@ func TestVdeep(t *testing.T) {
 			p:   data.NewParser("nested"),
 			stg: np,
 			sts: map[string]gopium.Struct{
-				"tests_data_nested_file.go:7": {
+				"github.com/1pkg/gopium/tests/data/nested:A": {
 					Name: "A",
 					Fields: []gopium.Field{
 						{
@@ -836,7 +934,7 @@ func TestVdeep(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_nested_file.go:11": {
+				"github.com/1pkg/gopium/tests/data/nested:b": {
 					Name: "b",
 					Fields: []gopium.Field{
 						{
@@ -855,7 +953,7 @@ func TestVdeep(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_nested_file.go:16": {
+				"github.com/1pkg/gopium/tests/data/nested:C": {
 					Name: "C",
 					Fields: []gopium.Field{
 						{
@@ -863,6 +961,7 @@ func TestVdeep(t *testing.T) {
 							Type:  "[]string",
 							Size:  24,
 							Align: 8,
+							Ptr:   8,
 						},
 						{
 							Name:     "A",
@@ -870,10 +969,27 @@ func TestVdeep(t *testing.T) {
 							Size:     24,
 							Align:    8,
 							Exported: true,
+							Nested: &gopium.Struct{
+								Name: "C.A",
+								Fields: []gopium.Field{
+									{
+										Name:  "b",
+										Type:  "github.com/1pkg/gopium/tests/data/nested.b",
+										Size:  16,
+										Align: 8,
+									},
+									{
+										Name:  "z",
+										Type:  "github.com/1pkg/gopium/tests/data/nested.A",
+										Size:  8,
+										Align: 8,
+									},
+								},
+							},
 						},
 					},
 				},
-				"tests_data_nested_file.go:25": {
+				"github.com/1pkg/gopium/tests/data/nested:scope1.B": {
 					Name: "B",
 					Fields: []gopium.Field{
 						{
@@ -885,7 +1001,7 @@ func TestVdeep(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_nested_file.go:28": {
+				"github.com/1pkg/gopium/tests/data/nested:scope1.b1": {
 					Name: "b1",
 					Fields: []gopium.Field{
 						{
@@ -904,7 +1020,7 @@ func TestVdeep(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_nested_file.go:37": {
+				"github.com/1pkg/gopium/tests/data/nested:scope2.A": {
 					Name: "A",
 					Fields: []gopium.Field{
 						{
@@ -915,7 +1031,7 @@ func TestVdeep(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_nested_file.go:40": {
+				"github.com/1pkg/gopium/tests/data/nested:scope2.a1": {
 					Name: "a1",
 					Fields: []gopium.Field{
 						{
@@ -923,10 +1039,11 @@ func TestVdeep(t *testing.T) {
 							Type:  "interface{}",
 							Size:  16,
 							Align: 8,
+							Ptr:   16,
 						},
 					},
 				},
-				"tests_data_nested_file.go:46": {
+				"github.com/1pkg/gopium/tests/data/nested:scope2.0.a1": {
 					Name: "a1",
 					Fields: []gopium.Field{
 						{
@@ -934,10 +1051,14 @@ func TestVdeep(t *testing.T) {
 							Type:  "struct{}",
 							Size:  0,
 							Align: 1,
+							Nested: &gopium.Struct{
+								Name:   "a1.i",
+								Fields: []gopium.Field{},
+							},
 						},
 					},
 				},
-				"tests_data_nested_file.go:63": {
+				"github.com/1pkg/gopium/tests/data/nested:Z": {
 					Name: "Z",
 					Fields: []gopium.Field{
 						{
@@ -951,6 +1072,7 @@ func TestVdeep(t *testing.T) {
 							Type:     "github.com/1pkg/gopium/tests/data/nested.C",
 							Size:     48,
 							Align:    8,
+							Ptr:      8,
 							Exported: true,
 						},
 						{
@@ -970,7 +1092,7 @@ func TestVdeep(t *testing.T) {
 			p:   data.NewParser("multi"),
 			stg: pck,
 			sts: map[string]gopium.Struct{
-				"tests_data_multi_file-1.go:9": {
+				"github.com/1pkg/gopium/tests/data/multi:A": {
 					Name: "A",
 					Fields: []gopium.Field{
 						{
@@ -981,7 +1103,7 @@ func TestVdeep(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_multi_file-3.go:17": {
+				"github.com/1pkg/gopium/tests/data/multi:AZ": {
 					Name: "AZ",
 					Fields: []gopium.Field{
 						{
@@ -1005,7 +1127,7 @@ func TestVdeep(t *testing.T) {
 						},
 					},
 				},
-				"tests_data_multi_file-3.go:27": {
+				"github.com/1pkg/gopium/tests/data/multi:Zeze": {
 					Name: "Zeze",
 					Fields: []gopium.Field{
 						{
@@ -1036,11 +1158,12 @@ func TestVdeep(t *testing.T) {
 							Type:     "github.com/1pkg/gopium/tests/data/multi.ze",
 							Size:     16,
 							Align:    8,
+							Ptr:      16,
 							Embedded: true,
 						},
 					},
 				},
-				"tests_data_multi_file-1.go:29": {
+				"github.com/1pkg/gopium/tests/data/multi:scope.TestAZ": {
 					Name: "TestAZ",
 					Fields: []gopium.Field{
 						{
@@ -1110,3 +1233,232 @@ func TestVdeep(t *testing.T) {
 		})
 	}
 }
+
+func TestNest(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b := strategies.Builder{}
+	pck, err := b.Build(strategies.Pack)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	table := map[string]struct {
+		ctx context.Context
+		stg gopium.Strategy
+		o   gopium.Struct
+		r   gopium.Struct
+		err error
+	}{
+		"empty struct should be applied to empty struct": {
+			ctx: context.Background(),
+			stg: pck,
+		},
+		"struct without nested structs should be applied to expected struct": {
+			ctx: context.Background(),
+			stg: pck,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "a", Type: "bool", Size: 1, Align: 1},
+					{Name: "b", Type: "int64", Size: 8, Align: 8},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "b", Type: "int64", Size: 8, Align: 8},
+					{Name: "a", Type: "bool", Size: 1, Align: 1},
+				},
+			},
+		},
+		"struct with nested structs should be applied bottom up to expected struct": {
+			ctx: context.Background(),
+			stg: pck,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "a", Type: "bool", Size: 1, Align: 1},
+					{
+						Name:  "n",
+						Type:  "struct{a bool; b int64; c bool}",
+						Size:  24,
+						Align: 8,
+						Nested: &gopium.Struct{
+							Name: "test.n",
+							Fields: []gopium.Field{
+								{Name: "a", Type: "bool", Size: 1, Align: 1},
+								{Name: "b", Type: "int64", Size: 8, Align: 8},
+								{Name: "c", Type: "bool", Size: 1, Align: 1, Tag: `json:"c"`},
+							},
+						},
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "n",
+						Type:  `struct{b int64; a bool; c bool "json:\"c\""}`,
+						Size:  16,
+						Align: 8,
+						Nested: &gopium.Struct{
+							Name: "test.n",
+							Fields: []gopium.Field{
+								{Name: "b", Type: "int64", Size: 8, Align: 8},
+								{Name: "a", Type: "bool", Size: 1, Align: 1},
+								{Name: "c", Type: "bool", Size: 1, Align: 1, Tag: `json:"c"`},
+							},
+						},
+					},
+					{Name: "a", Type: "bool", Size: 1, Align: 1},
+				},
+			},
+		},
+		"struct with wrapped nested structs should be applied bottom up to expected struct": {
+			ctx: context.Background(),
+			stg: pck,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "arr",
+						Type:  "[2]struct{a bool; b int64; c bool}",
+						Size:  48,
+						Align: 8,
+						Nested: &gopium.Struct{
+							Name: "test.arr",
+							Fields: []gopium.Field{
+								{Name: "a", Type: "bool", Size: 1, Align: 1},
+								{Name: "b", Type: "int64", Size: 8, Align: 8},
+								{Name: "c", Type: "bool", Size: 1, Align: 1},
+							},
+						},
+					},
+					{
+						Name:  "sl",
+						Type:  "[]struct{a bool; b int64; c bool}",
+						Size:  24,
+						Align: 8,
+						Ptr:   8,
+						Nested: &gopium.Struct{
+							Name: "test.sl",
+							Fields: []gopium.Field{
+								{Name: "a", Type: "bool", Size: 1, Align: 1},
+								{Name: "b", Type: "int64", Size: 8, Align: 8},
+								{Name: "c", Type: "bool", Size: 1, Align: 1},
+							},
+						},
+					},
+					{
+						Name:  "ptr",
+						Type:  "*struct{a bool; b int64; c bool}",
+						Size:  8,
+						Align: 8,
+						Ptr:   8,
+						Nested: &gopium.Struct{
+							Name: "test.ptr",
+							Fields: []gopium.Field{
+								{Name: "a", Type: "bool", Size: 1, Align: 1},
+								{Name: "b", Type: "int64", Size: 8, Align: 8},
+								{Name: "c", Type: "bool", Size: 1, Align: 1},
+							},
+						},
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "arr",
+						Type:  "[2]struct{b int64; a bool; c bool}",
+						Size:  32,
+						Align: 8,
+						Nested: &gopium.Struct{
+							Name: "test.arr",
+							Fields: []gopium.Field{
+								{Name: "b", Type: "int64", Size: 8, Align: 8},
+								{Name: "a", Type: "bool", Size: 1, Align: 1},
+								{Name: "c", Type: "bool", Size: 1, Align: 1},
+							},
+						},
+					},
+					{
+						Name:  "sl",
+						Type:  "[]struct{b int64; a bool; c bool}",
+						Size:  24,
+						Align: 8,
+						Ptr:   8,
+						Nested: &gopium.Struct{
+							Name: "test.sl",
+							Fields: []gopium.Field{
+								{Name: "b", Type: "int64", Size: 8, Align: 8},
+								{Name: "a", Type: "bool", Size: 1, Align: 1},
+								{Name: "c", Type: "bool", Size: 1, Align: 1},
+							},
+						},
+					},
+					{
+						Name:  "ptr",
+						Type:  "*struct{b int64; a bool; c bool}",
+						Size:  8,
+						Align: 8,
+						Ptr:   8,
+						Nested: &gopium.Struct{
+							Name: "test.ptr",
+							Fields: []gopium.Field{
+								{Name: "b", Type: "int64", Size: 8, Align: 8},
+								{Name: "a", Type: "bool", Size: 1, Align: 1},
+								{Name: "c", Type: "bool", Size: 1, Align: 1},
+							},
+						},
+					},
+				},
+			},
+		},
+		"struct with nested structs should be applied to original struct on canceled context": {
+			ctx: cctx,
+			stg: pck,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:   "n",
+						Type:   "struct{a bool}",
+						Size:   1,
+						Align:  1,
+						Nested: &gopium.Struct{Name: "test.n", Fields: []gopium.Field{{Name: "a", Type: "bool", Size: 1, Align: 1}}},
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:   "n",
+						Type:   "struct{a bool}",
+						Size:   1,
+						Align:  1,
+						Nested: &gopium.Struct{Name: "test.n", Fields: []gopium.Field{{Name: "a", Type: "bool", Size: 1, Align: 1}}},
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := nest(tcase.ctx, tcase.stg, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
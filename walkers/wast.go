@@ -36,6 +36,7 @@ var (
 
 // wast defines packages walker ast sync implementation
 type wast struct {
+	matrix    matrix                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	persister gopium.Persister      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	writer    gopium.CategoryWriter `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	parser    gopium.Parser         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
@@ -44,15 +45,16 @@ type wast struct {
 	apply     gopium.Apply          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	deep      bool                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	bref      bool                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_         [38]byte              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_         [14]byte              `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 } // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
 
 // With erich wast walker with external visiting parameters
-// parser, exposer, printer instances and additional visiting flags
-func (w wast) With(xp gopium.Parser, exp gopium.Exposer, p gopium.Printer, deep bool, bref bool) wast {
+// parser, exposer, printer instances, matrix targets and additional visiting flags
+func (w wast) With(xp gopium.Parser, exp gopium.Exposer, p gopium.Printer, mx []Target, deep bool, bref bool) wast {
 	w.parser = xp
 	w.exposer = exp
 	w.printer = p
+	w.matrix = mx
 	w.deep = deep
 	w.bref = bref
 	return w
@@ -63,39 +65,45 @@ func (w wast) With(xp gopium.Parser, exp gopium.Exposer, p gopium.Printer, deep
 // and applies strategy to them to get results,
 // then overrides ast files with astutil helpers
 func (w wast) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strategy) error {
-	// use parser to parse types pkg data
-	// we don't care about fset
-	pkg, loc, err := w.parser.ParseTypes(ctx)
-	if err != nil {
-		return err
-	}
-	// create govisit func
-	// using visit helper
-	// and run it on pkg scope
-	ch := make(appliedCh)
-	gvisit := with(w.exposer, loc, w.bref).
-		visit(regex, stg, ch, w.deep)
 	// prepare separate cancelation
 	// context for visiting
 	gctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	// run visiting in separate goroutine
-	go gvisit(gctx, pkg.Scope())
+	// either on parser package
+	// or on all matrix targets packages
+	ch := make(appliedCh)
+	if err := w.matrix.visit(gctx, w.parser, w.exposer, regex, stg, ch, w.deep, w.bref); err != nil {
+		return err
+	}
 	// prepare struct storage
 	h := collections.NewHierarchic("")
+	fs := fails(ctx)
 	for applied := range ch {
 		// in case any error happened
 		// just return error back
-		// it auto cancels context
+		// it auto cancels context,
+		// unless struct failure is collected
 		if applied.Err != nil {
-			return applied.Err
+			if err := fs.fail(applied.Err); err != nil {
+				return err
+			}
+			continue
+		}
+		// skip structs skipped
+		// by selection filters
+		if applied.Skip != "" {
+			continue
 		}
 		// push struct to storage
 		h.Push(applied.ID, applied.Loc, applied.R)
 	}
 	// run sync write
 	// with collected strategies results
-	return w.write(gctx, h)
+	if err := w.write(gctx, h); err != nil {
+		return err
+	}
+	return fs.err()
 }
 
 // write wast helps to sync and persist
@@ -108,24 +116,25 @@ func (w wast) write(ctx context.Context, h collections.Hierarchic) error {
 	// use parser to parse ast pkg data
 	pkg, loc, err := w.parser.ParseAst(ctx)
 	if err != nil {
-		return err
+		return kind(gopium.ErrParse, err, "")
 	}
 	// run ast apply with strategy result
 	// to update ast.Package
 	// in case any error happened
 	// just return error back
+	rcat := h.Rcat()
 	pkg, err = w.apply(ctx, pkg, loc, h)
 	if err != nil {
-		return err
+		return kind(gopium.ErrFormat, err, rcat)
 	}
 	// add writer root category
 	// in case any error happened
 	// just return error back
-	if err := w.writer.Category(h.Rcat()); err != nil {
-		return err
+	if err := w.writer.Category(rcat); err != nil {
+		return kind(gopium.ErrWrite, err, rcat)
 	}
 	// run persister with printer
 	// in case any error happened
 	// just return error back
-	return w.persister.Persist(ctx, w.printer, w.writer, loc, pkg)
+	return kind(gopium.ErrWrite, w.persister.Persist(ctx, w.printer, w.writer, loc, pkg), rcat)
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/1pkg/gopium/fmtio/astutil"
 	"github.com/1pkg/gopium/gopium"
 	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/tests"
 	"github.com/1pkg/gopium/tests/data"
 	"github.com/1pkg/gopium/tests/mocks"
 	"github.com/1pkg/gopium/typepkg"
@@ -101,7 +103,7 @@ type Single struct {
 			w:   data.Writer{Writer: &mocks.Writer{}},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-1"),
+			err: gopium.Error{Kind: gopium.ErrParse, Err: errors.New("test-1")},
 		},
 		"single struct pkg should visit nothing on ast parser error": {
 			ctx: context.Background(),
@@ -112,7 +114,7 @@ type Single struct {
 			w:   data.Writer{Writer: &mocks.Writer{}},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-2"),
+			err: gopium.Error{Kind: gopium.ErrParse, Err: errors.New("test-2")},
 		},
 		"single struct pkg should visit nothing on strategy error": {
 			ctx: context.Background(),
@@ -123,7 +125,7 @@ type Single struct {
 			w:   data.Writer{Writer: &mocks.Writer{}},
 			stg: &mocks.Strategy{Err: errors.New("test-3")},
 			sts: map[string][]byte{},
-			err: errors.New("test-3"),
+			err: gopium.Error{Kind: gopium.ErrStrategy, Err: errors.New("test-3"), ID: "github.com/1pkg/gopium/tests/data/single:Single", Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "file.go") + ":5:6"},
 		},
 		"single struct pkg should visit nothing on persist error": {
 			ctx: context.Background(),
@@ -134,7 +136,7 @@ type Single struct {
 			w:   data.Writer{Writer: (&mocks.Writer{Gerr: errors.New("test-4")})},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-4"),
+			err: gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-4"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single")},
 		},
 		"single struct pkg should visit nothing on cat persist error": {
 			ctx: context.Background(),
@@ -145,7 +147,7 @@ type Single struct {
 			w:   data.Writer{Writer: (&mocks.Writer{Cerr: errors.New("test-5")})},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-5"),
+			err: gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-5"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single")},
 		},
 		"single struct pkg should visit nothing on apply error": {
 			ctx: context.Background(),
@@ -156,7 +158,7 @@ type Single struct {
 			w:   data.Writer{Writer: &mocks.Writer{}},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-6"),
+			err: gopium.Error{Kind: gopium.ErrFormat, Err: errors.New("test-6"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single")},
 		},
 		"single struct pkg should visit nothing on persister error": {
 			ctx: context.Background(),
@@ -167,7 +169,7 @@ type Single struct {
 			w:   data.Writer{Writer: &mocks.Writer{}},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-7"),
+			err: gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-7"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single")},
 		},
 		"multi structs pkg should visit all expected levels structs with deep": {
 			ctx:  context.Background(),
@@ -355,7 +357,7 @@ type (
 				apply:     tcase.a,
 				persister: tcase.sp,
 				writer:    tcase.w,
-			}.With(tcase.p, m, p, tcase.deep, tcase.bref)
+			}.With(tcase.p, m, p, nil, tcase.deep, tcase.bref)
 			// exec
 			err := wast.Visit(tcase.ctx, tcase.r, tcase.stg)
 			// check
@@ -0,0 +1,139 @@
+package walkers
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of wdeps presets
+var (
+	depsfilemdt = wdeps{
+		fmt:     fmtio.DependenciesMdt,
+		writer:  fmtio.File{Name: gopium.NAME + "_deps", Ext: fmtio.MD},
+		swriter: fmtio.File{Name: gopium.NAME + "_skipped", Ext: fmtio.MD},
+		dwriter: fmtio.File{Name: gopium.NAME + "_diagnostics", Ext: fmtio.MD},
+	}
+)
+
+// wdeps defines packages walker dependencies implementation
+// that writes structs fields dependencies on named structs
+// and shows whether their sizes came from optimized
+// or original structs layouts
+type wdeps struct {
+	writer  gopium.Writer                                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	swriter gopium.Writer                                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	dwriter gopium.Writer                                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	parser  gopium.TypeParser                              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	exposer gopium.Exposer                                 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	fmt     func(collections.Dependencies) ([]byte, error) `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	deep    bool                                           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bref    bool                                           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [38]byte                                       `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
+
+// With erich wdeps walker with external visiting parameters
+// parser, exposer instances and additional visiting flags
+func (w wdeps) With(p gopium.TypeParser, exp gopium.Exposer, deep bool, bref bool) wdeps {
+	w.parser = p
+	w.exposer = exp
+	w.deep = deep
+	w.bref = bref
+	return w
+}
+
+// Visit wdeps implementation uses visit function helper
+// to go through all structs decls inside the package
+// and applies strategy to them in dependency order,
+// then uses dependencies formatter to format structs
+// fields dependencies and use writer to write results to output
+func (w wdeps) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strategy) error {
+	// use parser to parse types pkg data
+	// we don't care about fset
+	pkg, loc, err := w.parser.ParseTypes(ctx)
+	if err != nil {
+		return kind(gopium.ErrParse, err, "")
+	}
+	// create govisit func
+	// using gopium.Visit helper
+	// and run it on pkg scope
+	ch := make(appliedCh)
+	gvisit := with(w.exposer, loc, w.bref).
+		visit(regex, stg, ch, w.deep)
+	// prepare separate cancelation
+	// context for visiting with
+	// strategies diagnostics attached
+	gctx, cancel := context.WithCancel(withDiags(ctx))
+	defer cancel()
+	// run visiting in separate goroutine
+	go gvisit(gctx, pkg.Scope())
+	// prepare struct storage,
+	// structs skipped by selection filters
+	// and structs diagnostics
+	h := collections.NewHierarchic("")
+	ds := make(collections.Dependencies, 0)
+	ss := make(collections.Skips, 0)
+	dgs := make(collections.Diagnostics, 0)
+	fs := fails(ctx)
+	for applied := range ch {
+		// in case any error happened
+		// just return error back
+		// it auto cancels context,
+		// unless struct failure is collected
+		if applied.Err != nil {
+			if err := fs.fail(applied.Err); err != nil {
+				return err
+			}
+			continue
+		}
+		// collect skipped struct
+		if applied.Skip != "" {
+			ss = append(ss, collections.Skip{Struct: collections.Local(applied.ID), Loc: applied.Loc, Filter: applied.Skip})
+			continue
+		}
+		// push struct to storage
+		// and collect its dependencies
+		h.Push(applied.ID, applied.Loc, applied.R)
+		ds = append(ds, applied.Deps...)
+		dgs = append(dgs, applied.Diags...)
+	}
+	// write skipped structs
+	// and diagnostics first
+	if err := wskips(ss, w.swriter); err != nil {
+		return err
+	}
+	if err := wdiags(dgs, w.dwriter); err != nil {
+		return err
+	}
+	// skip empty writes
+	if len(ds) == 0 {
+		return fs.err()
+	}
+	// apply formatter
+	dloc := filepath.Join(h.Rcat(), gopium.NAME)
+	buf, err := w.fmt(ds)
+	// in case any error happened
+	// in formatter return error back
+	if err != nil {
+		return kind(gopium.ErrFormat, err, dloc)
+	}
+	// generate writer
+	wc, err := w.writer.Generate(dloc)
+	if err != nil {
+		return kind(gopium.ErrWrite, err, dloc)
+	}
+	// write results and close writer
+	// in case any error happened
+	// in writer return error
+	if _, err := wc.Write(buf); err != nil {
+		return kind(gopium.ErrWrite, err, dloc)
+	}
+	if err := wc.Close(); err != nil {
+		return kind(gopium.ErrWrite, err, dloc)
+	}
+	return fs.err()
+}
@@ -0,0 +1,206 @@
+package walkers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestWdeps(t *testing.T) {
+	// prepare
+	src := `
+package pkg
+
+type A struct {
+	a bool
+	b int64
+	c bool
+}
+
+type B struct {
+	x A
+	y [2]A
+	n struct {
+		z A
+	}
+	p *A
+	d D
+}
+
+type D struct {
+	a bool
+	b int64
+	c bool
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "pkg/file.go", src, parser.ParseComments)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	pkg, err := (&types.Config{Sizes: types.SizesFor("gc", "amd64")}).Check("pkg", fset, []*ast.File{file}, nil)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	p := typepkg.ParserXToolPackage{Package: &packages.Package{PkgPath: "pkg", Types: pkg, Fset: fset, Syntax: []*ast.File{file}}}
+	m, err := typepkg.NewMavenGoTypes("gc", "amd64", 64, 64, 64)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	pck, err := strategies.Builder{}.Build(strategies.Pack)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	table := map[string]struct {
+		r    *regexp.Regexp
+		p    gopium.TypeParser
+		fmt  func(collections.Dependencies) ([]byte, error)
+		w    *mocks.Writer
+		stg  gopium.Strategy
+		bref bool
+		sts  map[string][]byte
+		err  error
+	}{
+		"pkg with backref should visit dependencies on optimized and original layouts": {
+			r:    regexp.MustCompile(`^[AB]$`),
+			p:    p,
+			fmt:  fmtio.DependenciesMdt,
+			w:    &mocks.Writer{},
+			stg:  pck,
+			bref: true,
+			sts: map[string][]byte{
+				"pkg/gopium": []byte(`
+| Struct Key | Field Name | Dependency Type | Dependency Size | Dependency Align | Dependency Layout |
+| :---: | :---: | :---: | :---: | :---: | :---: |
+| B | x | pkg.A | 16 bytes | 8 bytes | optimized |
+| B | y | pkg.A | 16 bytes | 8 bytes | optimized |
+| B | n.z | pkg.A | 16 bytes | 8 bytes | optimized |
+| B | d | pkg.D | 24 bytes | 8 bytes | original |
+`),
+			},
+		},
+		"pkg without backref should visit dependencies on original layouts": {
+			r:   regexp.MustCompile(`.*`),
+			p:   p,
+			fmt: fmtio.DependenciesMdt,
+			w:   &mocks.Writer{},
+			stg: pck,
+			sts: map[string][]byte{
+				"pkg/gopium": []byte(`
+| Struct Key | Field Name | Dependency Type | Dependency Size | Dependency Align | Dependency Layout |
+| :---: | :---: | :---: | :---: | :---: | :---: |
+| B | x | pkg.A | 24 bytes | 8 bytes | original |
+| B | y | pkg.A | 24 bytes | 8 bytes | original |
+| B | n.z | pkg.A | 24 bytes | 8 bytes | original |
+| B | d | pkg.D | 24 bytes | 8 bytes | original |
+`),
+			},
+		},
+		"pkg without dependencies should visit nothing": {
+			r:   regexp.MustCompile(`^A$`),
+			p:   p,
+			fmt: fmtio.DependenciesMdt,
+			w:   &mocks.Writer{},
+			stg: pck,
+			sts: map[string][]byte{},
+		},
+		"pkg should visit nothing on parser error": {
+			r:   regexp.MustCompile(`.*`),
+			p:   mocks.Parser{Typeserr: errors.New("test-1")},
+			fmt: fmtio.DependenciesMdt,
+			w:   &mocks.Writer{},
+			stg: pck,
+			sts: map[string][]byte{},
+			err: gopium.Error{Kind: gopium.ErrParse, Err: errors.New("test-1")},
+		},
+		"pkg should visit nothing on strategy error": {
+			r:   regexp.MustCompile(`.*`),
+			p:   p,
+			fmt: fmtio.DependenciesMdt,
+			w:   &mocks.Writer{},
+			stg: &mocks.Strategy{Err: errors.New("test-2")},
+			sts: map[string][]byte{},
+			err: gopium.Error{Kind: gopium.ErrStrategy, Err: errors.New("test-2")},
+		},
+		"pkg should visit nothing on formatter error": {
+			r: regexp.MustCompile(`.*`),
+			p: p,
+			fmt: func(collections.Dependencies) ([]byte, error) {
+				return nil, errors.New("test-3")
+			},
+			w:   &mocks.Writer{},
+			stg: pck,
+			sts: map[string][]byte{},
+			err: gopium.Error{Kind: gopium.ErrFormat, Err: errors.New("test-3"), Pos: "pkg/gopium"},
+		},
+		"pkg should visit nothing on writer error": {
+			r:   regexp.MustCompile(`.*`),
+			p:   p,
+			fmt: fmtio.DependenciesMdt,
+			w:   &mocks.Writer{Gerr: errors.New("test-4")},
+			stg: pck,
+			sts: map[string][]byte{},
+			err: gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-4"), Pos: "pkg/gopium"},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			wdeps := wdeps{
+				fmt:    tcase.fmt,
+				writer: tcase.w,
+			}.With(tcase.p, m, false, tcase.bref)
+			// exec
+			err := anonymous(wdeps.Visit(context.Background(), tcase.r, tcase.stg))
+			// check
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// process checks only on success
+			if tcase.err == nil {
+				for id, rwc := range tcase.w.RWCs {
+					// check all struct
+					// against bytes map
+					if st, ok := tcase.sts[id]; ok {
+						// read rwc to buffer
+						var buf bytes.Buffer
+						_, err := buf.ReadFrom(rwc)
+						if !reflect.DeepEqual(err, nil) {
+							t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+						}
+						// format actual and expected identically
+						actual := strings.Trim(string(buf.Bytes()), "\n")
+						expected := strings.Trim(string(st), "\n")
+						if !reflect.DeepEqual(actual, expected) {
+							t.Errorf("id %v actual %v doesn't equal to expected %v", id, actual, expected)
+						}
+						delete(tcase.sts, id)
+					} else {
+						t.Errorf("actual %v doesn't equal to expected %v", id, "")
+					}
+				}
+				// check that map has been drained
+				if !reflect.DeepEqual(tcase.sts, map[string][]byte{}) {
+					t.Errorf("actual %v doesn't equal to expected %v", tcase.sts, map[string][]byte{})
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/1pkg/gopium/gopium"
 	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/tests"
 	"github.com/1pkg/gopium/tests/data"
 	"github.com/1pkg/gopium/tests/mocks"
 	"github.com/1pkg/gopium/typepkg"
@@ -69,39 +71,46 @@ func TestWdiff(t *testing.T) {
 			"Name": "Single",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "A",
 					"Type": "string",
 					"Size": 16,
 					"Align": 8,
+					"Ptr": 8,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "B",
 					"Type": "string",
 					"Size": 16,
 					"Align": 8,
+					"Ptr": 8,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "C",
 					"Type": "string",
 					"Size": 16,
 					"Align": 8,
+					"Ptr": 8,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		}
@@ -111,39 +120,46 @@ func TestWdiff(t *testing.T) {
 			"Name": "Single",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "A",
 					"Type": "string",
 					"Size": 16,
 					"Align": 8,
+					"Ptr": 8,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "B",
 					"Type": "string",
 					"Size": 16,
 					"Align": 8,
+					"Ptr": 8,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "C",
 					"Type": "string",
 					"Size": 16,
 					"Align": 8,
+					"Ptr": 8,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		}
@@ -170,7 +186,7 @@ func TestWdiff(t *testing.T) {
 			w:   data.Writer{Writer: &mocks.Writer{}},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-1"),
+			err: gopium.Error{Kind: gopium.ErrParse, Err: errors.New("test-1")},
 		},
 		"single struct pkg should visit nothing on strategy error": {
 			ctx: context.Background(),
@@ -180,7 +196,7 @@ func TestWdiff(t *testing.T) {
 			w:   data.Writer{Writer: &mocks.Writer{}},
 			stg: &mocks.Strategy{Err: errors.New("test-2")},
 			sts: map[string][]byte{},
-			err: errors.New("test-2"),
+			err: gopium.Error{Kind: gopium.ErrStrategy, Err: errors.New("test-2"), ID: "github.com/1pkg/gopium/tests/data/single:Single", Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "file.go") + ":5:6"},
 		},
 		"single struct pkg should visit nothing on writer error": {
 			ctx: context.Background(),
@@ -190,7 +206,7 @@ func TestWdiff(t *testing.T) {
 			w:   data.Writer{Writer: (&mocks.Writer{Gerr: errors.New("test-3")})},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-3"),
+			err: gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-3"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "gopium")},
 		},
 		"single struct pkg should visit nothing on fmt error": {
 			ctx: context.Background(),
@@ -200,7 +216,7 @@ func TestWdiff(t *testing.T) {
 			w:   data.Writer{Writer: &mocks.Writer{}},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-4"),
+			err: gopium.Error{Kind: gopium.ErrFormat, Err: errors.New("test-4"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "gopium")},
 		},
 		"single struct pkg should visit nothing on writer persist error": {
 			ctx: context.Background(),
@@ -212,7 +228,7 @@ func TestWdiff(t *testing.T) {
 			}})},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-5"),
+			err: gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-5"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "gopium")},
 		},
 		"single struct pkg should visit nothing on writer close error": {
 			ctx: context.Background(),
@@ -224,7 +240,7 @@ func TestWdiff(t *testing.T) {
 			}})},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-6"),
+			err: gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-6"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "gopium")},
 		},
 		"multi structs pkg should visit all expected levels structs with deep": {
 			ctx:  context.Background(),
@@ -242,17 +258,20 @@ func TestWdiff(t *testing.T) {
 			"Name": "A",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "a",
 					"Type": "int64",
 					"Size": 8,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		},
@@ -260,39 +279,46 @@ func TestWdiff(t *testing.T) {
 			"Name": "AZ",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "a",
 					"Type": "bool",
 					"Size": 1,
 					"Align": 1,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "D",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 					"Size": 24,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "z",
 					"Type": "bool",
 					"Size": 1,
 					"Align": 1,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		},
@@ -300,50 +326,59 @@ func TestWdiff(t *testing.T) {
 			"Name": "Zeze",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "ze",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.ze",
 					"Size": 16,
 					"Align": 8,
+					"Ptr": 16,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": true,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "D",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 					"Size": 24,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "AZ",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.AZ",
 					"Size": 33,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "AWA",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 					"Size": 24,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		},
@@ -351,39 +386,46 @@ func TestWdiff(t *testing.T) {
 			"Name": "TestAZ",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "a",
 					"Type": "bool",
 					"Size": 1,
 					"Align": 1,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "D",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.A",
 					"Size": 8,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "z",
 					"Type": "bool",
 					"Size": 1,
 					"Align": 1,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		}
@@ -393,17 +435,20 @@ func TestWdiff(t *testing.T) {
 			"Name": "A",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "a",
 					"Type": "int64",
 					"Size": 8,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		},
@@ -411,39 +456,46 @@ func TestWdiff(t *testing.T) {
 			"Name": "AZ",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "D",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 					"Size": 24,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "a",
 					"Type": "bool",
 					"Size": 1,
 					"Align": 1,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "z",
 					"Type": "bool",
 					"Size": 1,
 					"Align": 1,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		},
@@ -451,50 +503,59 @@ func TestWdiff(t *testing.T) {
 			"Name": "Zeze",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "AZ",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.AZ",
 					"Size": 33,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "D",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 					"Size": 24,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "AWA",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 					"Size": 24,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "ze",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.ze",
 					"Size": 16,
 					"Align": 8,
+					"Ptr": 16,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": true,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		},
@@ -502,39 +563,46 @@ func TestWdiff(t *testing.T) {
 			"Name": "TestAZ",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "D",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.A",
 					"Size": 8,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "a",
 					"Type": "bool",
 					"Size": 1,
 					"Align": 1,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "z",
 					"Type": "bool",
 					"Size": 1,
 					"Align": 1,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		}
@@ -559,17 +627,20 @@ func TestWdiff(t *testing.T) {
 			"Name": "A",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "a",
 					"Type": "int64",
 					"Size": 8,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		},
@@ -577,39 +648,46 @@ func TestWdiff(t *testing.T) {
 			"Name": "AZ",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "a",
 					"Type": "bool",
 					"Size": 1,
 					"Align": 1,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "D",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 					"Size": 24,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "z",
 					"Type": "bool",
 					"Size": 1,
 					"Align": 1,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		},
@@ -617,50 +695,59 @@ func TestWdiff(t *testing.T) {
 			"Name": "Zeze",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "ze",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.ze",
 					"Size": 16,
 					"Align": 8,
+					"Ptr": 16,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": true,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "D",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 					"Size": 24,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "AZ",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.AZ",
 					"Size": 32,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "AWA",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 					"Size": 24,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		}
@@ -670,17 +757,20 @@ func TestWdiff(t *testing.T) {
 			"Name": "A",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "a",
 					"Type": "int64",
 					"Size": 8,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		},
@@ -688,39 +778,46 @@ func TestWdiff(t *testing.T) {
 			"Name": "AZ",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "D",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 					"Size": 24,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "a",
 					"Type": "bool",
 					"Size": 1,
 					"Align": 1,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "z",
 					"Type": "bool",
 					"Size": 1,
 					"Align": 1,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		},
@@ -728,50 +825,59 @@ func TestWdiff(t *testing.T) {
 			"Name": "Zeze",
 			"Doc": null,
 			"Comment": null,
+			"Directives": null,
 			"Fields": [
 				{
 					"Name": "AZ",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.AZ",
 					"Size": 32,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "D",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 					"Size": 24,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "AWA",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 					"Size": 24,
 					"Align": 8,
+					"Ptr": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				},
 				{
 					"Name": "ze",
 					"Type": "github.com/1pkg/gopium/tests/data/multi.ze",
 					"Size": 16,
 					"Align": 8,
+					"Ptr": 16,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": true,
 					"Doc": null,
-					"Comment": null
+					"Comment": null,
+					"Nested": null
 				}
 			]
 		}
@@ -787,7 +893,7 @@ func TestWdiff(t *testing.T) {
 			wdiff := wdiff{
 				fmt:    tcase.fmt,
 				writer: tcase.w,
-			}.With(tcase.p, m, tcase.deep, tcase.bref)
+			}.With(tcase.p, m, nil, tcase.deep, tcase.bref)
 			// exec
 			err := wdiff.Visit(tcase.ctx, tcase.r, tcase.stg)
 			// check
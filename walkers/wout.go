@@ -13,39 +13,66 @@ import (
 // list of wout presets
 var (
 	filejson = wout{
-		fmt:    fmtio.Jsonb,
-		writer: fmtio.File{Name: gopium.NAME, Ext: fmtio.JSON},
+		fmt:      fmtio.Jsonb,
+		writer:   fmtio.File{Name: gopium.NAME, Ext: fmtio.JSON},
+		twriter:  fmtio.File{Name: gopium.NAME + "_test", Ext: fmtio.JSON},
+		swriter:  fmtio.File{Name: gopium.NAME + "_skipped", Ext: fmtio.MD},
+		dwriter:  fmtio.File{Name: gopium.NAME + "_diagnostics", Ext: fmtio.MD},
+		trwriter: fmtio.File{Name: gopium.NAME + "_trace", Ext: fmtio.JSON},
+		trfmt:    fmtio.TraceJsonb,
 	}
 	filexml = wout{
-		fmt:    fmtio.Xmlb,
-		writer: fmtio.File{Name: gopium.NAME, Ext: fmtio.XML},
+		fmt:      fmtio.Xmlb,
+		writer:   fmtio.File{Name: gopium.NAME, Ext: fmtio.XML},
+		twriter:  fmtio.File{Name: gopium.NAME + "_test", Ext: fmtio.XML},
+		swriter:  fmtio.File{Name: gopium.NAME + "_skipped", Ext: fmtio.MD},
+		dwriter:  fmtio.File{Name: gopium.NAME + "_diagnostics", Ext: fmtio.MD},
+		trwriter: fmtio.File{Name: gopium.NAME + "_trace", Ext: fmtio.MD},
+		trfmt:    fmtio.TraceMdt,
 	}
 	filecsv = wout{
-		fmt:    fmtio.Csvb(fmtio.Buffer()),
-		writer: fmtio.File{Name: gopium.NAME, Ext: fmtio.CSV},
+		fmt:      fmtio.Csvb(fmtio.Buffer()),
+		writer:   fmtio.File{Name: gopium.NAME, Ext: fmtio.CSV},
+		twriter:  fmtio.File{Name: gopium.NAME + "_test", Ext: fmtio.CSV},
+		swriter:  fmtio.File{Name: gopium.NAME + "_skipped", Ext: fmtio.MD},
+		dwriter:  fmtio.File{Name: gopium.NAME + "_diagnostics", Ext: fmtio.MD},
+		trwriter: fmtio.File{Name: gopium.NAME + "_trace", Ext: fmtio.MD},
+		trfmt:    fmtio.TraceMdt,
 	}
 	filemdt = wout{
-		fmt:    fmtio.Mdtb,
-		writer: fmtio.File{Name: gopium.NAME, Ext: fmtio.MD},
+		fmt:      fmtio.Mdtb,
+		writer:   fmtio.File{Name: gopium.NAME, Ext: fmtio.MD},
+		twriter:  fmtio.File{Name: gopium.NAME + "_test", Ext: fmtio.MD},
+		swriter:  fmtio.File{Name: gopium.NAME + "_skipped", Ext: fmtio.MD},
+		dwriter:  fmtio.File{Name: gopium.NAME + "_diagnostics", Ext: fmtio.MD},
+		trwriter: fmtio.File{Name: gopium.NAME + "_trace", Ext: fmtio.MD},
+		trfmt:    fmtio.TraceMdt,
 	}
 )
 
 // wout defines packages walker out implementation
 type wout struct {
-	writer  gopium.Writer     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	parser  gopium.TypeParser `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	exposer gopium.Exposer    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	fmt     gopium.Bytes      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	deep    bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	bref    bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_       [6]byte           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+	matrix   matrix                                   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	writer   gopium.Writer                            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	twriter  gopium.Writer                            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	swriter  gopium.Writer                            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	dwriter  gopium.Writer                            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	trwriter gopium.Writer                            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	parser   gopium.TypeParser                        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	exposer  gopium.Exposer                           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	trfmt    func(collections.Stages) ([]byte, error) `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	fmt      gopium.Bytes                             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	deep     bool                                     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bref     bool                                     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_        [38]byte                                 `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 192 bytes; struct align: 8 bytes; struct aligned size: 192 bytes; - 🌺 gopium @1pkg
 
 // With erich wast walker with external visiting parameters
-// parser, exposer instances and additional visiting flags
-func (w wout) With(p gopium.TypeParser, exp gopium.Exposer, deep bool, bref bool) wout {
+// parser, exposer instances, matrix targets and additional visiting flags
+func (w wout) With(p gopium.TypeParser, exp gopium.Exposer, mx []Target, deep bool, bref bool) wout {
 	w.parser = p
 	w.exposer = exp
+	w.matrix = mx
 	w.deep = deep
 	w.bref = bref
 	return w
@@ -55,70 +82,112 @@ func (w wout) With(p gopium.TypeParser, exp gopium.Exposer, deep bool, bref bool
 // to go through all structs decls inside the package
 // and applies strategy to them to get results,
 // then uses bytes formatter to format strategy results
-// and use writer to write results to output
+// and use writer to write results to output,
+// test only structs results are written separately
 func (w wout) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strategy) error {
-	// use parser to parse types pkg data
-	// we don't care about fset
-	pkg, loc, err := w.parser.ParseTypes(ctx)
-	if err != nil {
-		return err
-	}
-	// create govisit func
-	// using gopium.Visit helper
-	// and run it on pkg scope
-	ch := make(appliedCh)
-	gvisit := with(w.exposer, loc, w.bref).
-		visit(regex, stg, ch, w.deep)
 	// prepare separate cancelation
-	// context for visiting
-	gctx, cancel := context.WithCancel(ctx)
+	// context for visiting with
+	// strategies diagnostics attached
+	gctx, cancel := context.WithCancel(withDiags(ctx))
 	defer cancel()
 	// run visiting in separate goroutine
-	go gvisit(gctx, pkg.Scope())
-	// prepare struct storage
-	h := collections.NewHierarchic("")
+	// either on parser package
+	// or on all matrix targets packages
+	ch := make(appliedCh)
+	if err := w.matrix.visit(gctx, w.parser, w.exposer, regex, stg, ch, w.deep, w.bref); err != nil {
+		return err
+	}
+	// prepare struct storages
+	// for non test and test only structs,
+	// structs skipped by selection filters,
+	// structs traced stages and diagnostics
+	h, ht := collections.NewHierarchic(""), collections.NewHierarchic("")
+	ss := make(collections.Skips, 0)
+	trs := make(collections.Stages, 0)
+	dgs := make(collections.Diagnostics, 0)
+	fs := fails(ctx)
 	for applied := range ch {
 		// in case any error happened
 		// just return error back
-		// it auto cancels context
+		// it auto cancels context,
+		// unless struct failure is collected
 		if applied.Err != nil {
-			return applied.Err
+			if err := fs.fail(applied.Err); err != nil {
+				return err
+			}
+			continue
+		}
+		// collect skipped struct
+		if applied.Skip != "" {
+			ss = append(ss, collections.Skip{Struct: collections.Local(applied.ID), Loc: applied.Loc, Filter: applied.Skip})
+			continue
 		}
 		// push struct to storage
-		h.Push(applied.ID, applied.Loc, applied.R)
+		// with generic struct instantiations
+		hs := h
+		if tested(applied.Loc) {
+			hs = ht
+		}
+		hs.Push(applied.ID, applied.Loc, applied.R)
+		trs = append(trs, applied.Stages...)
+		dgs = append(dgs, applied.Diags...)
+		for _, inst := range applied.Insts {
+			hs.Push(inst.ID, inst.Loc, inst.R)
+			trs = append(trs, inst.Stages...)
+			dgs = append(dgs, inst.Diags...)
+		}
 	}
-	// run sync write
+	// run sync writes
 	// with collected strategies results
-	return w.write(gctx, h)
+	if err := w.write(gctx, h, w.writer); err != nil {
+		return err
+	}
+	if err := w.write(gctx, ht, w.twriter); err != nil {
+		return err
+	}
+	if err := wskips(ss, w.swriter); err != nil {
+		return err
+	}
+	if err := wtrace(trs, w.trfmt, w.trwriter); err != nil {
+		return err
+	}
+	if err := wdiags(dgs, w.dwriter); err != nil {
+		return err
+	}
+	return fs.err()
 }
 
 // write wout helps to apply formatter
 // to format strategies result and writer
 // to write result to output
-func (w wout) write(ctx context.Context, h collections.Hierarchic) error {
+func (w wout) write(ctx context.Context, h collections.Hierarchic, writer gopium.Writer) error {
 	// skip empty writes
 	f := h.Flat()
 	if len(f) == 0 {
 		return nil
 	}
 	// apply formatter
+	loc := filepath.Join(h.Rcat(), "gopium")
 	buf, err := w.fmt(f.Sorted())
 	// in case any error happened
 	// in formatter return error back
 	if err != nil {
-		return err
+		return kind(gopium.ErrFormat, err, loc)
 	}
 	// generate writer
-	loc := filepath.Join(h.Rcat(), "gopium")
-	writer, err := w.writer.Generate(loc)
+	// falling back to default writer
+	if writer == nil {
+		writer = w.writer
+	}
+	wc, err := writer.Generate(loc)
 	if err != nil {
-		return err
+		return kind(gopium.ErrWrite, err, loc)
 	}
 	// write results and close writer
 	// in case any error happened
 	// in writer return error
-	if _, err := writer.Write(buf); err != nil {
-		return err
+	if _, err := wc.Write(buf); err != nil {
+		return kind(gopium.ErrWrite, err, loc)
 	}
-	return writer.Close()
+	return kind(gopium.ErrWrite, wc.Close(), loc)
 }
@@ -4,6 +4,11 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
@@ -11,9 +16,12 @@ import (
 
 	"github.com/1pkg/gopium/gopium"
 	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/tests"
 	"github.com/1pkg/gopium/tests/data"
 	"github.com/1pkg/gopium/tests/mocks"
 	"github.com/1pkg/gopium/typepkg"
+
+	"golang.org/x/tools/go/packages"
 )
 
 func TestWout(t *testing.T) {
@@ -68,39 +76,46 @@ func TestWout(t *testing.T) {
 		"Name": "Single",
 		"Doc": null,
 		"Comment": null,
+		"Directives": null,
 		"Fields": [
 			{
 				"Name": "A",
 				"Type": "string",
 				"Size": 16,
 				"Align": 8,
+				"Ptr": 8,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			},
 			{
 				"Name": "B",
 				"Type": "string",
 				"Size": 16,
 				"Align": 8,
+				"Ptr": 8,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			},
 			{
 				"Name": "C",
 				"Type": "string",
 				"Size": 16,
 				"Align": 8,
+				"Ptr": 8,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			}
 		]
 	}
@@ -126,7 +141,7 @@ func TestWout(t *testing.T) {
 			w:   data.Writer{Writer: &mocks.Writer{}},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-1"),
+			err: gopium.Error{Kind: gopium.ErrParse, Err: errors.New("test-1")},
 		},
 		"single struct pkg should visit nothing on strategy error": {
 			ctx: context.Background(),
@@ -136,7 +151,19 @@ func TestWout(t *testing.T) {
 			w:   data.Writer{Writer: &mocks.Writer{}},
 			stg: &mocks.Strategy{Err: errors.New("test-2")},
 			sts: map[string][]byte{},
-			err: errors.New("test-2"),
+			err: gopium.Error{Kind: gopium.ErrStrategy, Err: errors.New("test-2"), ID: "github.com/1pkg/gopium/tests/data/single:Single", Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "file.go") + ":5:6"},
+		},
+		"single struct pkg should collect failures on keep going strategy error": {
+			ctx: gopium.WithKeepGoing(context.Background(), true),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("single"),
+			fmt: mocks.Bytes{}.Bytes,
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: &mocks.Strategy{Err: errors.New("test-2")},
+			sts: map[string][]byte{},
+			err: gopium.Errors{
+				gopium.Error{Kind: gopium.ErrStrategy, Err: errors.New("test-2"), ID: "github.com/1pkg/gopium/tests/data/single:Single", Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "file.go") + ":5:6"},
+			},
 		},
 		"single struct pkg should visit nothing on writer error": {
 			ctx: context.Background(),
@@ -146,7 +173,7 @@ func TestWout(t *testing.T) {
 			w:   data.Writer{Writer: (&mocks.Writer{Gerr: errors.New("test-3")})},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-3"),
+			err: gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-3"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "gopium")},
 		},
 		"single struct pkg should visit nothing on fmt error": {
 			ctx: context.Background(),
@@ -156,7 +183,7 @@ func TestWout(t *testing.T) {
 			w:   data.Writer{Writer: &mocks.Writer{}},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-4"),
+			err: gopium.Error{Kind: gopium.ErrFormat, Err: errors.New("test-4"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "gopium")},
 		},
 		"single struct pkg should visit nothing on writer persist error": {
 			ctx: context.Background(),
@@ -168,7 +195,7 @@ func TestWout(t *testing.T) {
 			}})},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-5"),
+			err: gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-5"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "gopium")},
 		},
 		"single struct pkg should visit nothing on writer close error": {
 			ctx: context.Background(),
@@ -180,7 +207,7 @@ func TestWout(t *testing.T) {
 			}})},
 			stg: np,
 			sts: map[string][]byte{},
-			err: errors.New("test-6"),
+			err: gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-6"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "gopium")},
 		},
 		"multi structs pkg should visit all expected levels structs with deep": {
 			ctx:  context.Background(),
@@ -197,17 +224,20 @@ func TestWout(t *testing.T) {
 		"Name": "A",
 		"Doc": null,
 		"Comment": null,
+		"Directives": null,
 		"Fields": [
 			{
 				"Name": "a",
 				"Type": "int64",
 				"Size": 8,
 				"Align": 8,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			}
 		]
 	},
@@ -215,39 +245,46 @@ func TestWout(t *testing.T) {
 		"Name": "AZ",
 		"Doc": null,
 		"Comment": null,
+		"Directives": null,
 		"Fields": [
 			{
 				"Name": "D",
 				"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 				"Size": 24,
 				"Align": 8,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			},
 			{
 				"Name": "a",
 				"Type": "bool",
 				"Size": 1,
 				"Align": 1,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			},
 			{
 				"Name": "z",
 				"Type": "bool",
 				"Size": 1,
 				"Align": 1,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			}
 		]
 	},
@@ -255,50 +292,59 @@ func TestWout(t *testing.T) {
 		"Name": "Zeze",
 		"Doc": null,
 		"Comment": null,
+		"Directives": null,
 		"Fields": [
 			{
 				"Name": "AZ",
 				"Type": "github.com/1pkg/gopium/tests/data/multi.AZ",
 				"Size": 33,
 				"Align": 8,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": true,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			},
 			{
 				"Name": "D",
 				"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 				"Size": 24,
 				"Align": 8,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": true,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			},
 			{
 				"Name": "AWA",
 				"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 				"Size": 24,
 				"Align": 8,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			},
 			{
 				"Name": "ze",
 				"Type": "github.com/1pkg/gopium/tests/data/multi.ze",
 				"Size": 16,
 				"Align": 8,
+				"Ptr": 16,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": true,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			}
 		]
 	},
@@ -306,39 +352,46 @@ func TestWout(t *testing.T) {
 		"Name": "TestAZ",
 		"Doc": null,
 		"Comment": null,
+		"Directives": null,
 		"Fields": [
 			{
 				"Name": "D",
 				"Type": "github.com/1pkg/gopium/tests/data/multi.A",
 				"Size": 8,
 				"Align": 8,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			},
 			{
 				"Name": "a",
 				"Type": "bool",
 				"Size": 1,
 				"Align": 1,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			},
 			{
 				"Name": "z",
 				"Type": "bool",
 				"Size": 1,
 				"Align": 1,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			}
 		]
 	}
@@ -361,17 +414,20 @@ func TestWout(t *testing.T) {
 		"Name": "A",
 		"Doc": null,
 		"Comment": null,
+		"Directives": null,
 		"Fields": [
 			{
 				"Name": "a",
 				"Type": "int64",
 				"Size": 8,
 				"Align": 8,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			}
 		]
 	},
@@ -379,39 +435,46 @@ func TestWout(t *testing.T) {
 		"Name": "AZ",
 		"Doc": null,
 		"Comment": null,
+		"Directives": null,
 		"Fields": [
 			{
 				"Name": "D",
 				"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 				"Size": 24,
 				"Align": 8,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			},
 			{
 				"Name": "a",
 				"Type": "bool",
 				"Size": 1,
 				"Align": 1,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			},
 			{
 				"Name": "z",
 				"Type": "bool",
 				"Size": 1,
 				"Align": 1,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			}
 		]
 	},
@@ -419,50 +482,59 @@ func TestWout(t *testing.T) {
 		"Name": "Zeze",
 		"Doc": null,
 		"Comment": null,
+		"Directives": null,
 		"Fields": [
 			{
 				"Name": "AZ",
 				"Type": "github.com/1pkg/gopium/tests/data/multi.AZ",
 				"Size": 32,
 				"Align": 8,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": true,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			},
 			{
 				"Name": "D",
 				"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 				"Size": 24,
 				"Align": 8,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": true,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			},
 			{
 				"Name": "AWA",
 				"Type": "github.com/1pkg/gopium/tests/data/multi.D",
 				"Size": 24,
 				"Align": 8,
+				"Ptr": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			},
 			{
 				"Name": "ze",
 				"Type": "github.com/1pkg/gopium/tests/data/multi.ze",
 				"Size": 16,
 				"Align": 8,
+				"Ptr": 16,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": true,
 				"Doc": null,
-				"Comment": null
+				"Comment": null,
+				"Nested": null
 			}
 		]
 	}
@@ -477,7 +549,7 @@ func TestWout(t *testing.T) {
 			wout := wout{
 				fmt:    tcase.fmt,
 				writer: tcase.w,
-			}.With(tcase.p, m, tcase.deep, tcase.bref)
+			}.With(tcase.p, m, nil, tcase.deep, tcase.bref)
 			// exec
 			err := wout.Visit(tcase.ctx, tcase.r, tcase.stg)
 			// check
@@ -516,3 +588,64 @@ func TestWout(t *testing.T) {
 		})
 	}
 }
+
+func TestWoutTested(t *testing.T) {
+	// prepare
+	fset := token.NewFileSet()
+	srcs := map[string]string{
+		filepath.Join("pkg", "file.go"):      "package pkg\n\ntype A struct{ a bool }\n",
+		filepath.Join("pkg", "file_test.go"): "package pkg\n\ntype B struct{ b bool }\n",
+	}
+	files := make([]*ast.File, 0, len(srcs))
+	for name, src := range srcs {
+		file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if !reflect.DeepEqual(err, nil) {
+			t.Fatalf("actual %v doesn't equal to %v", err, nil)
+		}
+		files = append(files, file)
+	}
+	pkg, err := (&types.Config{}).Check("pkg", fset, files, nil)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	m, err := typepkg.NewMavenGoTypes("gc", "amd64", 64, 64, 64)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	b := strategies.Builder{}
+	np, err := b.Build(strategies.Ignore)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	w, tw := &mocks.Writer{}, &mocks.Writer{}
+	walker := wout{
+		writer:  w,
+		twriter: tw,
+		fmt: func(sts []gopium.Struct) ([]byte, error) {
+			snames := make([]string, 0, len(sts))
+			for _, st := range sts {
+				snames = append(snames, st.Name)
+			}
+			return []byte(strings.Join(snames, ",")), nil
+		},
+	}.With(typepkg.ParserXToolPackage{Package: &packages.Package{Types: pkg, Fset: fset, Syntax: files}}, m, nil, false, false)
+	// exec
+	err = walker.Visit(context.Background(), regexp.MustCompile(`.*`), np)
+	// check
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	for writer, expected := range map[*mocks.Writer]string{w: "A", tw: "B"} {
+		rwc, ok := writer.RWCs[filepath.Join("pkg", "gopium")]
+		if !reflect.DeepEqual(ok, true) {
+			t.Fatalf("actual %v doesn't equal to %v", ok, true)
+		}
+		var buf bytes.Buffer
+		if _, err := buf.ReadFrom(rwc); !reflect.DeepEqual(err, nil) {
+			t.Fatalf("actual %v doesn't equal to %v", err, nil)
+		}
+		if actual := buf.String(); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v doesn't equal to expected %v", actual, expected)
+		}
+	}
+}
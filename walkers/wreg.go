@@ -0,0 +1,137 @@
+package walkers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of wreg presets
+var (
+	regress = wreg{
+		fmt:     fmtio.RegressMdt,
+		unlock:  fmtio.Unlockb,
+		reader:  fmtio.File{Name: gopium.NAME, Ext: fmtio.LOCK},
+		writer:  fmtio.Stdout{},
+		dwriter: fmtio.Stdout{},
+	}
+)
+
+// wreg defines packages walker regression implementation
+// that compares struct layouts with layouts baseline
+// and fails if any struct layout regressed
+type wreg struct {
+	reader   gopium.Reader                                                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	writer   gopium.Writer                                                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	dwriter  gopium.Writer                                                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	parser   gopium.TypeParser                                              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	baseline gopium.TypeParser                                              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	exposer  gopium.Exposer                                                 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	fmt      func(collections.Layouts, collections.Layouts) ([]byte, error) `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	unlock   func([]byte) (collections.Layouts, error)                      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	deep     bool                                                           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bref     bool                                                           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_        [14]byte                                                       `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
+
+// With erich wreg walker with external visiting parameters
+// parser, baseline parser, exposer instances and additional visiting flags,
+// in case baseline parser is nil layouts lock is used as baseline
+func (w wreg) With(p gopium.TypeParser, bp gopium.TypeParser, exp gopium.Exposer, deep bool, bref bool) wreg {
+	w.parser = p
+	w.baseline = bp
+	w.exposer = exp
+	w.deep = deep
+	w.bref = bref
+	return w
+}
+
+// Visit wreg implementation uses visit function helper
+// to go through all structs decls inside the package
+// and applies strategy to them to get results,
+// then compares results layouts with baseline layouts
+// and uses writer to write results difference to output,
+// current structs diagnostics are written next to it
+func (w wreg) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strategy) error {
+	// collect current layouts of all structs
+	// with strategies diagnostics attached
+	fs := fails(ctx)
+	lr, dgs, rcat, err := layouts(withDiags(ctx), fs, w.parser, w.exposer, regex, stg, w.deep, w.bref)
+	if err != nil {
+		return err
+	}
+	// skip empty comparisons
+	if len(lr) == 0 {
+		return fs.err()
+	}
+	// collect baseline layouts of all structs
+	lo, err := w.base(ctx, fs, regex, stg, rcat)
+	if err != nil {
+		return err
+	}
+	// apply formatter
+	loc := filepath.Join(rcat, gopium.NAME)
+	buf, err := w.fmt(lo, lr)
+	// in case any error happened
+	// in formatter return error back
+	if err != nil {
+		return kind(gopium.ErrFormat, err, loc)
+	}
+	// generate writer
+	writer, err := w.writer.Generate(loc)
+	if err != nil {
+		return kind(gopium.ErrWrite, err, loc)
+	}
+	// write results and close writer
+	// in case any error happened
+	// in writer return error
+	if _, err := writer.Write(buf); err != nil {
+		return kind(gopium.ErrWrite, err, loc)
+	}
+	if err := writer.Close(); err != nil {
+		return kind(gopium.ErrWrite, err, loc)
+	}
+	if err := wdiags(dgs, w.dwriter); err != nil {
+		return err
+	}
+	// finally check if any struct regressed
+	if keys := lo.Regressed(lr); len(keys) > 0 {
+		return fmt.Errorf("structs layouts regressed %s", strings.Join(keys, ", "))
+	}
+	return fs.err()
+}
+
+// base helps to collect baseline layouts
+// either from baseline parser if any
+// or from layouts lock on root category
+func (w wreg) base(ctx context.Context, fs *failures, regex *regexp.Regexp, stg gopium.Strategy, rcat string) (collections.Layouts, error) {
+	// in case baseline parser provided
+	// visit baseline package the same way
+	if w.baseline != nil {
+		lo, _, _, err := layouts(ctx, fs, w.baseline, w.exposer, regex, stg, w.deep, w.bref)
+		return lo, err
+	}
+	// otherwise read layouts lock
+	loc := filepath.Join(rcat, gopium.NAME)
+	reader, err := w.reader.Open(loc)
+	if err != nil {
+		return nil, kind(gopium.ErrParse, err, loc)
+	}
+	defer reader.Close()
+	buf, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, kind(gopium.ErrParse, err, loc)
+	}
+	lo, err := w.unlock(buf)
+	if err != nil {
+		return nil, kind(gopium.ErrParse, err, loc)
+	}
+	return lo, nil
+}
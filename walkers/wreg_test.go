@@ -0,0 +1,364 @@
+package walkers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/tests"
+	"github.com/1pkg/gopium/tests/data"
+	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
+)
+
+func TestWreg(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b := strategies.Builder{}
+	np, err := b.Build(strategies.Ignore)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	m, err := typepkg.NewMavenGoTypes("gc", "amd64", 64, 64, 64)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	lock := []byte(`
+{
+	"A": {
+		"Fields": [
+			"a"
+		],
+		"Size": 8,
+		"Align": 8,
+		"Ptr": 0,
+		"Pad": 0
+	},
+	"AZ": {
+		"Fields": [
+			"a",
+			"D",
+			"z"
+		],
+		"Size": 40,
+		"Align": 8,
+		"Ptr": 0,
+		"Pad": 14
+	}
+}
+`)
+	plock := []byte(`
+{
+	"AZ": {
+		"Fields": [
+			"D",
+			"a",
+			"z"
+		],
+		"Size": 32,
+		"Align": 8,
+		"Ptr": 0,
+		"Pad": 6
+	}
+}
+`)
+	table := map[string]struct {
+		ctx    context.Context
+		r      *regexp.Regexp
+		p      gopium.TypeParser
+		bp     gopium.TypeParser
+		rd     gopium.Reader
+		fmt    func(collections.Layouts, collections.Layouts) ([]byte, error)
+		unlock func([]byte) (collections.Layouts, error)
+		w      gopium.Writer
+		stg    gopium.Strategy
+		deep   bool
+		bref   bool
+		sts    map[string][]byte
+		err    error
+	}{
+		"empty pkg should visit nothing": {
+			ctx:    context.Background(),
+			r:      regexp.MustCompile(`.*`),
+			p:      data.NewParser("empty"),
+			rd:     mocks.Reader{Buf: []byte(`{}`)},
+			fmt:    mocks.Lock{}.Regress,
+			unlock: mocks.Lock{}.Unlock,
+			w:      data.Writer{Writer: &mocks.Writer{}},
+			stg:    np,
+			sts:    map[string][]byte{},
+		},
+		"multi structs pkg should visit all expected structs against lock": {
+			ctx:    context.Background(),
+			r:      regexp.MustCompile(`^A`),
+			p:      data.NewParser("multi"),
+			rd:     mocks.Reader{Buf: lock},
+			fmt:    mocks.Lock{}.Regress,
+			unlock: mocks.Lock{}.Unlock,
+			w:      data.Writer{Writer: &mocks.Writer{}},
+			stg:    np,
+			sts: map[string][]byte{
+				"tests_data_multi_gopium": []byte(`
+[
+	{
+		"A": {
+			"Fields": [
+				"a"
+			],
+			"Size": 8,
+			"Align": 8,
+			"Ptr": 0,
+			"Pad": 0
+		},
+		"AZ": {
+			"Fields": [
+				"a",
+				"D",
+				"z"
+			],
+			"Size": 40,
+			"Align": 8,
+			"Ptr": 0,
+			"Pad": 14
+		}
+	},
+	{
+		"A": {
+			"Fields": [
+				"a"
+			],
+			"Size": 8,
+			"Align": 8,
+			"Ptr": 0,
+			"Pad": 0
+		},
+		"AZ": {
+			"Fields": [
+				"a",
+				"D",
+				"z"
+			],
+			"Size": 40,
+			"Align": 8,
+			"Ptr": 0,
+			"Pad": 14
+		}
+	}
+]
+`),
+			},
+		},
+		"multi structs pkg should visit all expected structs against baseline": {
+			ctx:    context.Background(),
+			r:      regexp.MustCompile(`^A`),
+			p:      data.NewParser("multi"),
+			bp:     data.NewParser("multi"),
+			rd:     mocks.Reader{Oerr: errors.New("test-1")},
+			fmt:    mocks.Lock{}.Regress,
+			unlock: mocks.Lock{}.Unlock,
+			w:      data.Writer{Writer: &mocks.Writer{}},
+			stg:    np,
+			sts: map[string][]byte{
+				"tests_data_multi_gopium": []byte(`
+[
+	{
+		"A": {
+			"Fields": [
+				"a"
+			],
+			"Size": 8,
+			"Align": 8,
+			"Ptr": 0,
+			"Pad": 0
+		},
+		"AZ": {
+			"Fields": [
+				"a",
+				"D",
+				"z"
+			],
+			"Size": 40,
+			"Align": 8,
+			"Ptr": 0,
+			"Pad": 14
+		}
+	},
+	{
+		"A": {
+			"Fields": [
+				"a"
+			],
+			"Size": 8,
+			"Align": 8,
+			"Ptr": 0,
+			"Pad": 0
+		},
+		"AZ": {
+			"Fields": [
+				"a",
+				"D",
+				"z"
+			],
+			"Size": 40,
+			"Align": 8,
+			"Ptr": 0,
+			"Pad": 14
+		}
+	}
+]
+`),
+			},
+		},
+		"multi structs pkg should return error on regressed structs": {
+			ctx:    context.Background(),
+			r:      regexp.MustCompile(`^A`),
+			p:      data.NewParser("multi"),
+			rd:     mocks.Reader{Buf: plock},
+			fmt:    mocks.Lock{}.Regress,
+			unlock: mocks.Lock{}.Unlock,
+			w:      data.Writer{Writer: &mocks.Writer{}},
+			stg:    np,
+			err:    errors.New("structs layouts regressed AZ"),
+		},
+		"single struct pkg should visit nothing on canceled context": {
+			ctx:    cctx,
+			r:      regexp.MustCompile(`.*`),
+			p:      data.NewParser("single"),
+			rd:     mocks.Reader{Buf: lock},
+			fmt:    mocks.Lock{}.Regress,
+			unlock: mocks.Lock{}.Unlock,
+			w:      data.Writer{Writer: &mocks.Writer{}},
+			stg:    np,
+			sts:    map[string][]byte{},
+			err:    context.Canceled,
+		},
+		"single struct pkg should visit nothing on parser error": {
+			ctx:    context.Background(),
+			r:      regexp.MustCompile(`.*`),
+			p:      mocks.Parser{Typeserr: errors.New("test-1")},
+			rd:     mocks.Reader{Buf: lock},
+			fmt:    mocks.Lock{}.Regress,
+			unlock: mocks.Lock{}.Unlock,
+			w:      data.Writer{Writer: &mocks.Writer{}},
+			stg:    np,
+			sts:    map[string][]byte{},
+			err:    gopium.Error{Kind: gopium.ErrParse, Err: errors.New("test-1")},
+		},
+		"single struct pkg should visit nothing on baseline parser error": {
+			ctx:    context.Background(),
+			r:      regexp.MustCompile(`.*`),
+			p:      data.NewParser("single"),
+			bp:     mocks.Parser{Typeserr: errors.New("test-2")},
+			rd:     mocks.Reader{Buf: lock},
+			fmt:    mocks.Lock{}.Regress,
+			unlock: mocks.Lock{}.Unlock,
+			w:      data.Writer{Writer: &mocks.Writer{}},
+			stg:    np,
+			sts:    map[string][]byte{},
+			err:    gopium.Error{Kind: gopium.ErrParse, Err: errors.New("test-2")},
+		},
+		"single struct pkg should visit nothing on reader error": {
+			ctx:    context.Background(),
+			r:      regexp.MustCompile(`.*`),
+			p:      data.NewParser("single"),
+			rd:     mocks.Reader{Oerr: errors.New("test-3")},
+			fmt:    mocks.Lock{}.Regress,
+			unlock: mocks.Lock{}.Unlock,
+			w:      data.Writer{Writer: &mocks.Writer{}},
+			stg:    np,
+			sts:    map[string][]byte{},
+			err:    gopium.Error{Kind: gopium.ErrParse, Err: errors.New("test-3"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "gopium")},
+		},
+		"single struct pkg should visit nothing on unlock error": {
+			ctx:    context.Background(),
+			r:      regexp.MustCompile(`.*`),
+			p:      data.NewParser("single"),
+			rd:     mocks.Reader{Buf: lock},
+			fmt:    mocks.Lock{}.Regress,
+			unlock: mocks.Lock{Err: errors.New("test-4")}.Unlock,
+			w:      data.Writer{Writer: &mocks.Writer{}},
+			stg:    np,
+			sts:    map[string][]byte{},
+			err:    gopium.Error{Kind: gopium.ErrParse, Err: errors.New("test-4"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "gopium")},
+		},
+		"single struct pkg should visit nothing on formatter error": {
+			ctx:    context.Background(),
+			r:      regexp.MustCompile(`.*`),
+			p:      data.NewParser("single"),
+			rd:     mocks.Reader{Buf: lock},
+			fmt:    mocks.Lock{Err: errors.New("test-5")}.Regress,
+			unlock: mocks.Lock{}.Unlock,
+			w:      data.Writer{Writer: &mocks.Writer{}},
+			stg:    np,
+			sts:    map[string][]byte{},
+			err:    gopium.Error{Kind: gopium.ErrFormat, Err: errors.New("test-5"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "gopium")},
+		},
+		"single struct pkg should visit nothing on writer error": {
+			ctx:    context.Background(),
+			r:      regexp.MustCompile(`.*`),
+			p:      data.NewParser("single"),
+			rd:     mocks.Reader{Buf: lock},
+			fmt:    mocks.Lock{}.Regress,
+			unlock: mocks.Lock{}.Unlock,
+			w:      data.Writer{Writer: &mocks.Writer{Gerr: errors.New("test-6")}},
+			stg:    np,
+			sts:    map[string][]byte{},
+			err:    gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-6"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "gopium")},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			wreg := wreg{
+				fmt:    tcase.fmt,
+				unlock: tcase.unlock,
+				reader: tcase.rd,
+				writer: tcase.w,
+			}.With(tcase.p, tcase.bp, m, tcase.deep, tcase.bref)
+			// exec
+			err := wreg.Visit(tcase.ctx, tcase.r, tcase.stg)
+			// check
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// process checks only on success
+			if tcase.err == nil {
+				w := (tcase.w.(data.Writer)).Writer.(*mocks.Writer)
+				for id, rwc := range w.RWCs {
+					// check all struct
+					// against bytes map
+					if st, ok := tcase.sts[id]; ok {
+						// read rwc to buffer
+						var buf bytes.Buffer
+						_, err := buf.ReadFrom(rwc)
+						if !reflect.DeepEqual(err, nil) {
+							t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+						}
+						// format actual and expected identically
+						actual := strings.Trim(string(buf.Bytes()), "\n")
+						expected := strings.Trim(string(st), "\n")
+						if !reflect.DeepEqual(actual, expected) {
+							t.Errorf("id %v actual %v doesn't equal to expected %v", id, actual, expected)
+						}
+						delete(tcase.sts, id)
+					} else {
+						t.Errorf("actual %v doesn't equal to expected %v", id, "")
+					}
+				}
+				// check that map has been drained
+				if !reflect.DeepEqual(tcase.sts, map[string][]byte{}) {
+					t.Errorf("actual %v doesn't equal to expected %v", tcase.sts, map[string][]byte{})
+				}
+			}
+		})
+	}
+}
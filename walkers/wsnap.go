@@ -0,0 +1,152 @@
+package walkers
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of wsnap presets
+var (
+	snapshot = wsnap{
+		fmt:    fmtio.Lockb,
+		writer: fmtio.File{Name: gopium.NAME, Ext: fmtio.LOCK},
+	}
+)
+
+// wsnap defines packages walker snapshot implementation
+// that writes struct layouts baseline lock
+type wsnap struct {
+	writer  gopium.Writer                             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	parser  gopium.TypeParser                         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	exposer gopium.Exposer                            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	fmt     func(collections.Layouts) ([]byte, error) `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	deep    bool                                      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bref    bool                                      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [6]byte                                   `gopium:"pad;filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+
+// With erich wsnap walker with external visiting parameters
+// parser, exposer instances and additional visiting flags
+func (w wsnap) With(p gopium.TypeParser, exp gopium.Exposer, deep bool, bref bool) wsnap {
+	w.parser = p
+	w.exposer = exp
+	w.deep = deep
+	w.bref = bref
+	return w
+}
+
+// Visit wsnap implementation uses visit function helper
+// to go through all structs decls inside the package
+// and applies strategy to them to collect diagnostics,
+// then uses layouts formatter to format original structs layouts
+// and use writer to write layouts lock to output
+func (w wsnap) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strategy) error {
+	// collect layouts of all structs
+	fs := fails(ctx)
+	ls, _, rcat, err := layouts(ctx, fs, w.parser, w.exposer, regex, stg, w.deep, w.bref)
+	if err != nil {
+		return err
+	}
+	// skip empty writes
+	if len(ls) == 0 {
+		return fs.err()
+	}
+	// apply formatter
+	loc := filepath.Join(rcat, gopium.NAME)
+	buf, err := w.fmt(ls)
+	// in case any error happened
+	// in formatter return error back
+	if err != nil {
+		return kind(gopium.ErrFormat, err, loc)
+	}
+	// generate writer
+	writer, err := w.writer.Generate(loc)
+	if err != nil {
+		return kind(gopium.ErrWrite, err, loc)
+	}
+	// write results and close writer
+	// in case any error happened
+	// in writer return error
+	if _, err := writer.Write(buf); err != nil {
+		return kind(gopium.ErrWrite, err, loc)
+	}
+	if err := writer.Close(); err != nil {
+		return kind(gopium.ErrWrite, err, loc)
+	}
+	return fs.err()
+}
+
+// layouts helps to visit all structs decls inside the package
+// parsed by provided parser and to collect structs original
+// layouts as layouts keyed by struct local identity
+// along with structs diagnostics if any
+// and root category of the package,
+// structs failures are collected by failures collector
+func layouts(
+	ctx context.Context,
+	fs *failures,
+	p gopium.TypeParser,
+	exp gopium.Exposer,
+	regex *regexp.Regexp,
+	stg gopium.Strategy,
+	deep bool,
+	bref bool,
+) (collections.Layouts, collections.Diagnostics, string, error) {
+	// use parser to parse types pkg data
+	// we don't care about fset
+	pkg, loc, err := p.ParseTypes(ctx)
+	if err != nil {
+		return nil, nil, "", kind(gopium.ErrParse, err, "")
+	}
+	// create govisit func
+	// using gopium.Visit helper
+	// and run it on pkg scope
+	ch := make(appliedCh)
+	gvisit := with(exp, loc, bref).
+		visit(regex, stg, ch, deep)
+	// prepare separate cancelation
+	// context for visiting
+	gctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// run visiting in separate goroutine
+	go gvisit(gctx, pkg.Scope())
+	// prepare struct storage
+	// and structs diagnostics
+	h := collections.NewHierarchic("")
+	ls := make(collections.Layouts)
+	dgs := make(collections.Diagnostics, 0)
+	for applied := range ch {
+		// in case any error happened
+		// just return error back
+		// it auto cancels context,
+		// unless struct failure is collected
+		if applied.Err != nil {
+			if err := fs.fail(applied.Err); err != nil {
+				return nil, nil, "", err
+			}
+			continue
+		}
+		// skip structs skipped
+		// by selection filters
+		if applied.Skip != "" {
+			continue
+		}
+		// layouts are stored per package
+		// so local struct identity made of
+		// scope path and struct name is
+		// enough to build the key,
+		// layouts lock existing structs layouts
+		// so original structs are stored
+		// instead of strategy results
+		// push struct to storages
+		h.Push(applied.ID, applied.Loc, applied.O)
+		ls[collections.Local(applied.ID)] = collections.NewLayout(applied.O)
+		dgs = append(dgs, applied.Diags...)
+	}
+	return ls, dgs, h.Rcat(), nil
+}
@@ -0,0 +1,254 @@
+package walkers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/tests"
+	"github.com/1pkg/gopium/tests/data"
+	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
+)
+
+func TestWsnap(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b := strategies.Builder{}
+	np, err := b.Build(strategies.Ignore)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	pck, err := b.Build(strategies.Pack)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	m, err := typepkg.NewMavenGoTypes("gc", "amd64", 64, 64, 64)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	table := map[string]struct {
+		ctx  context.Context
+		r    *regexp.Regexp
+		p    gopium.TypeParser
+		fmt  func(collections.Layouts) ([]byte, error)
+		w    gopium.Writer
+		stg  gopium.Strategy
+		deep bool
+		bref bool
+		sts  map[string][]byte
+		err  error
+	}{
+		"empty pkg should visit nothing": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("empty"),
+			fmt: mocks.Lock{}.Lock,
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: np,
+			sts: map[string][]byte{},
+		},
+		"single struct pkg should visit the struct": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("single"),
+			fmt: mocks.Lock{}.Lock,
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: np,
+			sts: map[string][]byte{
+				"tests_data_single_gopium": []byte(`
+{
+	"Single": {
+		"Fields": [
+			"A",
+			"B",
+			"C"
+		],
+		"Size": 48,
+		"Align": 8,
+		"Ptr": 40,
+		"Pad": 0
+	}
+}
+`),
+			},
+		},
+		"single struct pkg should visit nothing on canceled context": {
+			ctx: cctx,
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("single"),
+			fmt: mocks.Lock{}.Lock,
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: np,
+			sts: map[string][]byte{},
+			err: context.Canceled,
+		},
+		"single struct pkg should visit nothing on parser error": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   mocks.Parser{Typeserr: errors.New("test-1")},
+			fmt: mocks.Lock{}.Lock,
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: np,
+			sts: map[string][]byte{},
+			err: gopium.Error{Kind: gopium.ErrParse, Err: errors.New("test-1")},
+		},
+		"single struct pkg should visit nothing on strategy error": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("single"),
+			fmt: mocks.Lock{}.Lock,
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: &mocks.Strategy{Err: errors.New("test-2")},
+			sts: map[string][]byte{},
+			err: gopium.Error{Kind: gopium.ErrStrategy, Err: errors.New("test-2"), ID: "github.com/1pkg/gopium/tests/data/single:Single", Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "file.go") + ":5:6"},
+		},
+		"single struct pkg should visit nothing on formatter error": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("single"),
+			fmt: mocks.Lock{Err: errors.New("test-3")}.Lock,
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: np,
+			sts: map[string][]byte{},
+			err: gopium.Error{Kind: gopium.ErrFormat, Err: errors.New("test-3"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "gopium")},
+		},
+		"single struct pkg should visit nothing on writer error": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("single"),
+			fmt: mocks.Lock{}.Lock,
+			w:   data.Writer{Writer: &mocks.Writer{Gerr: errors.New("test-4")}},
+			stg: np,
+			sts: map[string][]byte{},
+			err: gopium.Error{Kind: gopium.ErrWrite, Err: errors.New("test-4"), Pos: filepath.Join(tests.Gopium, "tests", "data", "single", "gopium")},
+		},
+		"multi structs pkg should lock all expected levels structs original layouts with deep": {
+			ctx:  context.Background(),
+			r:    regexp.MustCompile(`(?i)^[ab]`),
+			p:    data.NewParser("multi"),
+			fmt:  mocks.Lock{}.Lock,
+			w:    data.Writer{Writer: &mocks.Writer{}},
+			stg:  pck,
+			deep: true,
+			sts: map[string][]byte{
+				"tests_data_multi_gopium": []byte(`
+{
+	"A": {
+		"Fields": [
+			"a"
+		],
+		"Size": 8,
+		"Align": 8,
+		"Ptr": 0,
+		"Pad": 0
+	},
+	"AZ": {
+		"Fields": [
+			"a",
+			"D",
+			"z"
+		],
+		"Size": 40,
+		"Align": 8,
+		"Ptr": 0,
+		"Pad": 14
+	},
+	"b": {
+		"Fields": [
+			"A",
+			"b"
+		],
+		"Size": 16,
+		"Align": 8,
+		"Ptr": 0,
+		"Pad": 0
+	},
+	"scope1.B": {
+		"Fields": [
+			"b"
+		],
+		"Size": 16,
+		"Align": 8,
+		"Ptr": 0,
+		"Pad": 0
+	},
+	"scope1.b1": {
+		"Fields": [
+			"A",
+			"b"
+		],
+		"Size": 16,
+		"Align": 8,
+		"Ptr": 0,
+		"Pad": 0
+	},
+	"scope1.b2": {
+		"Fields": [
+			"A",
+			"b"
+		],
+		"Size": 16,
+		"Align": 8,
+		"Ptr": 0,
+		"Pad": 0
+	}
+}
+`),
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			wsnap := wsnap{
+				fmt:    tcase.fmt,
+				writer: tcase.w,
+			}.With(tcase.p, m, tcase.deep, tcase.bref)
+			// exec
+			err := wsnap.Visit(tcase.ctx, tcase.r, tcase.stg)
+			// check
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// process checks only on success
+			if tcase.err == nil {
+				w := (tcase.w.(data.Writer)).Writer.(*mocks.Writer)
+				for id, rwc := range w.RWCs {
+					// check all struct
+					// against bytes map
+					if st, ok := tcase.sts[id]; ok {
+						// read rwc to buffer
+						var buf bytes.Buffer
+						_, err := buf.ReadFrom(rwc)
+						if !reflect.DeepEqual(err, nil) {
+							t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+						}
+						// format actual and expected identically
+						actual := strings.Trim(string(buf.Bytes()), "\n")
+						expected := strings.Trim(string(st), "\n")
+						if !reflect.DeepEqual(actual, expected) {
+							t.Errorf("id %v actual %v doesn't equal to expected %v", id, actual, expected)
+						}
+						delete(tcase.sts, id)
+					} else {
+						t.Errorf("actual %v doesn't equal to expected %v", id, "")
+					}
+				}
+				// check that map has been drained
+				if !reflect.DeepEqual(tcase.sts, map[string][]byte{}) {
+					t.Errorf("actual %v doesn't equal to expected %v", tcase.sts, map[string][]byte{})
+				}
+			}
+		})
+	}
+}